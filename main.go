@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -9,25 +10,57 @@ import (
 	"term-to-json/parsers"
 )
 
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <parser> [options] [input]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Available parsers:\n")
+	fmt.Fprintf(os.Stderr, "  System: uname, osrelease, lsbrelease, hostnamectl, uptime, who, w, last, lastb, lastlog, id, env, dmesg\n")
+	fmt.Fprintf(os.Stderr, "  Process: ps, free, vmstat\n")
+	fmt.Fprintf(os.Stderr, "  Network: ping, netstat, arp, dig\n")
+	fmt.Fprintf(os.Stderr, "  Files: ls, df, du, mount, lsblk, blkid, fdisk, parted, find, stat\n")
+	fmt.Fprintf(os.Stderr, "  Services: systemctl\n")
+	fmt.Fprintf(os.Stderr, "  Utilities: date, wc\n")
+	fmt.Fprintf(os.Stderr, "  Config: hosts, resolvconf, nsswitch, passwd, group, shadow, gshadow, fstab, crypttab\n")
+	fmt.Fprintf(os.Stderr, "Options:\n")
+	fmt.Fprintf(os.Stderr, "  --passwd FILE  resolve user names and UIDs from a passwd file\n")
+	fmt.Fprintf(os.Stderr, "  --group FILE   resolve group names and GIDs from a group file\n")
+	fmt.Fprintf(os.Stderr, "  --validate     report problems in hosts, fstab and crypttab entries\n")
+	fmt.Fprintf(os.Stderr, "  --index        add a hostname to addresses lookup to hosts output\n")
+	fmt.Fprintf(os.Stderr, "  --tree         nest ls -lR, find -ls and du entries under their directories\n")
+	fmt.Fprintf(os.Stderr, "  --top N        list the N largest du entries\n")
+	fmt.Fprintf(os.Stderr, "  --flags FLAGS  the options wc, id or uname was run with, e.g. -lw, -Gn or -srm\n")
+	fmt.Fprintf(os.Stderr, "  --redact       mask env values of secret-looking variables\n")
+	fmt.Fprintf(os.Stderr, "  --format FMT   the find -printf or stat --format/--printf format of the input\n")
+	fmt.Fprintf(os.Stderr, "  --oui FILE     look up arp MAC address vendors in an IEEE OUI file\n")
+	fmt.Fprintf(os.Stderr, "  --include-hash keep password hashes in shadow and gshadow output\n")
+}
+
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <parser> [input]\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "Available parsers:\n")
-		fmt.Fprintf(os.Stderr, "  System: uname, uptime, who, w, id, env\n")
-		fmt.Fprintf(os.Stderr, "  Process: ps, free, vmstat\n")
-		fmt.Fprintf(os.Stderr, "  Network: ping, netstat, arp, dig\n")
-		fmt.Fprintf(os.Stderr, "  Files: ls, df, du, mount, lsblk, find, stat\n")
-		fmt.Fprintf(os.Stderr, "  Services: systemctl\n")
-		fmt.Fprintf(os.Stderr, "  Utilities: date, wc\n")
-		fmt.Fprintf(os.Stderr, "  Config: hosts, passwd\n")
+		usage()
 		os.Exit(1)
 	}
 
 	parserName := os.Args[1]
+
+	flags := flag.NewFlagSet(parserName, flag.ExitOnError)
+	flags.Usage = usage
+	passwdFile := flags.String("passwd", "", "passwd file used to resolve users")
+	groupFile := flags.String("group", "", "group file used to resolve groups")
+	validate := flags.Bool("validate", false, "report problems in config file entries")
+	index := flags.Bool("index", false, "add a hostname to addresses lookup to hosts output")
+	tree := flags.Bool("tree", false, "nest ls, find and du entries under their directories")
+	top := flags.Int("top", 0, "list the N largest du entries")
+	cmdFlags := flags.String("flags", "", "the options wc, id or uname was run with")
+	redact := flags.Bool("redact", false, "mask env values of secret-looking variables")
+	format := flags.String("format", "", "the find -printf or stat --format format of the input")
+	ouiFile := flags.String("oui", "", "IEEE OUI file used to look up MAC address vendors")
+	includeHash := flags.Bool("include-hash", false, "keep password hashes in shadow and gshadow output")
+	flags.Parse(os.Args[2:])
+
 	var input string
 
-	if len(os.Args) > 2 {
-		input = os.Args[2]
+	if flags.NArg() > 0 {
+		input = flags.Arg(0)
 	} else {
 		// Read from stdin
 		buf, err := os.ReadFile("/dev/stdin")
@@ -37,11 +70,64 @@ func main() {
 		input = string(buf)
 	}
 
-	result, err := parsers.Parse(parserName, input)
+	parser, err := parsers.NewParser(parserName)
 	if err != nil {
 		log.Fatalf("Error parsing: %v", err)
 	}
 
+	switch p := parser.(type) {
+	case *parsers.HostsParser:
+		p.Validate = *validate
+		p.Index = *index
+	case *parsers.FstabParser:
+		p.Validate = *validate
+	case *parsers.CrypttabParser:
+		p.Validate = *validate
+	case *parsers.ShadowParser:
+		p.IncludeHash = *includeHash
+	case *parsers.GshadowParser:
+		p.IncludeHash = *includeHash
+	case *parsers.LsParser:
+		p.Tree = *tree
+	case *parsers.FindParser:
+		p.Tree = *tree
+		p.Format = *format
+	case *parsers.StatParser:
+		p.Format = *format
+	case *parsers.WcParser:
+		p.Flags = *cmdFlags
+	case *parsers.IdParser:
+		p.Flags = *cmdFlags
+	case *parsers.UnameParser:
+		p.Flags = *cmdFlags
+	case *parsers.EnvParser:
+		p.Redact = *redact
+	case *parsers.DuParser:
+		p.Tree = *tree
+		p.Top = *top
+	case *parsers.ArpParser:
+		if *ouiFile != "" {
+			vendors, err := parsers.LoadOUITable(*ouiFile)
+			if err != nil {
+				log.Fatalf("Error loading OUI file: %v", err)
+			}
+			p.Vendors = vendors
+		}
+	}
+
+	result, err := parsers.ParseWith(parser, input)
+	if err != nil {
+		log.Fatalf("Error parsing: %v", err)
+	}
+
+	if *passwdFile != "" || *groupFile != "" {
+		table, err := parsers.LoadIdentityTable(*passwdFile, *groupFile)
+		if err != nil {
+			log.Fatalf("Error loading identity files: %v", err)
+		}
+		result = table.Enrich(result)
+	}
+
 	jsonOutput, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		log.Fatalf("Error marshaling JSON: %v", err)
@@ -2,21 +2,35 @@ package parsers
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
-// ArpParser parses arp command output
-type ArpParser struct{}
+// ArpParser parses arp command output: the net-tools table (arp -n),
+// arp -a on Linux and BSD/macOS, ip neigh and /proc/net/arp. MAC addresses
+// are normalized to lower case colon form. With Vendors set, entries are
+// labelled with the vendor registered for the MAC address prefix.
+type ArpParser struct {
+	Vendors *OUITable
+}
 
 // ArpEntry represents a single ARP table entry
 type ArpEntry struct {
-	Address    string `json:"address"`
-	HWType     string `json:"hw_type,omitempty"`
-	HWAddress  string `json:"hw_address"`
-	Flags      string `json:"flags,omitempty"`
-	Mask       string `json:"mask,omitempty"`
-	Interface  string `json:"interface"`
-	Incomplete bool   `json:"incomplete,omitempty"`
+	Address        string `json:"address"`
+	Hostname       string `json:"hostname,omitempty"`
+	HWType         string `json:"hw_type,omitempty"`
+	HWAddress      string `json:"hw_address"`
+	Vendor         string `json:"vendor,omitempty"`
+	Flags          string `json:"flags,omitempty"`
+	Mask           string `json:"mask,omitempty"`
+	Interface      string `json:"interface"`
+	State          string `json:"state,omitempty"`
+	Incomplete     bool   `json:"incomplete,omitempty"`
+	Permanent      bool   `json:"permanent,omitempty"`
+	Published      bool   `json:"published,omitempty"`
+	Router         bool   `json:"router,omitempty"`
+	ExpiresSeconds *int   `json:"expires_seconds,omitempty"`
 }
 
 func (p *ArpParser) Name() string {
@@ -29,54 +43,249 @@ func (p *ArpParser) Parse(input string) (interface{}, error) {
 		return nil, fmt.Errorf("empty input")
 	}
 
+	// arp -a: "host (192.168.1.1) at aa:bb:cc:dd:ee:ff [ether] on eth0"
+	arpARe := regexp.MustCompile(`^(\S+) \(([^)]+)\) at (\S+)(.*)$`)
+	// ip neigh: "192.168.1.1 dev eth0 lladdr aa:bb:cc:dd:ee:ff REACHABLE"
+	neighRe := regexp.MustCompile(`^\S+ (dev \S+|lladdr \S+|(INCOMPLETE|REACHABLE|STALE|DELAY|PROBE|FAILED|NOARP|PERMANENT)$)`)
+
 	lines := splitLines(input)
 	var entries []ArpEntry
+	var columns []string
+	var starts []int
 
 	for _, line := range lines {
-		// Skip header lines
-		if strings.HasPrefix(line, "Address") ||
-		   strings.Contains(line, "HWtype") ||
-		   strings.Contains(line, "HWaddress") {
+		// Header lines of the net-tools table and /proc/net/arp
+		if strings.HasPrefix(line, "Address") || strings.HasPrefix(line, "IP address") {
+			header := strings.NewReplacer("IP address", "IP_address", "HW type", "HW_type", "HW address", "HW_address").Replace(line)
+			columns = strings.Fields(header)
+			starts = columnStarts(header, columns)
 			continue
 		}
 
-		fields := splitFields(line)
-		if len(fields) < 3 {
+		var entry ArpEntry
+		switch {
+		case arpARe.MatchString(line):
+			entry = parseArpALine(arpARe.FindStringSubmatch(line))
+		case neighRe.MatchString(line):
+			entry = parseIpNeighLine(line)
+		case columns != nil:
+			entry = parseArpTableLine(line, columns, starts)
+		default:
+			entry = parseArpTableLine(line, []string{"Address", "HWtype", "HWaddress", "Flags", "Mask", "Iface"}, nil)
+		}
+		if entry.Address == "" {
 			continue
 		}
 
-		entry := ArpEntry{}
-		entry.Address = fields[0]
-
-		// Check if this is an incomplete entry
-		if strings.Contains(line, "<incomplete>") {
-			entry.Incomplete = true
-			entry.HWAddress = "<incomplete>"
-			// Find interface (usually last field)
-			if len(fields) > 1 {
-				entry.Interface = fields[len(fields)-1]
+		if entry.HWAddress != "" && !entry.Incomplete {
+			entry.HWAddress = normalizeMAC(entry.HWAddress)
+			if p.Vendors != nil {
+				entry.Vendor, _ = p.Vendors.Vendor(entry.HWAddress)
 			}
-		} else {
-			// Standard format: Address HWtype HWaddress Flags Mask Iface
-			if len(fields) >= 2 {
-				entry.HWType = fields[1]
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseArpTableLine parses a row of the net-tools table or /proc/net/arp.
+// The Mask column is usually blank, so values are assigned by position
+// under the header when it is known.
+func parseArpTableLine(line string, columns []string, starts []int) ArpEntry {
+	var values []string
+	if starts != nil {
+		values = alignFields(line, columns, starts)
+	} else {
+		values = splitFields(line)
+	}
+	if len(values) < 3 {
+		return ArpEntry{}
+	}
+
+	entry := ArpEntry{}
+	for i, column := range columns {
+		if i >= len(values) {
+			break
+		}
+		value := values[i]
+		switch column {
+		case "Address", "IP_address":
+			entry.Address = value
+		case "HWtype":
+			entry.HWType = value
+		case "HW_type":
+			// /proc/net/arp gives the ARPHRD type number
+			entry.HWType = value
+			if value == "0x1" {
+				entry.HWType = "ether"
 			}
-			if len(fields) >= 3 {
-				entry.HWAddress = fields[2]
+		case "HWaddress", "HW_address":
+			entry.HWAddress = value
+		case "Flags":
+			entry.Flags = value
+		case "Mask":
+			entry.Mask = value
+		case "Iface", "Device":
+			entry.Interface = value
+		}
+	}
+
+	// A row without a hardware address holds "<incomplete>" in its place
+	if strings.Contains(line, "<incomplete>") || strings.Contains(line, "(incomplete)") {
+		entry.Incomplete = true
+		entry.HWAddress = "<incomplete>"
+		entry.HWType, entry.Flags, entry.Mask = "", "", ""
+		fields := splitFields(line)
+		entry.Interface = fields[len(fields)-1]
+		return entry
+	}
+
+	if flags, err := strconv.ParseUint(entry.Flags, 0, 32); err == nil && strings.HasPrefix(entry.Flags, "0x") {
+		// /proc/net/arp: ATF_COM 0x2, ATF_PERM 0x4, ATF_PUBL 0x8
+		entry.Incomplete = flags&0x2 == 0
+		entry.Permanent = flags&0x4 != 0
+		entry.Published = flags&0x8 != 0
+	} else {
+		// net-tools: C complete, M permanent, P published
+		entry.Incomplete = entry.Flags != "" && !strings.Contains(entry.Flags, "C")
+		entry.Permanent = strings.Contains(entry.Flags, "M")
+		entry.Published = strings.Contains(entry.Flags, "P")
+	}
+	if entry.Incomplete {
+		entry.HWAddress = "<incomplete>"
+	}
+
+	return entry
+}
+
+// parseArpALine parses arp -a output from Linux and BSD/macOS:
+//
+//	? (192.168.1.1) at aa:bb:cc:dd:ee:ff [ether] PERM on eth0
+//	? (192.168.1.1) at 0:1a:2b:3c:4d:5e on en0 ifscope permanent [ethernet]
+//	? (10.0.0.1) at 00:11:22:33:44:55 on em0 expires in 1190 seconds [ethernet]
+func parseArpALine(matches []string) ArpEntry {
+	entry := ArpEntry{
+		Address:   matches[2],
+		HWAddress: matches[3],
+	}
+	if matches[1] != "?" {
+		entry.Hostname = matches[1]
+	}
+	if entry.HWAddress == "<incomplete>" || entry.HWAddress == "(incomplete)" {
+		entry.Incomplete = true
+		entry.HWAddress = "<incomplete>"
+	}
+
+	fields := strings.Fields(matches[4])
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		switch {
+		case strings.HasPrefix(field, "[") && strings.HasSuffix(field, "]"):
+			entry.HWType = strings.Trim(field, "[]")
+		case field == "on" && i+1 < len(fields):
+			entry.Interface = fields[i+1]
+			i++
+		case field == "netmask" && i+1 < len(fields):
+			entry.Mask = fields[i+1]
+			i++
+		case field == "expires" && i+2 < len(fields) && fields[i+1] == "in":
+			if n, err := strconv.Atoi(fields[i+2]); err == nil {
+				entry.ExpiresSeconds = &n
 			}
-			if len(fields) >= 4 {
-				entry.Flags = fields[3]
+			i += 2
+		case field == "PERM" || field == "permanent":
+			entry.Permanent = true
+		case field == "PUB" || field == "published":
+			entry.Published = true
+		}
+	}
+
+	return entry
+}
+
+// parseIpNeighLine parses a line of ip neigh output:
+//
+//	192.168.1.1 dev eth0 lladdr aa:bb:cc:dd:ee:ff router REACHABLE
+//	192.168.1.5 dev eth0 FAILED
+func parseIpNeighLine(line string) ArpEntry {
+	fields := strings.Fields(line)
+	entry := ArpEntry{
+		Address: fields[0],
+	}
+
+	for i := 1; i < len(fields); i++ {
+		switch fields[i] {
+		case "dev":
+			if i+1 < len(fields) {
+				entry.Interface = fields[i+1]
+				i++
 			}
-			if len(fields) >= 5 {
-				entry.Mask = fields[4]
+		case "lladdr":
+			if i+1 < len(fields) {
+				entry.HWAddress = fields[i+1]
+				entry.HWType = "ether"
+				i++
 			}
-			if len(fields) >= 6 {
-				entry.Interface = fields[5]
+		case "router":
+			entry.Router = true
+		case "proxy":
+			entry.Published = true
+		default:
+			if fields[i] == strings.ToUpper(fields[i]) {
+				entry.State = fields[i]
 			}
 		}
+	}
 
-		entries = append(entries, entry)
+	switch entry.State {
+	case "PERMANENT", "NOARP":
+		entry.Permanent = entry.State == "PERMANENT"
+	case "INCOMPLETE", "FAILED":
+		entry.Incomplete = true
+	}
+	if entry.HWAddress == "" {
+		entry.Incomplete = true
+	}
+	if entry.Incomplete {
+		entry.HWAddress = "<incomplete>"
 	}
 
-	return entries, nil
+	return entry
+}
+
+// normalizeMAC converts a MAC address to lower case, colon separated form
+// with two digits per octet. It accepts colon or dash separated octets,
+// including BSD's unpadded "0:1a:2b:3c:4d:5e", and Cisco "001a.2b3c.4d5e".
+// Anything else is returned lower cased.
+func normalizeMAC(mac string) string {
+	lower := strings.ToLower(mac)
+
+	var octets []string
+	switch {
+	case strings.Count(lower, ".") == 2 && len(lower) == 14:
+		hex := strings.ReplaceAll(lower, ".", "")
+		for i := 0; i < len(hex); i += 2 {
+			octets = append(octets, hex[i:i+2])
+		}
+	case strings.ContainsAny(lower, ":-"):
+		octets = strings.FieldsFunc(lower, func(r rune) bool { return r == ':' || r == '-' })
+	default:
+		return lower
+	}
+
+	for i, octet := range octets {
+		if len(octet) == 0 || len(octet) > 2 {
+			return lower
+		}
+		if _, err := strconv.ParseUint(octet, 16, 8); err != nil {
+			return lower
+		}
+		if len(octet) == 1 {
+			octets[i] = "0" + octet
+		}
+	}
+
+	return strings.Join(octets, ":")
 }
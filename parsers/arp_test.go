@@ -91,3 +91,170 @@ func TestArpParserEmpty(t *testing.T) {
 		t.Errorf("Expected 0 entries, got %d", len(entries))
 	}
 }
+
+func TestArpParserTableFlags(t *testing.T) {
+	parser := &ArpParser{}
+
+	testInput := `Address                  HWtype  HWaddress           Flags Mask            Iface
+192.168.1.1              ether   AA:BB:CC:DD:EE:FF   C                     eth0
+192.168.1.2              ether   00:11:22:33:44:55   CM                    eth0
+192.168.1.3              ether   00:11:22:33:44:56   CMP   255.255.255.0   eth1`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]ArpEntry)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].HWAddress != "aa:bb:cc:dd:ee:ff" || entries[0].Interface != "eth0" || entries[0].Mask != "" {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+	if !entries[1].Permanent || entries[1].Published {
+		t.Errorf("Expected permanent entry, got %+v", entries[1])
+	}
+	if !entries[2].Permanent || !entries[2].Published || entries[2].Mask != "255.255.255.0" || entries[2].Interface != "eth1" {
+		t.Errorf("Expected published entry with mask, got %+v", entries[2])
+	}
+}
+
+func TestArpParserArpA(t *testing.T) {
+	parser := &ArpParser{}
+
+	testInput := `? (192.168.1.1) at aa:bb:cc:dd:ee:ff [ether] on eth0
+router.lan (192.168.1.254) at 00:11:22:33:44:55 [ether] PERM PUB on eth0
+? (192.168.1.5) at <incomplete> on eth0
+? (10.0.0.1) at 0:1a:2b:3c:4d:5e on en0 ifscope permanent [ethernet]
+? (10.0.0.2) at (incomplete) on en0 ifscope [ethernet]
+? (10.0.0.3) at 00:11:22:33:44:55 on em0 expires in 1190 seconds [ethernet]`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]ArpEntry)
+	if len(entries) != 6 {
+		t.Fatalf("Expected 6 entries, got %d", len(entries))
+	}
+
+	if entries[0].Address != "192.168.1.1" || entries[0].HWType != "ether" || entries[0].Interface != "eth0" || entries[0].Hostname != "" {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+	if entries[1].Hostname != "router.lan" || !entries[1].Permanent || !entries[1].Published {
+		t.Errorf("Unexpected entry %+v", entries[1])
+	}
+	if !entries[2].Incomplete || entries[2].HWAddress != "<incomplete>" {
+		t.Errorf("Expected incomplete entry, got %+v", entries[2])
+	}
+	if entries[3].HWAddress != "00:1a:2b:3c:4d:5e" || !entries[3].Permanent || entries[3].HWType != "ethernet" || entries[3].Interface != "en0" {
+		t.Errorf("Unexpected BSD entry %+v", entries[3])
+	}
+	if !entries[4].Incomplete {
+		t.Errorf("Expected incomplete BSD entry, got %+v", entries[4])
+	}
+	if entries[5].ExpiresSeconds == nil || *entries[5].ExpiresSeconds != 1190 {
+		t.Errorf("Expected expiry 1190, got %+v", entries[5])
+	}
+}
+
+func TestArpParserIpNeigh(t *testing.T) {
+	parser := &ArpParser{}
+
+	testInput := `192.168.1.1 dev eth0 lladdr aa:bb:cc:dd:ee:ff router REACHABLE
+192.168.1.7 dev eth0 lladdr 00:11:22:33:44:55 STALE
+192.168.1.5 dev eth0 FAILED
+fe80::1 dev eth0 lladdr 00:11:22:33:44:66 router PERMANENT`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]ArpEntry)
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+	if !entries[0].Router || entries[0].State != "REACHABLE" || entries[0].HWAddress != "aa:bb:cc:dd:ee:ff" {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+	if entries[1].State != "STALE" || entries[1].Router {
+		t.Errorf("Unexpected entry %+v", entries[1])
+	}
+	if !entries[2].Incomplete || entries[2].State != "FAILED" {
+		t.Errorf("Expected failed entry, got %+v", entries[2])
+	}
+	if entries[3].Address != "fe80::1" || !entries[3].Permanent {
+		t.Errorf("Unexpected entry %+v", entries[3])
+	}
+}
+
+func TestArpParserProcNetArp(t *testing.T) {
+	parser := &ArpParser{}
+
+	testInput := `IP address       HW type     Flags       HW address            Mask     Device
+192.168.1.1      0x1         0x2         aa:bb:cc:dd:ee:ff     *        eth0
+192.168.1.9      0x1         0x0         00:00:00:00:00:00     *        eth0
+192.168.1.10     0x1         0x6         00:11:22:33:44:55     *        eth1`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]ArpEntry)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].HWType != "ether" || entries[0].HWAddress != "aa:bb:cc:dd:ee:ff" || entries[0].Interface != "eth0" || entries[0].Incomplete {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+	if !entries[1].Incomplete {
+		t.Errorf("Expected incomplete entry, got %+v", entries[1])
+	}
+	if !entries[2].Permanent || entries[2].Interface != "eth1" {
+		t.Errorf("Expected permanent entry, got %+v", entries[2])
+	}
+}
+
+func TestArpParserVendors(t *testing.T) {
+	vendors := NewOUITable(`OUI/MA-L                                                      Organization
+company_id                                                    Organization
+                                                              Address
+
+AA-BB-CC   (hex)		Example Networks, Inc.
+AABBCC     (base 16)		Example Networks, Inc.
+`)
+	parser := &ArpParser{Vendors: vendors}
+
+	result, err := parser.Parse(`? (192.168.1.1) at aa:bb:cc:dd:ee:ff [ether] on eth0
+? (192.168.1.2) at 00:11:22:33:44:55 [ether] on eth0`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]ArpEntry)
+	if entries[0].Vendor != "Example Networks, Inc." {
+		t.Errorf("Expected vendor, got %q", entries[0].Vendor)
+	}
+	if entries[1].Vendor != "" {
+		t.Errorf("Expected no vendor, got %q", entries[1].Vendor)
+	}
+}
+
+func TestNormalizeMAC(t *testing.T) {
+	tests := map[string]string{
+		"AA:BB:CC:DD:EE:FF": "aa:bb:cc:dd:ee:ff",
+		"aa-bb-cc-dd-ee-ff": "aa:bb:cc:dd:ee:ff",
+		"0:1a:2b:3:4d:5e":   "00:1a:2b:03:4d:5e",
+		"001A.2B3C.4D5E":    "00:1a:2b:3c:4d:5e",
+		"<incomplete>":      "<incomplete>",
+	}
+	for input, want := range tests {
+		if got := normalizeMAC(input); got != want {
+			t.Errorf("normalizeMAC(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BlkidParser parses blkid output, both the default
+// `DEVICE: KEY="value" ...` lines and `blkid -o export` blocks
+type BlkidParser struct{}
+
+func (p *BlkidParser) Name() string {
+	return "blkid"
+}
+
+func (p *BlkidParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	pairRe := regexp.MustCompile(`([A-Z_]+)="((?:[^"\\]|\\.)*)"`)
+	exportRe := regexp.MustCompile(`^([A-Z_]+)=(.*)$`)
+
+	entries := []PartitionEntry{}
+
+	if strings.HasPrefix(input, "DEVNAME=") {
+		// blkid -o export: one KEY=value per line, devices separated by blank lines
+		var entry *PartitionEntry
+		for _, line := range strings.Split(input, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				entry = nil
+				continue
+			}
+			matches := exportRe.FindStringSubmatch(line)
+			if matches == nil {
+				continue
+			}
+			if entry == nil {
+				entries = append(entries, PartitionEntry{})
+				entry = &entries[len(entries)-1]
+			}
+			applyBlkidValue(entry, matches[1], strings.ReplaceAll(matches[2], `\ `, " "))
+		}
+		return entries, nil
+	}
+
+	for _, line := range splitLines(input) {
+		device, attributes, found := strings.Cut(line, ": ")
+		if !found {
+			continue
+		}
+
+		entry := PartitionEntry{
+			Device: device,
+		}
+		for _, match := range pairRe.FindAllStringSubmatch(attributes, -1) {
+			applyBlkidValue(&entry, match[1], strings.ReplaceAll(match[2], `\"`, `"`))
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// applyBlkidValue stores a blkid tag, keeping unrecognized tags in Attributes
+func applyBlkidValue(entry *PartitionEntry, key, value string) {
+	switch key {
+	case "DEVNAME":
+		entry.Device = value
+	case "TYPE":
+		entry.FilesystemType = value
+	case "LABEL":
+		entry.Label = value
+	case "UUID":
+		entry.UUID = value
+	case "PARTUUID":
+		entry.PartUUID = value
+	case "PARTLABEL":
+		entry.Name = value
+	default:
+		if entry.Attributes == nil {
+			entry.Attributes = map[string]string{}
+		}
+		entry.Attributes[strings.ToLower(key)] = value
+	}
+}
@@ -0,0 +1,73 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestBlkidParser(t *testing.T) {
+	parser := &BlkidParser{}
+
+	testInput := `/dev/nvme0n1p1: UUID="1A2B-3C4D" BLOCK_SIZE="512" TYPE="vfat" PARTLABEL="EFI System Partition" PARTUUID="0a1b2c3d-4e5f-6789-abcd-ef0123456789"
+/dev/nvme0n1p2: LABEL="My \"Root\"" UUID="0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b" TYPE="ext4" PARTUUID="1b2c3d4e-5f60-7182-93a4-b5c6d7e8f901"
+/dev/sda: PTUUID="5c6d7e8f" PTTYPE="dos"`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]PartitionEntry)
+	if !ok {
+		t.Fatalf("Expected []PartitionEntry, got %T", result)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	efi := entries[0]
+	if efi.Device != "/dev/nvme0n1p1" || efi.UUID != "1A2B-3C4D" || efi.FilesystemType != "vfat" {
+		t.Errorf("Unexpected EFI entry %+v", efi)
+	}
+	if efi.Name != "EFI System Partition" || efi.PartUUID != "0a1b2c3d-4e5f-6789-abcd-ef0123456789" {
+		t.Errorf("Unexpected partition label/uuid %q %q", efi.Name, efi.PartUUID)
+	}
+	if efi.Attributes["block_size"] != "512" {
+		t.Errorf("Expected block_size attribute, got %v", efi.Attributes)
+	}
+
+	if entries[1].Label != `My "Root"` {
+		t.Errorf("Expected unescaped label, got %q", entries[1].Label)
+	}
+	if entries[2].Attributes["pttype"] != "dos" {
+		t.Errorf("Expected pttype attribute, got %v", entries[2].Attributes)
+	}
+}
+
+func TestBlkidParserExport(t *testing.T) {
+	parser := &BlkidParser{}
+
+	testInput := `DEVNAME=/dev/sda1
+UUID=0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b
+LABEL=Data\ Disk
+TYPE=ext4
+
+DEVNAME=/dev/sda2
+UUID=9e8d7c6b-5a49-3827-1605-f4e3d2c1b0a9
+TYPE=swap`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]PartitionEntry)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Device != "/dev/sda1" || entries[0].Label != "Data Disk" || entries[0].FilesystemType != "ext4" {
+		t.Errorf("Unexpected first entry %+v", entries[0])
+	}
+	if entries[1].Device != "/dev/sda2" || entries[1].FilesystemType != "swap" {
+		t.Errorf("Unexpected second entry %+v", entries[1])
+	}
+}
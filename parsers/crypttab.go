@@ -0,0 +1,106 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CrypttabParser parses /etc/crypttab file format. With Validate set, each
+// entry is checked and problems are reported in its Issues field.
+type CrypttabParser struct {
+	Validate bool
+}
+
+// CrypttabEntry represents a single crypttab file entry
+type CrypttabEntry struct {
+	Name           string                 `json:"name,omitempty"`
+	Device         string                 `json:"device,omitempty"`
+	SpecType       string                 `json:"spec_type,omitempty"`
+	SpecValue      string                 `json:"spec_value,omitempty"`
+	KeyFile        string                 `json:"key_file,omitempty"`
+	PasswordPrompt bool                   `json:"password_prompt"`
+	Options        string                 `json:"options,omitempty"`
+	OptionsMap     map[string]interface{} `json:"options_map,omitempty"`
+	Comment        string                 `json:"comment,omitempty"`
+	Original       string                 `json:"original"`
+	Issues         []string               `json:"issues,omitempty"`
+}
+
+func (p *CrypttabParser) Name() string {
+	return "crypttab"
+}
+
+func (p *CrypttabParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	var entries []CrypttabEntry
+	names := map[string]bool{}
+
+	for _, original := range strings.Split(input, "\n") {
+		line := strings.TrimSpace(original)
+
+		// Skip empty lines
+		if line == "" {
+			continue
+		}
+
+		entry := CrypttabEntry{
+			Original: original,
+		}
+
+		// Handle comment-only lines
+		if strings.HasPrefix(line, "#") {
+			entry.Comment = strings.TrimSpace(line[1:])
+			entries = append(entries, entry)
+			continue
+		}
+
+		// Inline comment; a "#" within the options or key file path is kept
+		line, entry.Comment = cutInlineComment(line)
+
+		// crypttab format: name encrypted-device [key-file] [options]
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			if p.Validate {
+				entry.Issues = append(entry.Issues, fmt.Sprintf("expected at least 2 fields, got %d", len(fields)))
+				entries = append(entries, entry)
+			}
+			continue
+		}
+
+		entry.Name = fields[0]
+		entry.Device = fields[1]
+		entry.SpecType, entry.SpecValue = parseBlockSpec(fields[1])
+
+		// A missing key file, "none" or "-" means the passphrase is asked for
+		entry.PasswordPrompt = true
+		if len(fields) > 2 {
+			entry.KeyFile = fields[2]
+			entry.PasswordPrompt = fields[2] == "none" || fields[2] == "-"
+		}
+		if len(fields) > 3 {
+			entry.Options = fields[3]
+			entry.OptionsMap = parseMountOptions(fields[3])
+		}
+
+		if p.Validate {
+			if names[entry.Name] {
+				entry.Issues = append(entry.Issues, fmt.Sprintf("duplicate mapping name %q", entry.Name))
+			}
+			if !entry.PasswordPrompt && !strings.HasPrefix(entry.KeyFile, "/") {
+				entry.Issues = append(entry.Issues, fmt.Sprintf("key file %q is not an absolute path", entry.KeyFile))
+			}
+			if len(fields) > 4 {
+				entry.Issues = append(entry.Issues, fmt.Sprintf("expected at most 4 fields, got %d", len(fields)))
+			}
+			names[entry.Name] = true
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,93 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestCrypttabParser(t *testing.T) {
+	parser := &CrypttabParser{}
+
+	testInput := `# <target name> <source device> <key file> <options>
+cryptroot UUID=9c1b7f0e-2a3d-4c5e-8f90-a1b2c3d4e5f6 none luks,discard
+cryptswap /dev/sda3 /dev/urandom swap,cipher=aes-xts-plain64,size=256
+cryptdata PARTLABEL=data /etc/keys/data.key luks # data volume
+cryptusb /dev/disk/by-id/usb-disk`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]CrypttabEntry)
+	if !ok {
+		t.Fatalf("Expected []CrypttabEntry, got %T", result)
+	}
+
+	if len(entries) != 5 {
+		t.Fatalf("Expected 5 entries, got %d", len(entries))
+	}
+
+	root := entries[1]
+	if root.Name != "cryptroot" || root.SpecType != SpecTypeUUID {
+		t.Errorf("Unexpected root entry %+v", root)
+	}
+	if !root.PasswordPrompt {
+		t.Error("Expected password prompt for key file 'none'")
+	}
+	if root.OptionsMap["luks"] != true || root.OptionsMap["discard"] != true {
+		t.Errorf("Unexpected options %v", root.OptionsMap)
+	}
+
+	swap := entries[2]
+	if swap.KeyFile != "/dev/urandom" || swap.PasswordPrompt {
+		t.Errorf("Unexpected key file %q prompt=%v", swap.KeyFile, swap.PasswordPrompt)
+	}
+	if swap.OptionsMap["cipher"] != "aes-xts-plain64" || swap.OptionsMap["size"] != "256" {
+		t.Errorf("Unexpected options %v", swap.OptionsMap)
+	}
+
+	data := entries[3]
+	if data.SpecType != SpecTypePartLabel || data.SpecValue != "data" {
+		t.Errorf("Unexpected spec %q/%q", data.SpecType, data.SpecValue)
+	}
+	if data.Comment != "data volume" {
+		t.Errorf("Expected comment 'data volume', got %q", data.Comment)
+	}
+
+	usb := entries[4]
+	if usb.SpecType != SpecTypePath || usb.KeyFile != "" || !usb.PasswordPrompt {
+		t.Errorf("Unexpected entry without key file %+v", usb)
+	}
+}
+
+func TestCrypttabParserValidate(t *testing.T) {
+	parser := &CrypttabParser{Validate: true}
+
+	testInput := `cryptroot /dev/sda2 none luks
+cryptroot /dev/sdb2 none luks
+cryptdata /dev/sdc1 keys/data.key luks
+broken`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]CrypttabEntry)
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+
+	if len(entries[0].Issues) != 0 {
+		t.Errorf("Expected no issues, got %v", entries[0].Issues)
+	}
+	if len(entries[1].Issues) != 1 || entries[1].Issues[0] != `duplicate mapping name "cryptroot"` {
+		t.Errorf("Expected duplicate name issue, got %v", entries[1].Issues)
+	}
+	if len(entries[2].Issues) != 1 || entries[2].Issues[0] != `key file "keys/data.key" is not an absolute path` {
+		t.Errorf("Expected key file issue, got %v", entries[2].Issues)
+	}
+	if len(entries[3].Issues) != 1 {
+		t.Errorf("Expected malformed line issue, got %v", entries[3].Issues)
+	}
+}
@@ -6,20 +6,29 @@ import (
 	"strings"
 )
 
-// DfParser parses df command output
+// DfParser parses df command output. Columns are taken from the header, so
+// -h, -T, -i, -P, -B and --output= layouts (and BSD df) are all accepted.
 type DfParser struct{}
 
-// DfEntry represents a single df output entry
+// DfEntry represents a single df output entry. Size, Used and Available are
+// in the block size from the header; the *Bytes fields are always in bytes.
 type DfEntry struct {
-	Filesystem string  `json:"filesystem"`
-	Size       int64   `json:"size"`
-	Used       int64   `json:"used"`
-	Available  int64   `json:"available"`
-	UsePercent int     `json:"use_percent"`
-	MountPoint string  `json:"mount_point"`
-	UsedBytes  int64   `json:"used_bytes"`
-	AvailBytes int64   `json:"avail_bytes"`
-	SizeBytes  int64   `json:"size_bytes"`
+	Filesystem       string `json:"filesystem"`
+	Type             string `json:"type,omitempty"`
+	Size             int64  `json:"size"`
+	Used             int64  `json:"used"`
+	Available        int64  `json:"available"`
+	UsePercent       int    `json:"use_percent"`
+	MountPoint       string `json:"mount_point"`
+	UsedBytes        int64  `json:"used_bytes"`
+	AvailBytes       int64  `json:"avail_bytes"`
+	SizeBytes        int64  `json:"size_bytes"`
+	BlockSize        int64  `json:"block_size,omitempty"`
+	Inodes           *int64 `json:"inodes,omitempty"`
+	InodesUsed       *int64 `json:"inodes_used,omitempty"`
+	InodesFree       *int64 `json:"inodes_free,omitempty"`
+	InodesUsePercent *int   `json:"inodes_use_percent,omitempty"`
+	File             string `json:"file,omitempty"`
 }
 
 func (p *DfParser) Name() string {
@@ -31,55 +40,133 @@ func (p *DfParser) Parse(input string) (interface{}, error) {
 	if input == "" {
 		return nil, fmt.Errorf("empty input")
 	}
-	
+
 	lines := splitLines(input)
 	if len(lines) == 0 {
 		return []DfEntry{}, nil
 	}
 
-	// Skip header line
-	dataLines := lines[1:]
-	var entries []DfEntry
+	header := strings.Replace(lines[0], "Mounted on", "Mounted_on", 1)
+	columns := strings.Fields(header)
+	starts := columnStarts(header, columns)
 
-	for _, line := range dataLines {
-		fields := splitFields(line)
-		
-		if len(fields) < 6 {
-			continue
+	// "1K-blocks", "1024-blocks", "512-blocks", "1M-blocks" or "Size" (-h)
+	blockSize := int64(1024)
+	reportedBlockSize := int64(0)
+	for _, column := range columns {
+		if strings.HasSuffix(column, "-blocks") {
+			if size, err := parseSizeToBytes(strings.TrimSuffix(column, "-blocks")); err == nil {
+				blockSize = size
+			}
+			reportedBlockSize = blockSize
+		} else if column == "Size" {
+			blockSize = 0
 		}
+	}
 
-		entry := DfEntry{}
-		entry.Filesystem = fields[0]
+	entries := []DfEntry{}
+	pending := ""
 
-		// Parse size (in 1K blocks by default)
-		if size, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
-			entry.Size = size
-			entry.SizeBytes = size * 1024
+	for _, line := range lines[1:] {
+		// Long filesystem names push the rest of the row onto the next line
+		if pending != "" {
+			line = pending + " " + line
+			pending = ""
 		}
 
-		// Parse used
-		if used, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
-			entry.Used = used
-			entry.UsedBytes = used * 1024
+		fields := strings.Fields(line)
+		if len(fields) < len(columns) {
+			if len(fields) == 1 {
+				pending = line
+			}
+			continue
 		}
 
-		// Parse available
-		if avail, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
-			entry.Available = avail
-			entry.AvailBytes = avail * 1024
+		values := fields
+		if len(fields) > len(columns) {
+			last := columns[len(columns)-1]
+			if last == "Mounted_on" || last == "File" {
+				// The mount point (or file) may contain spaces
+				values = append(fields[:len(columns)-1:len(columns)-1], strings.Join(fields[len(columns)-1:], " "))
+			} else {
+				values = alignFields(line, columns, starts)
+			}
 		}
 
-		// Parse use percentage
-		usePercentStr := strings.TrimSuffix(fields[4], "%")
-		if usePercent, err := strconv.Atoi(usePercentStr); err == nil {
-			entry.UsePercent = usePercent
+		entry := DfEntry{
+			BlockSize: reportedBlockSize,
+		}
+		for i, column := range columns {
+			applyDfValue(&entry, column, values[i], blockSize)
 		}
-
-		// Mount point
-		entry.MountPoint = fields[5]
 
 		entries = append(entries, entry)
 	}
 
 	return entries, nil
 }
+
+// applyDfValue stores a column value by its header name
+func applyDfValue(entry *DfEntry, column, value string, blockSize int64) {
+	blocks := func(value string) (int64, int64, bool) {
+		if blockSize == 0 {
+			bytes, err := parseSizeToBytes(value)
+			return 0, bytes, err == nil
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		return n, n * blockSize, err == nil
+	}
+	count := func(value string) *int64 {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return &n
+		}
+		return nil
+	}
+	percent := func(value string) (int, bool) {
+		n, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		return n, err == nil
+	}
+
+	switch {
+	case column == "Filesystem":
+		entry.Filesystem = value
+	case column == "Type":
+		entry.Type = value
+	case column == "Size" || strings.HasSuffix(column, "-blocks"):
+		if n, bytes, ok := blocks(value); ok {
+			entry.Size, entry.SizeBytes = n, bytes
+		}
+	case column == "Used":
+		if n, bytes, ok := blocks(value); ok {
+			entry.Used, entry.UsedBytes = n, bytes
+		}
+	case column == "Available" || column == "Avail":
+		if n, bytes, ok := blocks(value); ok {
+			entry.Available, entry.AvailBytes = n, bytes
+		}
+	case column == "Use%" || column == "Capacity":
+		if n, ok := percent(value); ok {
+			entry.UsePercent = n
+		}
+	case column == "Inodes":
+		entry.Inodes = count(value)
+	case column == "IUsed" || column == "iused":
+		entry.InodesUsed = count(value)
+	case column == "IFree" || column == "ifree":
+		entry.InodesFree = count(value)
+	case column == "IUse%" || column == "%iused":
+		if n, ok := percent(value); ok {
+			entry.InodesUsePercent = &n
+		}
+	case column == "File":
+		entry.File = value
+	case column == "Mounted_on":
+		entry.MountPoint = value
+	}
+
+	// BSD df reports used and free inodes only
+	if entry.Inodes == nil && entry.InodesUsed != nil && entry.InodesFree != nil {
+		total := *entry.InodesUsed + *entry.InodesFree
+		entry.Inodes = &total
+	}
+}
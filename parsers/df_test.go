@@ -93,3 +93,127 @@ func TestDfParserEmpty(t *testing.T) {
 		t.Errorf("Expected 0 entries, got %d", len(entries))
 	}
 }
+
+func TestDfParserTypeAndWrapped(t *testing.T) {
+	parser := &DfParser{}
+
+	testInput := `Filesystem                                  Type     1K-blocks     Used Available Use% Mounted on
+/dev/mapper/ubuntu--vg-ubuntu--lv--very--long--name
+                                            ext4      50254368 20123456  27550528  43% /
+tmpfs                                       tmpfs      4096000        0   4096000   0% /run/user/1000
+/dev/sdc1                                   vfat        523248     6220    517028   2% /media/user/USB Stick`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]DfEntry)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	if entries[0].Filesystem != "/dev/mapper/ubuntu--vg-ubuntu--lv--very--long--name" {
+		t.Errorf("Expected wrapped filesystem name, got %q", entries[0].Filesystem)
+	}
+	if entries[0].Type != "ext4" || entries[0].UsePercent != 43 || entries[0].MountPoint != "/" {
+		t.Errorf("Unexpected wrapped entry %+v", entries[0])
+	}
+	if entries[0].BlockSize != 1024 || entries[0].SizeBytes != 50254368*1024 {
+		t.Errorf("Unexpected block size %d / size bytes %d", entries[0].BlockSize, entries[0].SizeBytes)
+	}
+	if entries[2].MountPoint != "/media/user/USB Stick" {
+		t.Errorf("Expected mount point with spaces, got %q", entries[2].MountPoint)
+	}
+}
+
+func TestDfParserHumanReadable(t *testing.T) {
+	parser := &DfParser{}
+
+	testInput := `Filesystem      Size  Used Avail Use% Mounted on
+/dev/sda1        20G  7.5G   12G  40% /`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]DfEntry)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].SizeBytes != 20<<30 || entries[0].UsedBytes != 7.5*(1<<30) || entries[0].AvailBytes != 12<<30 {
+		t.Errorf("Unexpected byte sizes %+v", entries[0])
+	}
+	if entries[0].BlockSize != 0 {
+		t.Errorf("Expected no block size for -h output, got %d", entries[0].BlockSize)
+	}
+}
+
+func TestDfParserInodes(t *testing.T) {
+	parser := &DfParser{}
+
+	testInput := `Filesystem      Inodes  IUsed   IFree IUse% Mounted on
+/dev/sda1      1310720 245678 1065042   19% /
+/dev/sdb1            0      0       0     - /boot/efi`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]DfEntry)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	root := entries[0]
+	if root.Inodes == nil || *root.Inodes != 1310720 || *root.InodesUsed != 245678 || *root.InodesFree != 1065042 {
+		t.Errorf("Unexpected inode counts %+v", root)
+	}
+	if root.InodesUsePercent == nil || *root.InodesUsePercent != 19 {
+		t.Errorf("Expected inode use 19%%, got %v", root.InodesUsePercent)
+	}
+	if root.SizeBytes != 0 || root.BlockSize != 0 {
+		t.Errorf("Expected no block figures in inode mode, got %+v", root)
+	}
+	if entries[1].InodesUsePercent != nil {
+		t.Errorf("Expected no inode use percent for '-', got %d", *entries[1].InodesUsePercent)
+	}
+}
+
+func TestDfParserPortableAndOutput(t *testing.T) {
+	parser := &DfParser{}
+
+	testInput := `Filesystem     512-blocks      Used Available Capacity Mounted on
+/dev/disk1s1    976490576 453625608 512000000      47% /`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]DfEntry)
+	if entries[0].BlockSize != 512 || entries[0].SizeBytes != 976490576*512 || entries[0].UsePercent != 47 {
+		t.Errorf("Unexpected 512-block entry %+v", entries[0])
+	}
+
+	testInput = `Mounted on     Type  Inodes IUsed IFree IUse% 1K-blocks  Used Avail Use% Filesystem
+/              ext4  655360 81234 574126   13%  10218772 4321 5361 45% /dev/sda1`
+
+	result, err = parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries = result.([]DfEntry)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].MountPoint != "/" || entries[0].Filesystem != "/dev/sda1" || entries[0].Type != "ext4" {
+		t.Errorf("Unexpected --output entry %+v", entries[0])
+	}
+	if *entries[0].Inodes != 655360 || entries[0].Available != 5361 || entries[0].UsePercent != 45 {
+		t.Errorf("Unexpected --output values %+v", entries[0])
+	}
+}
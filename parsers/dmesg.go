@@ -0,0 +1,219 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DmesgParser parses dmesg kernel ring buffer output. dmesg -T prints local
+// times without a zone; they are read in Location (UTC when nil).
+type DmesgParser struct {
+	Location *time.Location
+}
+
+// DmesgEntry represents a single kernel log message
+type DmesgEntry struct {
+	Facility  string        `json:"facility,omitempty"`
+	Level     string        `json:"level,omitempty"`
+	Timestamp *float64      `json:"timestamp,omitempty"`
+	Time      *time.Time    `json:"time,omitempty"`
+	Subsystem string        `json:"subsystem,omitempty"`
+	Device    string        `json:"device,omitempty"`
+	Message   string        `json:"message"`
+	Event     string        `json:"event,omitempty"`
+	OOMKill   *DmesgOOMKill `json:"oom_kill,omitempty"`
+	IOError   *DmesgIOError `json:"io_error,omitempty"`
+	Original  string        `json:"original"`
+}
+
+// DmesgOOMKill represents an "Out of memory: Killed process" message
+type DmesgOOMKill struct {
+	PID         int    `json:"pid"`
+	Process     string `json:"process"`
+	TotalVMKB   int64  `json:"total_vm_kb,omitempty"`
+	AnonRSSKB   int64  `json:"anon_rss_kb,omitempty"`
+	FileRSSKB   int64  `json:"file_rss_kb,omitempty"`
+	ShmemRSSKB  int64  `json:"shmem_rss_kb,omitempty"`
+	UID         int    `json:"uid,omitempty"`
+	OOMScoreAdj int    `json:"oom_score_adj,omitempty"`
+	Cgroup      bool   `json:"cgroup,omitempty"`
+}
+
+// DmesgIOError represents a block layer I/O error message
+type DmesgIOError struct {
+	Device    string `json:"device"`
+	Sector    int64  `json:"sector,omitempty"`
+	Operation string `json:"operation,omitempty"`
+}
+
+// Event values reported for well known kernel messages
+const (
+	DmesgEventOOMKill = "oom_kill"
+	DmesgEventIOError = "io_error"
+	DmesgEventFSError = "fs_error"
+)
+
+var dmesgFacilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "res0", "res1", "res2", "res3",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+var dmesgLevels = []string{"emerg", "alert", "crit", "err", "warn", "notice", "info", "debug"}
+
+func (p *DmesgParser) Name() string {
+	return "dmesg"
+}
+
+func (p *DmesgParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	// dmesg -x:  "kern  :info  : [    0.000000] message"
+	prefixRe := regexp.MustCompile(`^([a-z0-9]+)\s*:\s*([a-z]+)\s*:\s?(.*)$`)
+	// dmesg -r:  "<6>[    0.000000] message"
+	rawRe := regexp.MustCompile(`^<(\d+)>(.*)$`)
+	// default:   "[    0.000000] message",  -T: "[Mon Jan 15 14:30:25 2023] message"
+	bracketRe := regexp.MustCompile(`^\[\s*([^\]]*)\]\s?(.*)$`)
+	// --time-format iso: "2023-01-15T14:30:25,123456+00:00 message"
+	isoRe := regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T[0-9:,.]+[+-]\d{2}:?\d{2})\s(.*)$`)
+
+	location := p.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	lines := splitLines(input)
+	var entries []DmesgEntry
+
+	for _, line := range lines {
+		entry := DmesgEntry{
+			Original: line,
+		}
+		rest := line
+
+		if matches := prefixRe.FindStringSubmatch(rest); matches != nil &&
+			findInSlice(dmesgFacilities, matches[1]) >= 0 && findInSlice(dmesgLevels, matches[2]) >= 0 {
+			entry.Facility = matches[1]
+			entry.Level = matches[2]
+			rest = matches[3]
+		} else if matches := rawRe.FindStringSubmatch(rest); matches != nil {
+			if prio, err := strconv.Atoi(matches[1]); err == nil {
+				if facility := prio >> 3; facility < len(dmesgFacilities) {
+					entry.Facility = dmesgFacilities[facility]
+				}
+				entry.Level = dmesgLevels[prio&7]
+			}
+			rest = matches[2]
+		}
+
+		if matches := bracketRe.FindStringSubmatch(rest); matches != nil {
+			stamp := strings.TrimSpace(matches[1])
+			if seconds, err := strconv.ParseFloat(stamp, 64); err == nil {
+				entry.Timestamp = &seconds
+			} else if t, err := time.ParseInLocation("Mon Jan _2 15:04:05 2006", strings.Join(strings.Fields(stamp), " "), location); err == nil {
+				entry.Time = &t
+			}
+			rest = matches[2]
+		} else if matches := isoRe.FindStringSubmatch(rest); matches != nil {
+			if t, err := time.Parse(time.RFC3339Nano, strings.Replace(matches[1], ",", ".", 1)); err == nil {
+				entry.Time = &t
+			}
+			rest = matches[2]
+		}
+
+		entry.Message = strings.TrimSpace(rest)
+		parseDmesgMessage(&entry)
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseDmesgMessage extracts the subsystem, device and well known events from a message
+func parseDmesgMessage(entry *DmesgEntry) {
+	message := entry.Message
+
+	// Out of memory: Killed process 1234 (java) total-vm:123456kB, anon-rss:...
+	// Memory cgroup out of memory: Killed process 1234 (java) ...
+	oomRe := regexp.MustCompile(`(?i)out of memory: Kill(?:ed)? process (\d+) \(([^)]*)\)(.*)$`)
+	if matches := oomRe.FindStringSubmatch(message); matches != nil {
+		kill := &DmesgOOMKill{
+			Process: matches[2],
+			Cgroup:  strings.HasPrefix(message, "Memory cgroup"),
+		}
+		kill.PID, _ = strconv.Atoi(matches[1])
+		details := strings.FieldsFunc(matches[3], func(r rune) bool { return r == ',' || r == ' ' })
+		for _, field := range details {
+			parts := strings.SplitN(field, ":", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			value := strings.TrimSuffix(parts[1], "kB")
+			switch parts[0] {
+			case "total-vm":
+				kill.TotalVMKB, _ = strconv.ParseInt(value, 10, 64)
+			case "anon-rss":
+				kill.AnonRSSKB, _ = strconv.ParseInt(value, 10, 64)
+			case "file-rss":
+				kill.FileRSSKB, _ = strconv.ParseInt(value, 10, 64)
+			case "shmem-rss":
+				kill.ShmemRSSKB, _ = strconv.ParseInt(value, 10, 64)
+			case "UID":
+				kill.UID, _ = strconv.Atoi(value)
+			case "oom_score_adj":
+				kill.OOMScoreAdj, _ = strconv.Atoi(value)
+			}
+		}
+		entry.Event = DmesgEventOOMKill
+		entry.OOMKill = kill
+		entry.Subsystem = "oom"
+		return
+	}
+
+	// blk_update_request: I/O error, dev sda, sector 12345 op 0x0:(READ) ...
+	ioRe := regexp.MustCompile(`I/O error,? dev ([^,\s]+)(?:, sector (\d+))?(?: op 0x[0-9a-fA-F]+:\((\w+)\))?`)
+	if matches := ioRe.FindStringSubmatch(message); matches != nil {
+		ioErr := &DmesgIOError{
+			Device:    matches[1],
+			Operation: matches[3],
+		}
+		if matches[2] != "" {
+			ioErr.Sector, _ = strconv.ParseInt(matches[2], 10, 64)
+		}
+		entry.Event = DmesgEventIOError
+		entry.IOError = ioErr
+		entry.Device = ioErr.Device
+	}
+
+	// EXT4-fs error (device sda1): ext4_find_entry:1455: ...
+	fsErrorRe := regexp.MustCompile(`^(\S+) error \(device ([^)]+)\): (.*)$`)
+	// EXT4-fs (sda1): mounted filesystem with ordered data mode
+	parenRe := regexp.MustCompile(`^(\S+) \(([^)]+)\): (.*)$`)
+	// usb 1-1: new high-speed USB device,  sd 0:0:0:0: [sda] Attached SCSI disk
+	deviceRe := regexp.MustCompile(`^([a-zA-Z][\w-]*) ([\w.:/-]+?): (.*)$`)
+	// ACPI: Early table checksum verification disabled
+	subsystemRe := regexp.MustCompile(`^([\w./-]+): (.*)$`)
+
+	if matches := fsErrorRe.FindStringSubmatch(message); matches != nil {
+		entry.Subsystem = matches[1]
+		entry.Device = matches[2]
+		if entry.Event == "" {
+			entry.Event = DmesgEventFSError
+		}
+	} else if matches := parenRe.FindStringSubmatch(message); matches != nil {
+		entry.Subsystem = matches[1]
+		entry.Device = matches[2]
+	} else if matches := deviceRe.FindStringSubmatch(message); matches != nil {
+		entry.Subsystem = matches[1]
+		entry.Device = matches[2]
+	} else if matches := subsystemRe.FindStringSubmatch(message); matches != nil {
+		entry.Subsystem = matches[1]
+	}
+}
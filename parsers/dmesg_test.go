@@ -0,0 +1,173 @@
+package parsers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDmesgParser(t *testing.T) {
+	parser := &DmesgParser{}
+
+	testInput := `[    0.000000] Linux version 5.15.0-91-generic (buildd@lcy02-amd64-045)
+[    1.234567] ACPI: Early table checksum verification disabled
+[    2.500000] usb 1-1: new high-speed USB device number 2 using xhci_hcd
+[    3.100000] EXT4-fs (sda1): mounted filesystem with ordered data mode. Opts: (null)
+[ 5123.456789] Out of memory: Killed process 1234 (java) total-vm:4194304kB, anon-rss:2097152kB, file-rss:0kB, shmem-rss:0kB, UID:1000 pgtables:4567kB oom_score_adj:0
+[ 6000.000001] blk_update_request: I/O error, dev sdb, sector 123456 op 0x0:(READ) flags 0x0 phys_seg 1 prio class 0
+[ 6001.000000] EXT4-fs error (device sdb1): ext4_find_entry:1455: inode #2: comm ls: reading directory lblock 0`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]DmesgEntry)
+	if !ok {
+		t.Fatalf("Expected []DmesgEntry, got %T", result)
+	}
+
+	if len(entries) != 7 {
+		t.Fatalf("Expected 7 entries, got %d", len(entries))
+	}
+
+	// Test timestamp and message
+	if entries[0].Timestamp == nil || *entries[0].Timestamp != 0 {
+		t.Errorf("Expected timestamp 0, got %v", entries[0].Timestamp)
+	}
+	if entries[1].Timestamp == nil || *entries[1].Timestamp != 1.234567 {
+		t.Errorf("Expected timestamp 1.234567, got %v", entries[1].Timestamp)
+	}
+	if entries[1].Subsystem != "ACPI" {
+		t.Errorf("Expected subsystem 'ACPI', got '%s'", entries[1].Subsystem)
+	}
+
+	// Test subsystem prefixes with devices
+	if entries[2].Subsystem != "usb" || entries[2].Device != "1-1" {
+		t.Errorf("Expected usb 1-1, got %s %s", entries[2].Subsystem, entries[2].Device)
+	}
+	if entries[2].Message != "usb 1-1: new high-speed USB device number 2 using xhci_hcd" {
+		t.Errorf("Unexpected message: %s", entries[2].Message)
+	}
+	if entries[3].Subsystem != "EXT4-fs" || entries[3].Device != "sda1" {
+		t.Errorf("Expected EXT4-fs sda1, got %s %s", entries[3].Subsystem, entries[3].Device)
+	}
+
+	// Test OOM kill
+	oom := entries[4]
+	if oom.Event != DmesgEventOOMKill || oom.OOMKill == nil {
+		t.Fatalf("Expected OOM kill event, got %+v", oom)
+	}
+	if oom.OOMKill.PID != 1234 || oom.OOMKill.Process != "java" {
+		t.Errorf("Expected pid 1234 (java), got %d (%s)", oom.OOMKill.PID, oom.OOMKill.Process)
+	}
+	if oom.OOMKill.TotalVMKB != 4194304 || oom.OOMKill.AnonRSSKB != 2097152 || oom.OOMKill.UID != 1000 {
+		t.Errorf("Unexpected OOM details: %+v", oom.OOMKill)
+	}
+
+	// Test I/O error
+	io := entries[5]
+	if io.Event != DmesgEventIOError || io.IOError == nil {
+		t.Fatalf("Expected I/O error event, got %+v", io)
+	}
+	if io.IOError.Device != "sdb" || io.IOError.Sector != 123456 || io.IOError.Operation != "READ" {
+		t.Errorf("Unexpected I/O error details: %+v", io.IOError)
+	}
+
+	// Test filesystem error
+	if entries[6].Event != DmesgEventFSError || entries[6].Device != "sdb1" {
+		t.Errorf("Expected fs_error on sdb1, got %s on %s", entries[6].Event, entries[6].Device)
+	}
+
+	// Test JSON marshaling
+	jsonData, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("JSON marshal failed: %v", err)
+	}
+
+	if len(jsonData) == 0 {
+		t.Error("JSON output is empty")
+	}
+}
+
+func TestDmesgParserHumanAndLevels(t *testing.T) {
+	parser := &DmesgParser{}
+
+	testInput := `kern  :info  : [Mon Jan 15 14:30:25 2024] usb 2-1: USB disconnect, device number 3
+kern  :err   : [Mon Jan 15 14:31:02 2024] Memory cgroup out of memory: Killed process 4321 (node) total-vm:1024kB
+<3>[   12.000000] nvme0n1: I/O error, dev nvme0n1, sector 2048
+2024-01-15T14:30:25,123456+00:00 e1000e: eth0 NIC Link is Up`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]DmesgEntry)
+	if !ok {
+		t.Fatalf("Expected []DmesgEntry, got %T", result)
+	}
+
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+
+	if entries[0].Facility != "kern" || entries[0].Level != "info" {
+		t.Errorf("Expected kern/info, got %s/%s", entries[0].Facility, entries[0].Level)
+	}
+	if entries[0].Time == nil || entries[0].Time.Format("2006-01-02 15:04:05") != "2024-01-15 14:30:25" {
+		t.Errorf("Unexpected time: %v", entries[0].Time)
+	}
+	if entries[0].Timestamp != nil {
+		t.Errorf("Expected no relative timestamp, got %v", *entries[0].Timestamp)
+	}
+	if entries[0].Subsystem != "usb" || entries[0].Device != "2-1" {
+		t.Errorf("Expected usb 2-1, got %s %s", entries[0].Subsystem, entries[0].Device)
+	}
+
+	if entries[1].Level != "err" || entries[1].OOMKill == nil || !entries[1].OOMKill.Cgroup {
+		t.Errorf("Expected cgroup OOM kill at err level, got %+v", entries[1])
+	}
+
+	if entries[2].Facility != "kern" || entries[2].Level != "err" {
+		t.Errorf("Expected kern/err from <3>, got %s/%s", entries[2].Facility, entries[2].Level)
+	}
+	if entries[2].IOError == nil || entries[2].IOError.Sector != 2048 {
+		t.Errorf("Unexpected I/O error: %+v", entries[2].IOError)
+	}
+
+	if entries[3].Time == nil || entries[3].Time.Nanosecond() != 123456000 {
+		t.Errorf("Unexpected ISO time: %v", entries[3].Time)
+	}
+	if entries[3].Subsystem != "e1000e" || entries[3].Message != "e1000e: eth0 NIC Link is Up" {
+		t.Errorf("Unexpected subsystem/message: %s / %s", entries[3].Subsystem, entries[3].Message)
+	}
+}
+
+func TestDmesgParserLocation(t *testing.T) {
+	parser := &DmesgParser{Location: time.FixedZone("CET", 3600)}
+
+	result, err := parser.Parse("[Mon Jan 15 14:30:25 2024] usb 2-1: USB disconnect, device number 3")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]DmesgEntry)
+	if entries[0].Time == nil || !entries[0].Time.Equal(time.Date(2024, 1, 15, 13, 30, 25, 0, time.UTC)) {
+		t.Errorf("Expected 13:30:25 UTC, got %v", entries[0].Time)
+	}
+}
+
+func TestDmesgParserEmpty(t *testing.T) {
+	parser := &DmesgParser{}
+
+	_, err := parser.Parse("")
+	if err == nil {
+		t.Error("Expected error for empty input")
+	}
+
+	_, err = parser.Parse("   ")
+	if err == nil {
+		t.Error("Expected error for whitespace-only input")
+	}
+}
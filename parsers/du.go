@@ -2,18 +2,39 @@ package parsers
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
-// DuParser parses du command output
-type DuParser struct{}
+// DuParser parses du command output. With Tree set, entries are nested
+// under the nearest listed ancestor directory; with Top set, the largest
+// entries are listed separately. Either option returns a DuOutput instead
+// of the flat entry list.
+type DuParser struct {
+	Tree bool
+	Top  int
+}
 
 // DuEntry represents a single du output entry
 type DuEntry struct {
-	Size      int64  `json:"size"`
-	SizeBytes int64  `json:"size_bytes"`
-	Path      string `json:"path"`
+	Size      int64      `json:"size"`
+	SizeBytes int64      `json:"size_bytes"`
+	Path      string     `json:"path"`
+	Time      *time.Time `json:"time,omitempty"`
+	Percent   float64    `json:"percent"`
+	Total     bool       `json:"total,omitempty"`
+	Children  []DuEntry  `json:"children,omitempty"`
+}
+
+// DuOutput is the tree and top-N report built from du output
+type DuOutput struct {
+	Entries    []DuEntry `json:"entries"`
+	TotalBytes int64     `json:"total_bytes"`
+	Top        []DuEntry `json:"top,omitempty"`
 }
 
 func (p *DuParser) Name() string {
@@ -25,32 +46,226 @@ func (p *DuParser) Parse(input string) (interface{}, error) {
 	if input == "" {
 		return nil, fmt.Errorf("empty input")
 	}
-	
+
+	timeRe := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}( \d{2}:\d{2}(:\d{2}(\.\d+)?( [+-]\d{4})?)?)?$`)
+
 	lines := splitLines(input)
 	var entries []DuEntry
 
 	for _, line := range lines {
-		fields := splitFields(line)
-		
-		// du output format: size path
+		// du separates columns with tabs; fall back to whitespace
+		var fields []string
+		if strings.Contains(line, "\t") {
+			fields = strings.Split(line, "\t")
+		} else {
+			fields = splitFields(line)
+		}
+
+		// du output format: size [time] path
 		if len(fields) < 2 {
 			continue
 		}
 
 		entry := DuEntry{}
 
-		// Parse size (typically in KB by default)
+		// Parse size (typically in KB by default, or human readable with -h)
 		if size, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
 			entry.Size = size
 			// Convert to bytes (assuming KB input by default)
 			entry.SizeBytes = size * 1024
+		} else if bytes, err := parseSizeToBytes(fields[0]); err == nil {
+			entry.SizeBytes = bytes
 		}
 
-		// Path is everything after the first field
-		entry.Path = strings.Join(fields[1:], " ")
+		rest := fields[1:]
+
+		// du --time adds the modification time before the path; without tabs
+		// the date and time of day are separate fields
+		if len(rest) > 2 && !strings.Contains(line, "\t") && timeRe.MatchString(rest[0]+" "+rest[1]) {
+			if t, err := parseDuTime(rest[0] + " " + rest[1]); err == nil {
+				entry.Time = &t
+			}
+			rest = rest[2:]
+		} else if len(rest) > 1 && timeRe.MatchString(rest[0]) {
+			if t, err := parseDuTime(rest[0]); err == nil {
+				entry.Time = &t
+			}
+			rest = rest[1:]
+		}
+
+		// Path is everything after the size (and time) columns
+		if strings.Contains(line, "\t") {
+			entry.Path = strings.Join(rest, "\t")
+		} else {
+			entry.Path = strings.Join(rest, " ")
+		}
+
+		// du -c appends a grand total row
+		entry.Total = entry.Path == "total"
 
 		entries = append(entries, entry)
 	}
 
-	return entries, nil
+	totalBytes := computeDuPercentages(entries)
+
+	if !p.Tree && p.Top <= 0 {
+		return entries, nil
+	}
+
+	output := DuOutput{
+		Entries:    entries,
+		TotalBytes: totalBytes,
+	}
+	if p.Tree {
+		output.Entries = buildDuTree(entries)
+	}
+	if p.Top > 0 {
+		output.Top = topDuEntries(entries, p.Top)
+	}
+	if output.Entries == nil {
+		output.Entries = []DuEntry{}
+	}
+
+	return output, nil
+}
+
+// parseDuTime parses du --time values in the long-iso, full-iso and iso styles
+func parseDuTime(value string) (time.Time, error) {
+	formats := []string{
+		"2006-01-02 15:04",
+		"2006-01-02 15:04:05.999999999 -0700",
+		"2006-01-02 15:04:05",
+		"2006-01-02",
+	}
+
+	for _, format := range formats {
+		if t, err := time.Parse(format, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse time: %s", value)
+}
+
+// computeDuPercentages sets each entry's share of the total and returns the
+// total in bytes: the du -c total row when present, otherwise the sum of the
+// top-level entries
+func computeDuPercentages(entries []DuEntry) int64 {
+	var total int64
+	hasTotal := false
+	for _, entry := range entries {
+		if entry.Total {
+			total = entry.SizeBytes
+			hasTotal = true
+		}
+	}
+
+	if !hasTotal {
+		parents := duParents(entries)
+		for i, entry := range entries {
+			if parents[i] == -1 {
+				total += entry.SizeBytes
+			}
+		}
+	}
+
+	if total > 0 {
+		for i := range entries {
+			entries[i].Percent = float64(entries[i].SizeBytes) * 100 / float64(total)
+		}
+	}
+
+	return total
+}
+
+// duParents returns, for each entry, the index of the nearest listed ancestor
+// directory, or -1 for top-level entries and the total row
+func duParents(entries []DuEntry) []int {
+	index := map[string]int{}
+	for i, entry := range entries {
+		if !entry.Total {
+			index[path.Clean(entry.Path)] = i
+		}
+	}
+
+	parents := make([]int, len(entries))
+	for i, entry := range entries {
+		parents[i] = -1
+		if entry.Total {
+			continue
+		}
+		for dir := path.Clean(entry.Path); dir != "/" && dir != "."; {
+			dir = path.Dir(dir)
+			if j, ok := index[dir]; ok && j != i {
+				parents[i] = j
+				break
+			}
+		}
+	}
+
+	return parents
+}
+
+// buildDuTree nests entries under their parent directories. Children are
+// ordered largest first; the total row is kept as the last top-level entry.
+func buildDuTree(entries []DuEntry) []DuEntry {
+	parents := duParents(entries)
+	children := map[int][]int{}
+	var roots []int
+	for i, parent := range parents {
+		if parent == -1 {
+			roots = append(roots, i)
+		} else {
+			children[parent] = append(children[parent], i)
+		}
+	}
+
+	var build func(indexes []int) []DuEntry
+	build = func(indexes []int) []DuEntry {
+		var nodes []DuEntry
+		for _, i := range indexes {
+			node := entries[i]
+			node.Children = build(children[i])
+			nodes = append(nodes, node)
+		}
+		sort.SliceStable(nodes, func(a, b int) bool {
+			if nodes[a].Total != nodes[b].Total {
+				return !nodes[a].Total
+			}
+			return nodes[a].SizeBytes > nodes[b].SizeBytes
+		})
+		return nodes
+	}
+
+	return build(roots)
+}
+
+// topDuEntries returns the n largest entries. The total row is skipped, as is
+// a single top-level directory, since it always accounts for everything.
+func topDuEntries(entries []DuEntry, n int) []DuEntry {
+	parents := duParents(entries)
+	roots := 0
+	for i, parent := range parents {
+		if parent == -1 && !entries[i].Total {
+			roots++
+		}
+	}
+
+	var candidates []DuEntry
+	for i, entry := range entries {
+		if entry.Total || (roots == 1 && parents[i] == -1) {
+			continue
+		}
+		entry.Children = nil
+		candidates = append(candidates, entry)
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].SizeBytes > candidates[b].SizeBytes
+	})
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+
+	return candidates
 }
@@ -96,3 +96,106 @@ func TestDuParserEmpty(t *testing.T) {
 		t.Errorf("Expected 0 entries, got %d", len(entries))
 	}
 }
+
+func TestDuParserTotalAndPercent(t *testing.T) {
+	parser := &DuParser{}
+
+	testInput := "300\t./a\n100\t./b\n400\t.\n400\ttotal"
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]DuEntry)
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+	if !entries[3].Total || entries[2].Total {
+		t.Errorf("Expected only the last row to be the total")
+	}
+	if entries[0].Percent != 75 || entries[1].Percent != 25 || entries[2].Percent != 100 {
+		t.Errorf("Unexpected percentages %v %v %v", entries[0].Percent, entries[1].Percent, entries[2].Percent)
+	}
+}
+
+func TestDuParserTime(t *testing.T) {
+	parser := &DuParser{}
+
+	testInput := "8\t2024-01-15 10:30\t./docs\n4.0K\t2024-01-14 09:15:42.123456789 +0100\t./my file"
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]DuEntry)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Path != "./docs" || entries[0].Time == nil || entries[0].Time.Hour() != 10 {
+		t.Errorf("Unexpected long-iso entry %+v", entries[0])
+	}
+	if entries[1].Path != "./my file" || entries[1].Time == nil || entries[1].Time.Second() != 42 {
+		t.Errorf("Unexpected full-iso entry %+v", entries[1])
+	}
+	if entries[1].SizeBytes != 4096 {
+		t.Errorf("Expected human readable size 4096 bytes, got %d", entries[1].SizeBytes)
+	}
+
+	result, err = parser.Parse("8 2024-01-15 10:30 ./docs")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if entry := result.([]DuEntry)[0]; entry.Path != "./docs" || entry.Time == nil {
+		t.Errorf("Unexpected space separated entry %+v", entry)
+	}
+}
+
+func TestDuParserTreeAndTop(t *testing.T) {
+	parser := &DuParser{Tree: true, Top: 2}
+
+	testInput := `40	./src/main.go
+60	./src/lib
+100	./src
+150	./data/big.bin
+160	./data
+10	./README.md
+270	.
+270	total`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	output, ok := result.(DuOutput)
+	if !ok {
+		t.Fatalf("Expected DuOutput, got %T", result)
+	}
+	if output.TotalBytes != 270*1024 {
+		t.Errorf("Expected total bytes %d, got %d", 270*1024, output.TotalBytes)
+	}
+
+	if len(output.Entries) != 2 || output.Entries[0].Path != "." || !output.Entries[1].Total {
+		t.Fatalf("Expected root and total row, got %+v", output.Entries)
+	}
+
+	root := output.Entries[0]
+	if len(root.Children) != 3 {
+		t.Fatalf("Expected 3 children of '.', got %d", len(root.Children))
+	}
+	if root.Children[0].Path != "./data" || root.Children[1].Path != "./src" || root.Children[2].Path != "./README.md" {
+		t.Errorf("Expected children ordered by size, got %s %s %s", root.Children[0].Path, root.Children[1].Path, root.Children[2].Path)
+	}
+	if len(root.Children[1].Children) != 2 || root.Children[1].Children[0].Path != "./src/lib" {
+		t.Errorf("Unexpected ./src children %+v", root.Children[1].Children)
+	}
+
+	if len(output.Top) != 2 || output.Top[0].Path != "./data" || output.Top[1].Path != "./data/big.bin" {
+		t.Errorf("Unexpected top entries %+v", output.Top)
+	}
+	if output.Top[0].Children != nil {
+		t.Error("Expected top entries without children")
+	}
+}
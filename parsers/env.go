@@ -2,19 +2,32 @@ package parsers
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
-// EnvParser parses env command output
-type EnvParser struct{}
+// EnvParser parses env command output, NUL separated env -0 and
+// /proc/<pid>/environ input, and the shell forms printed by export -p,
+// declare -p and set. With Redact set, values of variables whose names look
+// like secrets (tokens, passwords, keys) are masked.
+type EnvParser struct {
+	Redact bool
+}
 
 // EnvEntry represents a single environment variable
 type EnvEntry struct {
-	Name     string `json:"name"`
-	Value    string `json:"value"`
-	Original string `json:"original"`
+	Name       string            `json:"name"`
+	Value      string            `json:"value"`
+	Attributes string            `json:"attributes,omitempty"`
+	Elements   map[string]string `json:"elements,omitempty"`
+	Redacted   bool              `json:"redacted,omitempty"`
+	Original   string            `json:"original"`
 }
 
+// envRedacted replaces the value of redacted variables
+const envRedacted = "[REDACTED]"
+
 func (p *EnvParser) Name() string {
 	return "env"
 }
@@ -25,34 +38,289 @@ func (p *EnvParser) Parse(input string) (interface{}, error) {
 		return nil, fmt.Errorf("empty input")
 	}
 
-	lines := strings.Split(input, "\n")
+	// Shell forms: declare/export lines, arrays, functions and, for plain
+	// set output, values in 'single' or $'ANSI-C' quotes
+	shellRe := regexp.MustCompile(`(?m)^(declare|typeset|export|readonly) |^[A-Za-z_]\w*=\(\[|^[^\s=()]+ \(\) *$|^[A-Za-z_]\w*=\$?'`)
+
+	var entries []EnvEntry
+	switch {
+	case strings.Contains(input, "\x00"):
+		entries = parseEnvNul(input)
+	case shellRe.MatchString(input):
+		entries = parseShellEnv(input)
+	default:
+		entries = parseEnvLines(input)
+	}
+
+	if p.Redact {
+		for i := range entries {
+			redactEnvEntry(&entries[i])
+		}
+	}
+
+	return entries, nil
+}
+
+// parseEnvNul parses NUL separated NAME=VALUE pairs, whose values may
+// contain newlines
+func parseEnvNul(input string) []EnvEntry {
+	var entries []EnvEntry
+	for _, pair := range strings.Split(input, "\x00") {
+		if pair == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(pair, "=")
+		entries = append(entries, EnvEntry{
+			Name:     name,
+			Value:    value,
+			Original: pair,
+		})
+	}
+	return entries
+}
+
+// parseEnvLines parses plain env output. A line that does not start a new
+// NAME=VALUE pair continues the value of the previous variable, so values
+// with embedded newlines (certificates, JSON) are kept whole.
+func parseEnvLines(input string) []EnvEntry {
+	nameRe := regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*|BASH_FUNC_[^=\s]+)=`)
+
 	var entries []EnvEntry
+	for _, line := range strings.Split(input, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if len(entries) > 0 && !nameRe.MatchString(line) {
+			last := &entries[len(entries)-1]
+			last.Value += "\n" + line
+			last.Original += "\n" + line
+			continue
+		}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		
-		// Skip empty lines
-		if line == "" {
+		if strings.TrimSpace(line) == "" {
 			continue
 		}
 
-		entry := EnvEntry{
+		// Environment variables are in format NAME=VALUE; a line without an
+		// equals sign is taken as a name with an empty value
+		name, value, _ := strings.Cut(line, "=")
+		entries = append(entries, EnvEntry{
+			Name:     name,
+			Value:    value,
 			Original: line,
+		})
+	}
+
+	// Blank lines between variables are not part of a value
+	for i := range entries {
+		entries[i].Value = strings.TrimRight(entries[i].Value, "\n")
+		entries[i].Original = strings.TrimRight(entries[i].Original, "\n")
+	}
+
+	return entries
+}
+
+// parseShellEnv parses export -p, declare -p and set output. Values are
+// shell quoted and may span lines; arrays are written as ([0]="a" [1]="b").
+// Function definitions printed by set and declare -f are skipped.
+func parseShellEnv(input string) []EnvEntry {
+	nameRe := regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`)
+	funcRe := regexp.MustCompile(`^[^\s=()]+ \(\) *$`)
+
+	var entries []EnvEntry
+	rest := input
+
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n")
+		if rest == "" {
+			break
 		}
 
-		// Environment variables are in format NAME=VALUE
-		eqIdx := strings.Index(line, "=")
-		if eqIdx == -1 {
-			// No equals sign, treat whole line as name with empty value
-			entry.Name = line
-			entry.Value = ""
-		} else {
-			entry.Name = line[:eqIdx]
-			entry.Value = line[eqIdx+1:]
+		line, _, _ := strings.Cut(rest, "\n")
+		if funcRe.MatchString(strings.TrimSpace(line)) {
+			// Skip the body up to the closing brace on its own line
+			for rest != "" {
+				line, rest, _ = strings.Cut(rest, "\n")
+				if strings.TrimSpace(line) == "}" {
+					break
+				}
+			}
+			continue
 		}
 
+		start := rest
+		entry := EnvEntry{}
+
+		for _, keyword := range []string{"declare ", "typeset ", "export ", "readonly "} {
+			if !strings.HasPrefix(rest, keyword) {
+				continue
+			}
+			rest = rest[len(keyword):]
+			switch keyword {
+			case "export ":
+				entry.Attributes = "x"
+			case "readonly ":
+				entry.Attributes = "r"
+			}
+			// Options such as -x, -rx or --
+			for {
+				rest = strings.TrimLeft(rest, " \t")
+				if !strings.HasPrefix(rest, "-") {
+					break
+				}
+				option, remainder, _ := strings.Cut(rest, " ")
+				if option != "--" {
+					entry.Attributes += strings.TrimPrefix(option, "-")
+				}
+				rest = remainder
+			}
+			break
+		}
+
+		entry.Name = nameRe.FindString(rest)
+		if entry.Name == "" {
+			// Not a variable; skip the line
+			_, rest, _ = strings.Cut(rest, "\n")
+			continue
+		}
+		rest = rest[len(entry.Name):]
+
+		if strings.HasPrefix(rest, "=") {
+			rest = rest[1:]
+			if strings.HasPrefix(rest, "(") {
+				elements, remainder := parseShellArray(rest[1:])
+				entry.Value = rest[:len(rest)-len(remainder)]
+				entry.Elements = elements
+				rest = remainder
+			} else {
+				entry.Value, rest = readShellWord(rest)
+				// Older bash quotes the whole array: '([0]="a" [1]="b")'
+				if strings.HasPrefix(entry.Value, "([") && strings.HasSuffix(entry.Value, ")") {
+					entry.Elements, _ = parseShellArray(entry.Value[1:])
+				}
+			}
+		}
+
+		entry.Original = strings.TrimSpace(start[:len(start)-len(rest)])
 		entries = append(entries, entry)
+
+		_, rest, _ = strings.Cut(rest, "\n")
 	}
 
-	return entries, nil
+	return entries
+}
+
+// parseShellArray parses the elements of a shell array literal following the
+// opening parenthesis, up to the closing one, and returns the elements by
+// index or key along with the input after the literal
+func parseShellArray(s string) (map[string]string, string) {
+	elements := map[string]string{}
+	next := 0
+
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if s == "" {
+			return elements, ""
+		}
+		if s[0] == ')' {
+			return elements, s[1:]
+		}
+
+		key := strconv.Itoa(next)
+		if s[0] == '[' {
+			end := strings.Index(s, "]=")
+			if end == -1 {
+				return elements, ""
+			}
+			key, _ = readShellWord(s[1:end])
+			s = s[end+2:]
+		}
+
+		var value string
+		value, s = readShellWord(s)
+		elements[key] = value
+		if n, err := strconv.Atoi(key); err == nil {
+			next = n + 1
+		}
+	}
+}
+
+// readShellWord reads one shell word, undoing 'single', "double" and $'ANSI-C'
+// quoting and backslash escapes, and returns its value and the input after it
+func readShellWord(s string) (string, string) {
+	var out strings.Builder
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == ')':
+			if c == ')' && i+1 < len(s) && s[i+1] != ' ' && s[i+1] != '\n' {
+				out.WriteByte(c)
+				i++
+				continue
+			}
+			return out.String(), s[i:]
+		case strings.HasPrefix(s[i:], "$'"):
+			end := i + 2
+			for end < len(s) && s[end] != '\'' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			out.WriteString(decodeLsEscapes(s[i+2 : min(end, len(s))]))
+			i = end + 1
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end == -1 {
+				out.WriteString(s[i+1:])
+				return out.String(), ""
+			}
+			out.WriteString(s[i+1 : i+1+end])
+			i += end + 2
+		case c == '"':
+			i++
+			for i < len(s) && s[i] != '"' {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`\n", s[i+1]) >= 0 {
+					// A backslash-newline inside double quotes joins lines
+					if s[i+1] != '\n' {
+						out.WriteByte(s[i+1])
+					}
+					i += 2
+					continue
+				}
+				out.WriteByte(s[i])
+				i++
+			}
+			i++
+		case c == '\\' && i+1 < len(s):
+			if s[i+1] != '\n' {
+				out.WriteByte(s[i+1])
+			}
+			i += 2
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String(), ""
+}
+
+// redactEnvEntry masks the value of a variable whose name looks like it
+// holds a secret
+func redactEnvEntry(entry *EnvEntry) {
+	secretRe := regexp.MustCompile(`(?i)PASSWORD|PASSWD|TOKEN|SECRET|CREDENTIAL|PRIVATE|(^|_)(API_?)?KEY(S?)(_|$)|(^|_)PASS(_|$)`)
+	if !secretRe.MatchString(entry.Name) {
+		return
+	}
+
+	entry.Redacted = true
+	entry.Value = envRedacted
+	for key := range entry.Elements {
+		entry.Elements[key] = envRedacted
+	}
+
+	// The original text holds the value as well
+	if i := strings.Index(entry.Original, entry.Name+"="); i != -1 {
+		entry.Original = entry.Original[:i+len(entry.Name)+1] + envRedacted
+	}
 }
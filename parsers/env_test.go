@@ -104,3 +104,194 @@ func TestEnvParserEmpty(t *testing.T) {
 		t.Error("Expected error for input with only empty lines")
 	}
 }
+
+func TestEnvParserMultiLineValues(t *testing.T) {
+	parser := &EnvParser{}
+
+	testInput := `HOME=/home/user
+CERT=-----BEGIN CERTIFICATE-----
+MIIBszCCAVmgAwIBAgIU
+-----END CERTIFICATE-----
+CONFIG={
+  "debug": true
+}
+USER=testuser`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]EnvEntry)
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d: %+v", len(entries), entries)
+	}
+	if entries[1].Value != "-----BEGIN CERTIFICATE-----\nMIIBszCCAVmgAwIBAgIU\n-----END CERTIFICATE-----" {
+		t.Errorf("Unexpected certificate value %q", entries[1].Value)
+	}
+	if entries[2].Value != "{\n  \"debug\": true\n}" {
+		t.Errorf("Unexpected JSON value %q", entries[2].Value)
+	}
+	if entries[3].Name != "USER" {
+		t.Errorf("Expected USER, got %q", entries[3].Name)
+	}
+}
+
+func TestEnvParserNul(t *testing.T) {
+	parser := &EnvParser{}
+
+	result, err := parser.Parse("A=1\x00MULTI=line one\nline two\x00EMPTY=\x00")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]EnvEntry)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[1].Name != "MULTI" || entries[1].Value != "line one\nline two" {
+		t.Errorf("Unexpected entry %+v", entries[1])
+	}
+	if entries[2].Name != "EMPTY" || entries[2].Value != "" {
+		t.Errorf("Unexpected entry %+v", entries[2])
+	}
+}
+
+func TestEnvParserDeclare(t *testing.T) {
+	parser := &EnvParser{}
+
+	testInput := `declare -x HOME="/home/user"
+declare -x MSG="say \"hi\"
+and \$bye"
+declare -rx READONLY="1"
+declare -x UNSET
+declare -a ARR=([0]="one" [1]="two words" [5]="six")
+declare -A MAP=([key]="value" ["my key"]="other" )`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]EnvEntry)
+	if len(entries) != 6 {
+		t.Fatalf("Expected 6 entries, got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Name != "HOME" || entries[0].Value != "/home/user" || entries[0].Attributes != "x" {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+	if entries[1].Value != "say \"hi\"\nand $bye" {
+		t.Errorf("Unexpected multi-line value %q", entries[1].Value)
+	}
+	if entries[2].Attributes != "rx" {
+		t.Errorf("Expected attributes 'rx', got %q", entries[2].Attributes)
+	}
+	if entries[3].Name != "UNSET" || entries[3].Value != "" {
+		t.Errorf("Unexpected entry %+v", entries[3])
+	}
+	if entries[4].Elements["1"] != "two words" || entries[4].Elements["5"] != "six" {
+		t.Errorf("Unexpected array elements %v", entries[4].Elements)
+	}
+	if entries[5].Elements["my key"] != "other" || entries[5].Elements["key"] != "value" {
+		t.Errorf("Unexpected map elements %v", entries[5].Elements)
+	}
+}
+
+func TestEnvParserSet(t *testing.T) {
+	parser := &EnvParser{}
+
+	testInput := `BASH=/bin/bash
+BASH_VERSINFO=([0]="5" [1]="2" [2]="15")
+IFS=$' \t\n'
+PS1='\u@\h:\w\$ '
+QUOTED='it'\''s'
+greet () 
+{ 
+    echo "NOT=a variable"
+}
+export PATH=/usr/bin:/bin`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]EnvEntry)
+	names := []string{"BASH", "BASH_VERSINFO", "IFS", "PS1", "QUOTED", "PATH"}
+	if len(entries) != len(names) {
+		t.Fatalf("Expected %d entries, got %d: %+v", len(names), len(entries), entries)
+	}
+	for i, name := range names {
+		if entries[i].Name != name {
+			t.Errorf("Expected %s, got %s", name, entries[i].Name)
+		}
+	}
+	if entries[2].Value != " \t\n" {
+		t.Errorf("Unexpected IFS %q", entries[2].Value)
+	}
+	if entries[3].Value != `\u@\h:\w\$ ` {
+		t.Errorf("Unexpected PS1 %q", entries[3].Value)
+	}
+	if entries[4].Value != "it's" {
+		t.Errorf("Unexpected QUOTED %q", entries[4].Value)
+	}
+	if entries[5].Attributes != "x" || entries[5].Value != "/usr/bin:/bin" {
+		t.Errorf("Unexpected PATH %+v", entries[5])
+	}
+}
+
+func TestEnvParserPosixSet(t *testing.T) {
+	parser := &EnvParser{}
+
+	// POSIX set (e.g. dash, bash --posix) prints no arrays or functions
+	testInput := `FOO='bar baz'
+HOME='/home/alice'
+IFS=' 	
+'
+OPTIND='1'
+TAB=$'a\tb'`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]EnvEntry)
+	want := map[string]string{"FOO": "bar baz", "HOME": "/home/alice", "IFS": " \t\n", "OPTIND": "1", "TAB": "a\tb"}
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %d: %+v", len(want), len(entries), entries)
+	}
+	for _, entry := range entries {
+		if entry.Value != want[entry.Name] {
+			t.Errorf("%s: expected %q, got %q", entry.Name, want[entry.Name], entry.Value)
+		}
+	}
+}
+
+func TestEnvParserRedact(t *testing.T) {
+	parser := &EnvParser{Redact: true}
+
+	testInput := `GITHUB_TOKEN=ghp_abc
+DB_PASSWORD=hunter2
+AWS_SECRET_ACCESS_KEY=xyz
+API_KEY=k
+KEYBOARD=us
+HOME=/home/user`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]EnvEntry)
+	for _, entry := range entries[:4] {
+		if !entry.Redacted || entry.Value != "[REDACTED]" || entry.Original != entry.Name+"=[REDACTED]" {
+			t.Errorf("Expected %s to be redacted, got %+v", entry.Name, entry)
+		}
+	}
+	for _, entry := range entries[4:] {
+		if entry.Redacted {
+			t.Errorf("Expected %s to be kept, got %+v", entry.Name, entry)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FdiskParser parses fdisk -l output
+type FdiskParser struct{}
+
+// DiskEntry describes a disk and its partition table. It is shared by the
+// fdisk and parted parsers.
+type DiskEntry struct {
+	Device             string           `json:"device"`
+	Model              string           `json:"model,omitempty"`
+	Size               string           `json:"size,omitempty"`
+	SizeBytes          int64            `json:"size_bytes"`
+	Sectors            int64            `json:"sectors,omitempty"`
+	Transport          string           `json:"transport,omitempty"`
+	LogicalSectorSize  int              `json:"logical_sector_size,omitempty"`
+	PhysicalSectorSize int              `json:"physical_sector_size,omitempty"`
+	MinimumIOSize      int              `json:"minimum_io_size,omitempty"`
+	OptimalIOSize      int              `json:"optimal_io_size,omitempty"`
+	LabelType          string           `json:"label_type,omitempty"`
+	Identifier         string           `json:"identifier,omitempty"`
+	Flags              []string         `json:"flags,omitempty"`
+	Partitions         []PartitionEntry `json:"partitions"`
+}
+
+// PartitionEntry describes a partition or formatted block device. It is
+// shared by the blkid, fdisk and parted parsers; each fills the fields its
+// output carries.
+type PartitionEntry struct {
+	Device         string            `json:"device,omitempty"`
+	Number         int               `json:"number,omitempty"`
+	Boot           bool              `json:"boot,omitempty"`
+	StartSector    *int64            `json:"start_sector,omitempty"`
+	EndSector      *int64            `json:"end_sector,omitempty"`
+	Sectors        *int64            `json:"sectors,omitempty"`
+	StartBytes     *int64            `json:"start_bytes,omitempty"`
+	EndBytes       *int64            `json:"end_bytes,omitempty"`
+	Size           string            `json:"size,omitempty"`
+	SizeBytes      *int64            `json:"size_bytes,omitempty"`
+	TypeID         string            `json:"type_id,omitempty"`
+	Type           string            `json:"type,omitempty"`
+	FilesystemType string            `json:"filesystem_type,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	Label          string            `json:"label,omitempty"`
+	UUID           string            `json:"uuid,omitempty"`
+	PartUUID       string            `json:"partuuid,omitempty"`
+	Flags          []string          `json:"flags,omitempty"`
+	Attributes     map[string]string `json:"attributes,omitempty"`
+}
+
+func (p *FdiskParser) Name() string {
+	return "fdisk"
+}
+
+func (p *FdiskParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	diskRe := regexp.MustCompile(`^Disk (\S+): (.+?), (\d+) bytes, (\d+) sectors$`)
+	pairRe := regexp.MustCompile(`(\d+) bytes / (\d+) bytes`)
+
+	disks := []DiskEntry{}
+	var disk *DiskEntry
+	var columns []string
+
+	for _, line := range splitLines(input) {
+		if matches := diskRe.FindStringSubmatch(line); matches != nil {
+			disks = append(disks, DiskEntry{
+				Device:     matches[1],
+				Size:       matches[2],
+				Partitions: []PartitionEntry{},
+			})
+			disk = &disks[len(disks)-1]
+			disk.SizeBytes, _ = strconv.ParseInt(matches[3], 10, 64)
+			disk.Sectors, _ = strconv.ParseInt(matches[4], 10, 64)
+			columns = nil
+			continue
+		}
+
+		if disk == nil {
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(line, ": ")
+		switch {
+		case hasValue && key == "Disk model":
+			disk.Model = strings.TrimSpace(value)
+		case hasValue && key == "Disklabel type":
+			disk.LabelType = strings.TrimSpace(value)
+		case hasValue && key == "Disk identifier":
+			disk.Identifier = strings.TrimSpace(value)
+		case hasValue && strings.HasPrefix(key, "Sector size"):
+			if m := pairRe.FindStringSubmatch(value); m != nil {
+				disk.LogicalSectorSize, _ = strconv.Atoi(m[1])
+				disk.PhysicalSectorSize, _ = strconv.Atoi(m[2])
+			}
+		case hasValue && strings.HasPrefix(key, "I/O size"):
+			if m := pairRe.FindStringSubmatch(value); m != nil {
+				disk.MinimumIOSize, _ = strconv.Atoi(m[1])
+				disk.OptimalIOSize, _ = strconv.Atoi(m[2])
+			}
+		case strings.HasPrefix(line, "Device "):
+			columns = strings.Fields(line)
+		case columns != nil && strings.HasPrefix(line, "/"):
+			disk.Partitions = append(disk.Partitions, parseFdiskPartition(line, columns, disk.LogicalSectorSize))
+		}
+	}
+
+	return disks, nil
+}
+
+// parseFdiskPartition parses a partition table row. The Boot column is
+// blank for non-bootable DOS partitions and the Type column may contain
+// spaces, so values are taken in order rather than by position.
+func parseFdiskPartition(line string, columns []string, sectorSize int) PartitionEntry {
+	fields := strings.Fields(line)
+	partition := PartitionEntry{
+		Device: fields[0],
+	}
+	if m := regexp.MustCompile(`(\d+)$`).FindString(partition.Device); m != "" {
+		partition.Number, _ = strconv.Atoi(m)
+	}
+
+	rest := fields[1:]
+	for i := 1; i < len(columns) && len(rest) > 0; i++ {
+		switch columns[i] {
+		case "Boot":
+			if rest[0] == "*" {
+				partition.Boot = true
+				rest = rest[1:]
+			}
+			continue
+		case "Type", "Type-UUID":
+			if i == len(columns)-1 {
+				partition.Type = strings.Join(rest, " ")
+				rest = nil
+				continue
+			}
+		}
+
+		value := rest[0]
+		rest = rest[1:]
+		number, err := strconv.ParseInt(value, 10, 64)
+		switch columns[i] {
+		case "Start":
+			if err == nil {
+				partition.StartSector = &number
+			}
+		case "End":
+			if err == nil {
+				partition.EndSector = &number
+			}
+		case "Sectors":
+			if err == nil {
+				partition.Sectors = &number
+			}
+		case "Size":
+			partition.Size = value
+			if bytes, err := parseSizeToBytes(value); err == nil {
+				partition.SizeBytes = &bytes
+			}
+		case "Id":
+			partition.TypeID = value
+		case "Type", "Type-UUID":
+			partition.Type = value
+		case "Name":
+			partition.Name = value
+		}
+	}
+
+	// Exact byte offsets follow from the sector numbers
+	if sectorSize > 0 {
+		unit := int64(sectorSize)
+		if partition.StartSector != nil {
+			start := *partition.StartSector * unit
+			partition.StartBytes = &start
+		}
+		if partition.EndSector != nil {
+			end := (*partition.EndSector+1)*unit - 1
+			partition.EndBytes = &end
+		}
+		if partition.Sectors != nil {
+			size := *partition.Sectors * unit
+			partition.SizeBytes = &size
+		}
+	}
+
+	return partition
+}
@@ -0,0 +1,127 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestFdiskParserGPT(t *testing.T) {
+	parser := &FdiskParser{}
+
+	testInput := `Disk /dev/nvme0n1: 476.94 GiB, 512110190592 bytes, 1000215216 sectors
+Disk model: Samsung SSD 970 EVO Plus 512GB
+Units: sectors of 1 * 512 = 512 bytes
+Sector size (logical/physical): 512 bytes / 4096 bytes
+I/O size (minimum/optimal): 4096 bytes / 4096 bytes
+Disklabel type: gpt
+Disk identifier: 5C6D7E8F-1A2B-3C4D-5E6F-7A8B9C0D1E2F
+
+Device           Start        End    Sectors   Size Type
+/dev/nvme0n1p1    2048    1050623    1048576   512M EFI System
+/dev/nvme0n1p2 1050624 1000214527  999163904 476.4G Linux filesystem
+
+
+Disk /dev/mapper/vg-root: 50 GiB, 53687091200 bytes, 104857600 sectors
+Units: sectors of 1 * 512 = 512 bytes
+Sector size (logical/physical): 512 bytes / 512 bytes
+I/O size (minimum/optimal): 512 bytes / 512 bytes`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	disks, ok := result.([]DiskEntry)
+	if !ok {
+		t.Fatalf("Expected []DiskEntry, got %T", result)
+	}
+	if len(disks) != 2 {
+		t.Fatalf("Expected 2 disks, got %d", len(disks))
+	}
+
+	disk := disks[0]
+	if disk.Device != "/dev/nvme0n1" || disk.Size != "476.94 GiB" {
+		t.Errorf("Unexpected disk %q size %q", disk.Device, disk.Size)
+	}
+	if disk.SizeBytes != 512110190592 || disk.Sectors != 1000215216 {
+		t.Errorf("Unexpected size %d bytes, %d sectors", disk.SizeBytes, disk.Sectors)
+	}
+	if disk.Model != "Samsung SSD 970 EVO Plus 512GB" {
+		t.Errorf("Unexpected model %q", disk.Model)
+	}
+	if disk.LogicalSectorSize != 512 || disk.PhysicalSectorSize != 4096 {
+		t.Errorf("Unexpected sector sizes %d/%d", disk.LogicalSectorSize, disk.PhysicalSectorSize)
+	}
+	if disk.MinimumIOSize != 4096 || disk.OptimalIOSize != 4096 {
+		t.Errorf("Unexpected I/O sizes %d/%d", disk.MinimumIOSize, disk.OptimalIOSize)
+	}
+	if disk.LabelType != "gpt" || disk.Identifier != "5C6D7E8F-1A2B-3C4D-5E6F-7A8B9C0D1E2F" {
+		t.Errorf("Unexpected label %q identifier %q", disk.LabelType, disk.Identifier)
+	}
+
+	if len(disk.Partitions) != 2 {
+		t.Fatalf("Expected 2 partitions, got %d", len(disk.Partitions))
+	}
+	efi := disk.Partitions[0]
+	if efi.Device != "/dev/nvme0n1p1" || efi.Number != 1 || efi.Type != "EFI System" {
+		t.Errorf("Unexpected EFI partition %+v", efi)
+	}
+	if *efi.StartSector != 2048 || *efi.EndSector != 1050623 || *efi.Sectors != 1048576 {
+		t.Errorf("Unexpected sectors %d-%d (%d)", *efi.StartSector, *efi.EndSector, *efi.Sectors)
+	}
+	if *efi.StartBytes != 1048576 || *efi.EndBytes != 537919487 || *efi.SizeBytes != 536870912 {
+		t.Errorf("Unexpected byte range %d-%d (%d)", *efi.StartBytes, *efi.EndBytes, *efi.SizeBytes)
+	}
+	if disk.Partitions[1].Type != "Linux filesystem" || disk.Partitions[1].Size != "476.4G" {
+		t.Errorf("Unexpected root partition %+v", disk.Partitions[1])
+	}
+
+	if disks[1].Device != "/dev/mapper/vg-root" || len(disks[1].Partitions) != 0 {
+		t.Errorf("Unexpected mapper disk %+v", disks[1])
+	}
+}
+
+func TestFdiskParserDOS(t *testing.T) {
+	parser := &FdiskParser{}
+
+	testInput := `Disk /dev/sda: 465.76 GiB, 500107862016 bytes, 976773168 sectors
+Units: sectors of 1 * 512 = 512 bytes
+Sector size (logical/physical): 512 bytes / 512 bytes
+I/O size (minimum/optimal): 512 bytes / 512 bytes
+Disklabel type: dos
+Disk identifier: 0x0004a1b2
+
+Device     Boot   Start       End   Sectors   Size Id Type
+/dev/sda1  *       2048   1026047   1024000   500M 83 Linux
+/dev/sda2       1026048 976773119 975747072 465.3G 8e Linux LVM`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	disks := result.([]DiskEntry)
+	if len(disks) != 1 || len(disks[0].Partitions) != 2 {
+		t.Fatalf("Expected 1 disk with 2 partitions, got %+v", disks)
+	}
+	if disks[0].Identifier != "0x0004a1b2" {
+		t.Errorf("Unexpected identifier %q", disks[0].Identifier)
+	}
+
+	boot := disks[0].Partitions[0]
+	if !boot.Boot || boot.TypeID != "83" || boot.Type != "Linux" || *boot.StartSector != 2048 {
+		t.Errorf("Unexpected boot partition %+v", boot)
+	}
+
+	lvm := disks[0].Partitions[1]
+	if lvm.Boot || lvm.TypeID != "8e" || lvm.Type != "Linux LVM" || *lvm.StartSector != 1026048 {
+		t.Errorf("Unexpected LVM partition %+v", lvm)
+	}
+}
+
+func TestFdiskParserEmpty(t *testing.T) {
+	parser := &FdiskParser{}
+
+	if _, err := parser.Parse(""); err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
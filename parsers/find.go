@@ -2,13 +2,24 @@ package parsers
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// FindParser parses find command output (find -ls format)
-type FindParser struct{}
+// FindParser parses find command output: find -ls, bare paths (newline or
+// NUL separated with -print0) and, when Format holds the -printf format that
+// produced the output, -printf records. With Tree set, entries are returned
+// as a nested FileTreeNode tree. ReferenceTime and Location work as for
+// LsParser.
+type FindParser struct {
+	Tree          bool
+	Format        string
+	ReferenceTime time.Time
+	Location      *time.Location
+}
 
 // FindEntry represents a single find output entry
 type FindEntry struct {
@@ -21,6 +32,16 @@ type FindEntry struct {
 	Links        int       `json:"links"`
 	Owner        string    `json:"owner"`
 	Group        string    `json:"group"`
+	UID          *int      `json:"uid,omitempty"`
+	GID          *int      `json:"gid,omitempty"`
+	LinkTarget   string    `json:"link_target,omitempty"`
+	FileMode
+	Issues     []string          `json:"issues,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Depth      *int              `json:"depth,omitempty"`
+	AccessTime *time.Time        `json:"access_time,omitempty"`
+	ChangeTime *time.Time        `json:"change_time,omitempty"`
+	Extra      map[string]string `json:"extra,omitempty"`
 }
 
 func (p *FindParser) Name() string {
@@ -32,19 +53,74 @@ func (p *FindParser) Parse(input string) (interface{}, error) {
 	if input == "" {
 		return nil, fmt.Errorf("empty input")
 	}
-	
-	lines := splitLines(input)
+
+	clock := newLsClock(p.ReferenceTime, p.Location)
 	var entries []FindEntry
 
-	for _, line := range lines {
-		// Handle different find output formats
-		if strings.Contains(line, " ") {
-			// Assume find -ls format or similar detailed output
-			if entry := parseFindLsLine(line); entry != nil {
-				entries = append(entries, *entry)
+	switch {
+	case p.Format != "":
+		parsed, err := parseFindPrintf(input, p.Format, clock)
+		if err != nil {
+			return nil, err
+		}
+		entries = parsed
+	case strings.Contains(input, "\x00"):
+		// -print0: NUL separated paths, which may contain newlines
+		for _, path := range strings.Split(input, "\x00") {
+			if path == "" {
+				continue
 			}
+			entries = append(entries, FindEntry{
+				Path: path,
+				Type: "unknown",
+			})
+		}
+	default:
+		entries = parseFindLines(input, clock)
+	}
+
+	if p.Tree {
+		var items []FileTreeNode
+		for _, entry := range entries {
+			node := FileTreeNode{
+				Path:        entry.Path,
+				Type:        entry.FileType,
+				Permissions: entry.Permissions,
+				Owner:       entry.Owner,
+				Group:       entry.Group,
+				UID:         entry.UID,
+				GID:         entry.GID,
+				Size:        entry.Size,
+				LinkTarget:  entry.LinkTarget,
+			}
+			if entry.Type == "unknown" {
+				node.Type = FileTypeUnknown
+				if strings.HasSuffix(entry.Path, "/") {
+					node.Type = FileTypeDirectory
+				}
+			}
+			if !entry.ModifiedTime.IsZero() {
+				modified := entry.ModifiedTime
+				node.Modified = &modified
+			}
+			items = append(items, node)
+		}
+		return buildFileTree(items), nil
+	}
+
+	return entries, nil
+}
+
+// parseFindLines parses newline separated find output
+func parseFindLines(input string, clock lsClock) []FindEntry {
+	var entries []FindEntry
+
+	for _, line := range splitLines(input) {
+		// Assume find -ls format or similar detailed output, falling back to
+		// the simple path-only format
+		if entry := parseFindLsLine(line, clock); entry != nil {
+			entries = append(entries, *entry)
 		} else {
-			// Simple path-only format
 			entry := FindEntry{
 				Path: strings.TrimSpace(line),
 				Type: "unknown",
@@ -53,63 +129,215 @@ func (p *FindParser) Parse(input string) (interface{}, error) {
 		}
 	}
 
-	return entries, nil
+	return entries
 }
 
-// parseFindLsLine parses a line from find -ls output
-func parseFindLsLine(line string) *FindEntry {
-	fields := splitFields(line)
-	
+// parseFindLsLine parses a line from find -ls output, which is ls -l output
+// preceded by the inode and block count
+func parseFindLsLine(line string, clock lsClock) *FindEntry {
 	// find -ls format: inode blocks permissions links owner group size date time path
-	if len(fields) < 11 {
+	lsEntry, _, ok := parseLsLine(line, clock)
+	if !ok || lsEntry.Inode == nil {
 		return nil
 	}
 
-	entry := &FindEntry{}
-
-	// Parse inode
-	if inode, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
-		entry.Inode = inode
+	entry := &FindEntry{
+		Path:         lsEntry.Name,
+		Permissions:  lsEntry.Permissions,
+		Size:         lsEntry.Size,
+		ModifiedTime: lsEntry.Modified,
+		Inode:        *lsEntry.Inode,
+		Links:        lsEntry.Links,
+		Owner:        lsEntry.Owner,
+		Group:        lsEntry.Group,
+		LinkTarget:   lsEntry.LinkTarget,
+		FileMode:     lsEntry.FileMode,
+		Issues:       lsEntry.Issues,
 	}
 
-	// Parse permissions and determine type
-	entry.Permissions = fields[2]
-	if strings.HasPrefix(entry.Permissions, "d") {
-		entry.Type = "directory"
-	} else if strings.HasPrefix(entry.Permissions, "l") {
-		entry.Type = "symlink"
-	} else if strings.HasPrefix(entry.Permissions, "-") {
-		entry.Type = "file"
-	} else {
+	// Determine type
+	switch entry.FileType {
+	case FileTypeDirectory, FileTypeSymlink, FileTypeRegular:
+		entry.Type = entry.FileType
+	default:
 		entry.Type = "special"
 	}
 
-	// Parse links
-	if links, err := strconv.Atoi(fields[3]); err == nil {
-		entry.Links = links
+	return entry
+}
+
+// parseFindPrintf parses find -printf output produced by format
+func parseFindPrintf(input, format string, clock lsClock) ([]FindEntry, error) {
+	directiveRe := regexp.MustCompile(`^%[-+# 0]*\d*(?:\.\d+)?([ABCT][a-zA-Z@+]|[a-zA-Z%])`)
+	directives := parseFormatDirectives(decodeLsEscapes(format), directiveRe)
+
+	names, records, err := matchFormatRecords(input, format, directives, findDirectivePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []FindEntry
+	for _, values := range records {
+		entry := FindEntry{
+			Type: "unknown",
+		}
+		for i, directive := range names {
+			applyFindDirective(&entry, directive, values[i], clock)
+		}
+		entries = append(entries, entry)
 	}
 
-	// Parse owner and group
-	entry.Owner = fields[4]
-	entry.Group = fields[5]
+	return entries, nil
+}
 
-	// Parse size
-	if size, err := strconv.ParseInt(fields[6], 10, 64); err == nil {
-		entry.Size = size
+// findDirectivePattern returns the regexp capturing a directive's value.
+// Numeric directives may be space padded by a field width.
+func findDirectivePattern(directive string) string {
+	switch directive {
+	case "s", "k", "b", "d", "i", "n", "U", "G", "D":
+		return ` *(\d+) *`
+	case "m":
+		return ` *([0-7]+) *`
+	case "T@", "A@", "C@", "B@":
+		return ` *(-?\d+(?:\.\d+)?) *`
+	case "M":
+		return `([-dlcbpsD?][-rwxsStTlL?]{9}[.+@]?)`
+	case "y", "Y":
+		return `([fdlcbpsDUN?])`
 	}
+	return `(.*?)`
+}
+
+// applyFindDirective stores a captured -printf value in its typed field;
+// directives without one are kept in Extra
+func applyFindDirective(entry *FindEntry, directive, value string, clock lsClock) {
+	value = strings.TrimSpace(value)
+	number, numberErr := strconv.ParseInt(value, 10, 64)
 
-	// Parse date/time (fields 7, 8, 9)
-	if len(fields) >= 10 {
-		dateStr := strings.Join(fields[7:10], " ")
-		if parsedTime, err := parseDate(dateStr); err == nil {
-			entry.ModifiedTime = parsedTime
+	switch directive {
+	case "p":
+		entry.Path = value
+		if entry.Name == "" {
+			entry.Name = path.Base(value)
+		}
+	case "f":
+		entry.Name = value
+	case "s":
+		entry.Size = number
+	case "d":
+		if numberErr == nil {
+			depth := int(number)
+			entry.Depth = &depth
+		}
+	case "i":
+		entry.Inode = number
+	case "n":
+		entry.Links = int(number)
+	case "u":
+		entry.Owner = value
+	case "g":
+		entry.Group = value
+	case "U":
+		if numberErr == nil {
+			uid := int(number)
+			entry.UID = &uid
+		}
+	case "G":
+		if numberErr == nil {
+			gid := int(number)
+			entry.GID = &gid
+		}
+	case "l":
+		entry.LinkTarget = value
+	case "m":
+		applyOctalMode(&entry.FileMode, value)
+	case "M":
+		entry.Permissions = value
+		mode := decodeFileMode(value)
+		if entry.Mode != "" {
+			mode.Mode = entry.Mode
+		}
+		entry.FileMode = mode
+		setFindType(entry, mode.FileType)
+	case "y":
+		setFindType(entry, findTypeLetters[value])
+	case "T@", "A@", "C@":
+		t, err := parseEpochTime(value, clock.location)
+		if err != nil {
+			entry.Issues = append(entry.Issues, err.Error())
+			return
+		}
+		switch directive {
+		case "T@":
+			entry.ModifiedTime = t
+		case "A@":
+			entry.AccessTime = &t
+		case "C@":
+			entry.ChangeTime = &t
 		}
+	case "t", "a", "c":
+		t, err := parseFindCtime(value, clock)
+		if err != nil {
+			entry.Issues = append(entry.Issues, err.Error())
+			return
+		}
+		switch directive {
+		case "t":
+			entry.ModifiedTime = t
+		case "a":
+			entry.AccessTime = &t
+		case "c":
+			entry.ChangeTime = &t
+		}
+	default:
+		if entry.Extra == nil {
+			entry.Extra = map[string]string{}
+		}
+		entry.Extra["%"+directive] = value
 	}
+}
 
-	// Parse path (remaining fields)
-	if len(fields) > 10 {
-		entry.Path = strings.Join(fields[10:], " ")
+// findTypeLetters maps the %y type letters to file types
+var findTypeLetters = map[string]string{
+	"f": FileTypeRegular,
+	"d": FileTypeDirectory,
+	"l": FileTypeSymlink,
+	"c": FileTypeCharDevice,
+	"b": FileTypeBlockDevice,
+	"p": FileTypeFIFO,
+	"s": FileTypeSocket,
+	"D": FileTypeDoor,
+}
+
+func setFindType(entry *FindEntry, fileType string) {
+	if fileType == "" {
+		fileType = FileTypeUnknown
 	}
+	entry.FileType = fileType
+	switch fileType {
+	case FileTypeDirectory, FileTypeSymlink, FileTypeRegular:
+		entry.Type = fileType
+	case FileTypeUnknown:
+		entry.Type = "unknown"
+	default:
+		entry.Type = "special"
+	}
+}
 
-	return entry
+// parseFindCtime parses %t style times: "Mon Jan 15 10:30:25.1234567890 2024"
+func parseFindCtime(value string, clock lsClock) (time.Time, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 5 {
+		clockTime, fraction, _ := strings.Cut(fields[3], ".")
+		fields[3] = clockTime
+		t, err := time.ParseInLocation("Mon Jan 2 15:04:05 2006", strings.Join(fields, " "), clock.location)
+		if err == nil {
+			if fraction != "" {
+				nsec, _ := strconv.ParseInt((fraction + "000000000")[:9], 10, 64)
+				t = t.Add(time.Duration(nsec))
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse date: %s", value)
 }
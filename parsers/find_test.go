@@ -3,6 +3,7 @@ package parsers
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestFindParser(t *testing.T) {
@@ -122,3 +123,160 @@ func TestFindParserEmpty(t *testing.T) {
 		t.Error("Expected error for whitespace-only input")
 	}
 }
+
+func TestFindParserTree(t *testing.T) {
+	parser := &FindParser{Tree: true}
+
+	testInput := `   100      4 drwxr-xr-x   3 user group     4096 Jan 15 14:30 .
+   101      4 -rw-r--r--   1 user group     1024 Jan 15 14:30 ./file.txt
+   102      4 drwxr-xr-x   2 user group     4096 Jan 14 10:20 ./dir
+   103      4 -rw-r--r--   1 user group      512 Jan 14 10:20 ./dir/my notes.txt
+   104      0 lrwxrwxrwx   1 user group        8 Jan 13 16:45 ./dir/link -> ../file.txt`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	roots, ok := result.([]FileTreeNode)
+	if !ok {
+		t.Fatalf("Expected []FileTreeNode, got %T", result)
+	}
+	if len(roots) != 1 || roots[0].Path != "." {
+		t.Fatalf("Expected single '.' root, got %+v", roots)
+	}
+
+	root := roots[0]
+	if root.FileCount != 3 || root.DirectoryCount != 1 || root.TotalSize != 1544 {
+		t.Errorf("Unexpected totals files=%d dirs=%d size=%d", root.FileCount, root.DirectoryCount, root.TotalSize)
+	}
+
+	dir := root.Children[1]
+	if dir.Path != "./dir" || len(dir.Children) != 2 {
+		t.Fatalf("Unexpected ./dir node %+v", dir)
+	}
+	if dir.Children[0].Name != "my notes.txt" || dir.Children[1].LinkTarget != "../file.txt" {
+		t.Errorf("Unexpected ./dir children %+v", dir.Children)
+	}
+}
+
+func TestFindParserYearInference(t *testing.T) {
+	parser := &FindParser{ReferenceTime: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)}
+
+	result, err := parser.Parse(`   123456       8 -rw-r--r--   1 user group     1024 Dec 28 14:30 ./file.txt`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]FindEntry)
+	want := time.Date(2023, 12, 28, 14, 30, 0, 0, time.UTC)
+	if !entries[0].ModifiedTime.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, entries[0].ModifiedTime)
+	}
+}
+
+func TestFindParserPathWithSpaces(t *testing.T) {
+	parser := &FindParser{}
+
+	result, err := parser.Parse("./my documents/report 2024.pdf\n./plain.txt")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]FindEntry)
+	if len(entries) != 2 || entries[0].Path != "./my documents/report 2024.pdf" {
+		t.Errorf("Unexpected entries %+v", entries)
+	}
+}
+
+func TestFindParserPrint0(t *testing.T) {
+	parser := &FindParser{}
+
+	result, err := parser.Parse("./a b.txt\x00./line\nbreak.txt\x00./c.txt\x00")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]FindEntry)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[1].Path != "./line\nbreak.txt" {
+		t.Errorf("Expected path with newline, got %q", entries[1].Path)
+	}
+}
+
+func TestFindParserPrintf(t *testing.T) {
+	parser := &FindParser{Format: `%p\t%s\t%T@\t%u\n`}
+
+	testInput := "./my file.txt\t1024\t1705329025.5000000000\talice\n" +
+		"./dir\t4096\t1705329000.0000000000\tbob\n"
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]FindEntry)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.Path != "./my file.txt" || entry.Name != "my file.txt" {
+		t.Errorf("Unexpected path %q name %q", entry.Path, entry.Name)
+	}
+	if entry.Size != 1024 || entry.Owner != "alice" {
+		t.Errorf("Unexpected size %d owner %q", entry.Size, entry.Owner)
+	}
+	want := time.Unix(1705329025, 500000000)
+	if !entry.ModifiedTime.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, entry.ModifiedTime)
+	}
+	if entries[1].Owner != "bob" {
+		t.Errorf("Expected owner 'bob', got %q", entries[1].Owner)
+	}
+}
+
+func TestFindParserPrintfTypedFields(t *testing.T) {
+	parser := &FindParser{Format: `%y %m %M %d %i %n %U:%G %h %p\0`}
+
+	testInput := "d 755 drwxr-xr-x 0 100 3 1000:1000 . .\x00" +
+		"f 4755 -rwsr-xr-x 1 101 1 0:0 . ./bin/tool\x00" +
+		"l 777 lrwxrwxrwx 2 102 1 1000:100 ./ab ./ab/my link\x00"
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]FindEntry)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	if entries[0].Type != "directory" || entries[0].Mode != "0755" || *entries[0].Depth != 0 || entries[0].Links != 3 {
+		t.Errorf("Unexpected directory entry %+v", entries[0])
+	}
+	tool := entries[1]
+	if tool.Type != "file" || tool.Mode != "4755" || !tool.Setuid || tool.Inode != 101 || *tool.UID != 0 {
+		t.Errorf("Unexpected file entry %+v", tool)
+	}
+	link := entries[2]
+	if link.Type != "symlink" || link.Path != "./ab/my link" || link.Extra["%h"] != "./ab" {
+		t.Errorf("Unexpected symlink entry %+v", link)
+	}
+}
+
+func TestFindParserPrintfMismatch(t *testing.T) {
+	parser := &FindParser{Format: `%s %p\n`}
+
+	if _, err := parser.Parse("no size here"); err == nil {
+		t.Error("Expected error for output not matching the format")
+	}
+
+	parser.Format = `plain\n`
+	if _, err := parser.Parse("plain"); err == nil {
+		t.Error("Expected error for format without directives")
+	}
+}
@@ -0,0 +1,208 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FstabParser parses /etc/fstab file format. With Validate set, each entry
+// is checked and problems are reported in its Issues field.
+type FstabParser struct {
+	Validate bool
+}
+
+// FstabEntry represents a single fstab file entry
+type FstabEntry struct {
+	Spec           string                 `json:"spec,omitempty"`
+	SpecType       string                 `json:"spec_type,omitempty"`
+	SpecValue      string                 `json:"spec_value,omitempty"`
+	MountPoint     string                 `json:"mount_point,omitempty"`
+	FilesystemType string                 `json:"filesystem_type,omitempty"`
+	Options        string                 `json:"options,omitempty"`
+	OptionsMap     map[string]interface{} `json:"options_map,omitempty"`
+	Dump           *int                   `json:"dump,omitempty"`
+	Pass           *int                   `json:"pass,omitempty"`
+	Comment        string                 `json:"comment,omitempty"`
+	Original       string                 `json:"original"`
+	Issues         []string               `json:"issues,omitempty"`
+}
+
+// Block device spec types shared by fstab and crypttab
+const (
+	SpecTypeUUID      = "uuid"
+	SpecTypeLabel     = "label"
+	SpecTypePartUUID  = "partuuid"
+	SpecTypePartLabel = "partlabel"
+	SpecTypeID        = "id"
+	SpecTypePath      = "path"
+	SpecTypeNetwork   = "network"
+	SpecTypeOther     = "other"
+)
+
+// knownFilesystemTypes lists the filesystem types accepted by fstab validation
+var knownFilesystemTypes = []string{
+	"auto", "none", "swap", "ext2", "ext3", "ext4", "xfs", "btrfs", "bcachefs",
+	"f2fs", "jfs", "reiserfs", "nilfs2", "zfs", "vfat", "fat", "msdos", "exfat",
+	"ntfs", "ntfs3", "ntfs-3g", "hfs", "hfsplus", "iso9660", "udf", "squashfs",
+	"erofs", "overlay", "tmpfs", "ramfs", "proc", "sysfs", "devpts", "devtmpfs",
+	"securityfs", "cgroup", "cgroup2", "debugfs", "tracefs", "hugetlbfs",
+	"mqueue", "configfs", "bpf", "efivarfs", "binfmt_misc", "autofs", "fuse",
+	"fuseblk", "sshfs", "nfs", "nfs4", "cifs", "smb3", "smbfs", "ceph",
+	"glusterfs", "9p", "virtiofs", "davfs",
+}
+
+func (p *FstabParser) Name() string {
+	return "fstab"
+}
+
+func (p *FstabParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	var entries []FstabEntry
+	mountPoints := map[string]int{}
+
+	for _, original := range strings.Split(input, "\n") {
+		line := strings.TrimSpace(original)
+
+		// Skip empty lines
+		if line == "" {
+			continue
+		}
+
+		entry := FstabEntry{
+			Original: original,
+		}
+
+		// Handle comment-only lines
+		if strings.HasPrefix(line, "#") {
+			entry.Comment = strings.TrimSpace(line[1:])
+			entries = append(entries, entry)
+			continue
+		}
+
+		// Inline comment; "#" inside a field (e.g. sshfs#host:/) is kept
+		line, entry.Comment = cutInlineComment(line)
+
+		// fstab format: spec file vfstype mntops freq passno
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			if p.Validate {
+				entry.Issues = append(entry.Issues, fmt.Sprintf("expected at least 4 fields, got %d", len(fields)))
+				entries = append(entries, entry)
+			}
+			continue
+		}
+
+		entry.Spec = fields[0]
+		entry.SpecType, entry.SpecValue = parseBlockSpec(fields[0])
+		entry.MountPoint = decodeMountEscapes(fields[1])
+		entry.FilesystemType = fields[2]
+		entry.Options = fields[3]
+		entry.OptionsMap = parseMountOptions(fields[3])
+
+		// dump and pass default to 0 when left out; rows that are not
+		// mount entries have neither
+		dump, pass := 0, 0
+		entry.Dump, entry.Pass = &dump, &pass
+		if len(fields) > 4 {
+			value, err := strconv.Atoi(fields[4])
+			if err == nil {
+				dump = value
+			} else if p.Validate {
+				entry.Issues = append(entry.Issues, fmt.Sprintf("invalid dump value %q", fields[4]))
+			}
+		}
+		if len(fields) > 5 {
+			value, err := strconv.Atoi(fields[5])
+			if err == nil {
+				pass = value
+			}
+			if p.Validate && (err != nil || value < 0 || value > 2) {
+				entry.Issues = append(entry.Issues, fmt.Sprintf("invalid pass value %q, expected 0, 1 or 2", fields[5]))
+			}
+		}
+
+		if p.Validate {
+			entry.Issues = append(entry.Issues, validateFstabEntry(&entry, mountPoints)...)
+			if entry.MountPoint != "none" && entry.FilesystemType != "swap" {
+				mountPoints[entry.MountPoint]++
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// validateFstabEntry checks the filesystem type and mount point of an entry.
+// mountPoints counts the mount points seen on earlier lines.
+func validateFstabEntry(entry *FstabEntry, mountPoints map[string]int) []string {
+	var issues []string
+
+	for _, fsType := range strings.Split(entry.FilesystemType, ",") {
+		if findInSlice(knownFilesystemTypes, fsType) == -1 && !strings.HasPrefix(fsType, "fuse.") {
+			issues = append(issues, fmt.Sprintf("unknown filesystem type %q", fsType))
+		}
+	}
+
+	if entry.FilesystemType != "swap" && entry.MountPoint != "none" {
+		if !strings.HasPrefix(entry.MountPoint, "/") {
+			issues = append(issues, fmt.Sprintf("mount point %q is not an absolute path", entry.MountPoint))
+		}
+		if mountPoints[entry.MountPoint] > 0 {
+			issues = append(issues, fmt.Sprintf("duplicate mount point %q", entry.MountPoint))
+		}
+	}
+
+	return issues
+}
+
+// inlineCommentRe matches the start of a comment following the fields of a
+// table line; only a "#" preceded by whitespace begins one
+var inlineCommentRe = regexp.MustCompile(`\s#`)
+
+// cutInlineComment splits a table line such as an fstab or crypttab entry
+// into its fields and the trailing comment
+func cutInlineComment(line string) (fields, comment string) {
+	loc := inlineCommentRe.FindStringIndex(line)
+	if loc == nil {
+		return line, ""
+	}
+	return strings.TrimSpace(line[:loc[0]]), strings.TrimSpace(line[loc[1]:])
+}
+
+// parseBlockSpec decodes a device spec such as UUID=..., LABEL=..., PARTUUID=...
+// or a device path into its type and value
+func parseBlockSpec(spec string) (specType, value string) {
+	prefixes := map[string]string{
+		"UUID":      SpecTypeUUID,
+		"LABEL":     SpecTypeLabel,
+		"PARTUUID":  SpecTypePartUUID,
+		"PARTLABEL": SpecTypePartLabel,
+		"ID":        SpecTypeID,
+	}
+
+	if eq := strings.Index(spec, "="); eq != -1 {
+		if specType, ok := prefixes[strings.ToUpper(spec[:eq])]; ok {
+			return specType, decodeMountEscapes(strings.Trim(spec[eq+1:], `"'`))
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(spec, "/"):
+		if strings.HasPrefix(spec, "//") {
+			return SpecTypeNetwork, spec
+		}
+		return SpecTypePath, decodeMountEscapes(spec)
+	case strings.Contains(spec, ":/"):
+		return SpecTypeNetwork, spec
+	}
+
+	return SpecTypeOther, spec
+}
@@ -0,0 +1,138 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestFstabParser(t *testing.T) {
+	parser := &FstabParser{}
+
+	testInput := `# /etc/fstab: static file system information.
+UUID=3f2a1b4c-5d6e-7f80-9a1b-2c3d4e5f6a7b /               ext4    errors=remount-ro 0       1
+LABEL=EFI   /boot/efi       vfat    umask=0077      0       1
+PARTUUID=0a1b2c3d-01 none swap sw 0 0
+/dev/sdb1	/mnt/My\040Data	ntfs	defaults,uid=1000	0	0 # external disk
+server:/export	/mnt/nfs	nfs	rw,hard,timeo=600	0	0`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]FstabEntry)
+	if !ok {
+		t.Fatalf("Expected []FstabEntry, got %T", result)
+	}
+
+	if len(entries) != 6 {
+		t.Fatalf("Expected 6 entries, got %d", len(entries))
+	}
+
+	if entries[0].Comment != "/etc/fstab: static file system information." {
+		t.Errorf("Unexpected comment %q", entries[0].Comment)
+	}
+	if entries[0].Dump != nil || entries[0].Pass != nil {
+		t.Errorf("Expected no dump or pass on a comment row, got %v %v", entries[0].Dump, entries[0].Pass)
+	}
+
+	root := entries[1]
+	if root.SpecType != SpecTypeUUID || root.SpecValue != "3f2a1b4c-5d6e-7f80-9a1b-2c3d4e5f6a7b" {
+		t.Errorf("Unexpected spec %q/%q", root.SpecType, root.SpecValue)
+	}
+	if root.MountPoint != "/" || root.FilesystemType != "ext4" {
+		t.Errorf("Unexpected mount point %q or type %q", root.MountPoint, root.FilesystemType)
+	}
+	if root.OptionsMap["errors"] != "remount-ro" {
+		t.Errorf("Expected errors=remount-ro, got %v", root.OptionsMap["errors"])
+	}
+	if root.Dump == nil || *root.Dump != 0 || root.Pass == nil || *root.Pass != 1 {
+		t.Errorf("Expected dump 0 pass 1, got %v %v", root.Dump, root.Pass)
+	}
+
+	if entries[2].SpecType != SpecTypeLabel || entries[2].SpecValue != "EFI" {
+		t.Errorf("Unexpected label spec %q/%q", entries[2].SpecType, entries[2].SpecValue)
+	}
+
+	if entries[3].SpecType != SpecTypePartUUID || entries[3].MountPoint != "none" {
+		t.Errorf("Unexpected swap entry %+v", entries[3])
+	}
+	if entries[3].OptionsMap["sw"] != true {
+		t.Errorf("Expected sw flag, got %v", entries[3].OptionsMap["sw"])
+	}
+
+	disk := entries[4]
+	if disk.SpecType != SpecTypePath || disk.SpecValue != "/dev/sdb1" {
+		t.Errorf("Unexpected path spec %q/%q", disk.SpecType, disk.SpecValue)
+	}
+	if disk.MountPoint != "/mnt/My Data" {
+		t.Errorf("Expected decoded mount point, got %q", disk.MountPoint)
+	}
+	if disk.Comment != "external disk" {
+		t.Errorf("Expected comment 'external disk', got %q", disk.Comment)
+	}
+	if disk.OptionsMap["uid"] != "1000" {
+		t.Errorf("Expected uid=1000, got %v", disk.OptionsMap["uid"])
+	}
+
+	if entries[5].SpecType != SpecTypeNetwork {
+		t.Errorf("Expected network spec, got %q", entries[5].SpecType)
+	}
+
+	for _, entry := range entries {
+		if len(entry.Issues) != 0 {
+			t.Errorf("Expected no issues without Validate, got %v", entry.Issues)
+		}
+	}
+}
+
+func TestFstabParserValidate(t *testing.T) {
+	parser := &FstabParser{Validate: true}
+
+	testInput := `UUID=1234 / ext4 defaults 0 1
+/dev/sdb1 /data ext9 defaults 0 2
+/dev/sdc1 / xfs defaults 0 3
+/dev/sdd1 relative ext4 defaults 0 0
+sshfs#user@host:/ /mnt/remote fuse.sshfs defaults 0 0
+/dev/sde1 /broken
+tmpfs /tmp tmpfs defaults 0 0`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]FstabEntry)
+	if len(entries) != 7 {
+		t.Fatalf("Expected 7 entries, got %d", len(entries))
+	}
+
+	if len(entries[0].Issues) != 0 {
+		t.Errorf("Expected no issues for root, got %v", entries[0].Issues)
+	}
+	if len(entries[1].Issues) != 1 || entries[1].Issues[0] != `unknown filesystem type "ext9"` {
+		t.Errorf("Expected unknown type issue, got %v", entries[1].Issues)
+	}
+	if len(entries[2].Issues) != 2 {
+		t.Errorf("Expected pass and duplicate issues, got %v", entries[2].Issues)
+	}
+	if len(entries[3].Issues) != 1 || entries[3].Issues[0] != `mount point "relative" is not an absolute path` {
+		t.Errorf("Expected relative path issue, got %v", entries[3].Issues)
+	}
+	if len(entries[5].Issues) != 1 || entries[5].Issues[0] != "expected at least 4 fields, got 2" {
+		t.Errorf("Expected malformed line issue, got %v", entries[5].Issues)
+	}
+	if entries[4].Spec != "sshfs#user@host:/" || len(entries[4].Issues) != 0 {
+		t.Errorf("Expected fuse.sshfs entry without issues, got %q %v", entries[4].Spec, entries[4].Issues)
+	}
+	if len(entries[6].Issues) != 0 {
+		t.Errorf("Expected no issues for tmpfs, got %v", entries[6].Issues)
+	}
+}
+
+func TestFstabParserEmpty(t *testing.T) {
+	parser := &FstabParser{}
+
+	if _, err := parser.Parse(""); err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
@@ -0,0 +1,51 @@
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GroupParser parses /etc/group file format
+type GroupParser struct{}
+
+// GroupEntry represents a single group file entry
+type GroupEntry struct {
+	GroupName string   `json:"group_name"`
+	Password  string   `json:"password"`
+	GID       int      `json:"gid"`
+	Members   []string `json:"members"`
+	Original  string   `json:"original"`
+}
+
+func (p *GroupParser) Name() string {
+	return "group"
+}
+
+func (p *GroupParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	var entries []GroupEntry
+
+	// group format: group_name:password:GID:user_list
+	for _, record := range parseColonRecords(input, 4) {
+		fields := record.Fields
+		entry := GroupEntry{
+			GroupName: fields[0],
+			Password:  fields[1],
+			Members:   splitMemberList(fields[3]),
+			Original:  record.Original,
+		}
+
+		if gid, err := strconv.Atoi(fields[2]); err == nil {
+			entry.GID = gid
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,63 @@
+package parsers
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGroupParser(t *testing.T) {
+	parser := &GroupParser{}
+
+	testInput := `root:x:0:
+adm:x:4:syslog,alice
+# local groups
+sudo:x:27:alice,bob
+docker:x:999:
+malformed:x:1`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]GroupEntry)
+	if !ok {
+		t.Fatalf("Expected []GroupEntry, got %T", result)
+	}
+
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+
+	if entries[0].GroupName != "root" || entries[0].GID != 0 || entries[0].Password != "x" {
+		t.Errorf("Unexpected root entry: %+v", entries[0])
+	}
+	if entries[0].Members == nil || len(entries[0].Members) != 0 {
+		t.Errorf("Expected empty member list, got %v", entries[0].Members)
+	}
+
+	if entries[2].GID != 27 || len(entries[2].Members) != 2 || entries[2].Members[1] != "bob" {
+		t.Errorf("Unexpected sudo entry: %+v", entries[2])
+	}
+	if entries[2].Original != "sudo:x:27:alice,bob" {
+		t.Errorf("Unexpected original: %s", entries[2].Original)
+	}
+
+	// Test JSON marshaling keeps members as a list
+	jsonData, err := json.Marshal(entries[3])
+	if err != nil {
+		t.Fatalf("JSON marshal failed: %v", err)
+	}
+	if string(jsonData) != `{"group_name":"docker","password":"x","gid":999,"members":[],"original":"docker:x:999:"}` {
+		t.Errorf("Unexpected JSON: %s", jsonData)
+	}
+}
+
+func TestGroupParserEmpty(t *testing.T) {
+	parser := &GroupParser{}
+
+	_, err := parser.Parse("")
+	if err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
@@ -0,0 +1,54 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GshadowParser parses /etc/gshadow file format. The password hash is only
+// included in the output when IncludeHash is set.
+type GshadowParser struct {
+	IncludeHash bool
+}
+
+// GshadowEntry represents a single gshadow file entry
+type GshadowEntry struct {
+	GroupName      string   `json:"group_name"`
+	Password       string   `json:"password,omitempty"`
+	PasswordStatus string   `json:"password_status"`
+	HashAlgorithm  string   `json:"hash_algorithm,omitempty"`
+	Administrators []string `json:"administrators"`
+	Members        []string `json:"members"`
+}
+
+func (p *GshadowParser) Name() string {
+	return "gshadow"
+}
+
+func (p *GshadowParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	var entries []GshadowEntry
+
+	// gshadow format: group_name:password:administrators:members
+	for _, record := range parseColonRecords(input, 4) {
+		fields := record.Fields
+		entry := GshadowEntry{
+			GroupName:      fields[0],
+			Administrators: splitMemberList(fields[2]),
+			Members:        splitMemberList(fields[3]),
+		}
+
+		entry.PasswordStatus, entry.HashAlgorithm = describePasswordHash(fields[1])
+		if p.IncludeHash {
+			entry.Password = fields[1]
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,60 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestGshadowParser(t *testing.T) {
+	parser := &GshadowParser{}
+
+	testInput := `root:*::
+sudo:!::alice,bob
+devs:$6$salt$hash:alice:bob,carol`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]GshadowEntry)
+	if !ok {
+		t.Fatalf("Expected []GshadowEntry, got %T", result)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	if entries[0].PasswordStatus != PasswordStatusNoLogin || len(entries[0].Members) != 0 {
+		t.Errorf("Unexpected root entry: %+v", entries[0])
+	}
+	if entries[1].PasswordStatus != PasswordStatusLocked || len(entries[1].Members) != 2 {
+		t.Errorf("Unexpected sudo entry: %+v", entries[1])
+	}
+
+	devs := entries[2]
+	if devs.HashAlgorithm != "sha512" || devs.Password != "" {
+		t.Errorf("Unexpected devs password fields: %+v", devs)
+	}
+	if len(devs.Administrators) != 1 || devs.Administrators[0] != "alice" {
+		t.Errorf("Unexpected administrators: %v", devs.Administrators)
+	}
+	if len(devs.Members) != 2 || devs.Members[1] != "carol" {
+		t.Errorf("Unexpected members: %v", devs.Members)
+	}
+
+	parser.IncludeHash = true
+	result, _ = parser.Parse(`devs:$6$salt$hash:alice:bob,carol`)
+	if result.([]GshadowEntry)[0].Password != "$6$salt$hash" {
+		t.Error("Expected hash to be included")
+	}
+}
+
+func TestGshadowParserEmpty(t *testing.T) {
+	parser := &GshadowParser{}
+
+	_, err := parser.Parse("")
+	if err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
@@ -0,0 +1,110 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HostnamectlParser parses hostnamectl (hostnamectl status) output
+type HostnamectlParser struct{}
+
+// Hostnamectl is the host identity and platform reported by hostnamectl.
+// The Kernel line is split into KernelName and KernelRelease, with the
+// release broken into parts as for uname. Chassis drops the icon that
+// follows the chassis type. Lines without a field of their own are kept in
+// Extra.
+type Hostnamectl struct {
+	StaticHostname    string            `json:"static_hostname,omitempty"`
+	TransientHostname string            `json:"transient_hostname,omitempty"`
+	PrettyHostname    string            `json:"pretty_hostname,omitempty"`
+	IconName          string            `json:"icon_name,omitempty"`
+	Chassis           string            `json:"chassis,omitempty"`
+	Deployment        string            `json:"deployment,omitempty"`
+	Location          string            `json:"location,omitempty"`
+	MachineID         string            `json:"machine_id,omitempty"`
+	BootID            string            `json:"boot_id,omitempty"`
+	Virtualization    string            `json:"virtualization,omitempty"`
+	OperatingSystem   string            `json:"operating_system,omitempty"`
+	CPEOSName         string            `json:"cpe_os_name,omitempty"`
+	KernelName        string            `json:"kernel_name,omitempty"`
+	KernelRelease     string            `json:"kernel_release,omitempty"`
+	Kernel            *KernelInfo       `json:"kernel,omitempty"`
+	Architecture      string            `json:"architecture,omitempty"`
+	HardwareVendor    string            `json:"hardware_vendor,omitempty"`
+	HardwareModel     string            `json:"hardware_model,omitempty"`
+	FirmwareVersion   string            `json:"firmware_version,omitempty"`
+	FirmwareDate      string            `json:"firmware_date,omitempty"`
+	Extra             map[string]string `json:"extra,omitempty"`
+}
+
+func (p *HostnamectlParser) Name() string {
+	return "hostnamectl"
+}
+
+func (p *HostnamectlParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	host := Hostnamectl{}
+
+	// Example: " Static hostname: web01" with the labels right aligned
+	for _, line := range splitLines(input) {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "Static hostname":
+			host.StaticHostname = value
+		case "Transient hostname":
+			host.TransientHostname = value
+		case "Pretty hostname":
+			host.PrettyHostname = value
+		case "Icon name":
+			host.IconName = value
+		case "Chassis":
+			if fields := strings.Fields(value); len(fields) > 0 {
+				host.Chassis = fields[0]
+			}
+		case "Deployment":
+			host.Deployment = value
+		case "Location":
+			host.Location = value
+		case "Machine ID":
+			host.MachineID = value
+		case "Boot ID":
+			host.BootID = value
+		case "Virtualization":
+			host.Virtualization = value
+		case "Operating System":
+			host.OperatingSystem = value
+		case "CPE OS Name":
+			host.CPEOSName = value
+		case "Kernel":
+			host.KernelName, host.KernelRelease, _ = strings.Cut(value, " ")
+			host.Kernel = parseKernelInfo(host.KernelRelease, "", time.UTC)
+		case "Architecture":
+			host.Architecture = value
+		case "Hardware Vendor":
+			host.HardwareVendor = value
+		case "Hardware Model":
+			host.HardwareModel = value
+		case "Firmware Version":
+			host.FirmwareVersion = value
+		case "Firmware Date":
+			host.FirmwareDate = value
+		default:
+			if host.Extra == nil {
+				host.Extra = map[string]string{}
+			}
+			host.Extra[key] = value
+		}
+	}
+
+	return host, nil
+}
@@ -0,0 +1,58 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestHostnamectlParser(t *testing.T) {
+	parser := &HostnamectlParser{}
+
+	testInput := ` Static hostname: web01
+       Icon name: computer-vm
+         Chassis: vm 🖴
+      Machine ID: 4b1c2d3e4f5061728394a5b6c7d8e9f0
+         Boot ID: 0f9e8d7c6b5a49382716f5e4d3c2b1a0
+  Virtualization: kvm
+Operating System: Ubuntu 22.04.3 LTS
+          Kernel: Linux 5.15.0-91-generic
+    Architecture: x86-64
+ Hardware Vendor: QEMU
+  Hardware Model: Standard PC _i440FX + PIIX, 1996_
+Firmware Version: 1.16.2-debian-1.16.2-1
+   Firmware Date: Tue 2014-04-01
+    Firmware Age: 10y 2month 1w 3d`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	host, ok := result.(Hostnamectl)
+	if !ok {
+		t.Fatalf("Expected Hostnamectl, got %T", result)
+	}
+
+	if host.StaticHostname != "web01" || host.Chassis != "vm" || host.Virtualization != "kvm" ||
+		host.OperatingSystem != "Ubuntu 22.04.3 LTS" || host.Architecture != "x86-64" {
+		t.Errorf("Unexpected host %+v", host)
+	}
+	if host.KernelName != "Linux" || host.KernelRelease != "5.15.0-91-generic" ||
+		host.Kernel == nil || host.Kernel.Major != 5 || host.Kernel.Minor != 15 {
+		t.Errorf("Unexpected kernel %+v", host)
+	}
+	if host.HardwareModel != "Standard PC _i440FX + PIIX, 1996_" || host.FirmwareDate != "Tue 2014-04-01" {
+		t.Errorf("Unexpected hardware %+v", host)
+	}
+	if host.Extra["Firmware Age"] != "10y 2month 1w 3d" {
+		t.Errorf("Unexpected extra %v", host.Extra)
+	}
+}
+
+func TestHostnamectlParserEmpty(t *testing.T) {
+	parser := &HostnamectlParser{}
+
+	_, err := parser.Parse("")
+	if err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
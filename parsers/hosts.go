@@ -2,20 +2,43 @@ package parsers
 
 import (
 	"fmt"
+	"net/netip"
+	"regexp"
 	"strings"
 )
 
-// HostsParser parses /etc/hosts file format
-type HostsParser struct{}
+// HostsParser parses /etc/hosts file format. With Validate set, invalid
+// addresses and hostnames and duplicate or conflicting mappings are reported
+// in each entry's Issues field. With Index set, a HostsOutput with a
+// name-to-addresses lookup is returned instead of the entry list.
+type HostsParser struct {
+	Validate bool
+	Index    bool
+}
 
 // HostsEntry represents a single hosts file entry
 type HostsEntry struct {
-	IP        string   `json:"ip"`
-	Hostnames []string `json:"hostnames"`
-	Comment   string   `json:"comment,omitempty"`
-	Original  string   `json:"original"`
+	IP          string   `json:"ip"`
+	AddressType string   `json:"address_type,omitempty"`
+	Hostnames   []string `json:"hostnames"`
+	Comment     string   `json:"comment,omitempty"`
+	Original    string   `json:"original"`
+	Issues      []string `json:"issues,omitempty"`
+}
+
+// HostsOutput is the hosts file with each hostname's addresses in file order
+type HostsOutput struct {
+	Entries []HostsEntry        `json:"entries"`
+	Names   map[string][]string `json:"names"`
 }
 
+// Address types of hosts entries
+const (
+	AddressTypeIPv4    = "ipv4"
+	AddressTypeIPv6    = "ipv6"
+	AddressTypeInvalid = "invalid"
+)
+
 func (p *HostsParser) Name() string {
 	return "hosts"
 }
@@ -32,7 +55,7 @@ func (p *HostsParser) Parse(input string) (interface{}, error) {
 	for _, line := range lines {
 		original := line
 		line = strings.TrimSpace(line)
-		
+
 		// Skip empty lines
 		if line == "" {
 			continue
@@ -65,9 +88,103 @@ func (p *HostsParser) Parse(input string) (interface{}, error) {
 			// Only IP, no hostnames
 			entry.IP = fields[0]
 		}
+		entry.AddressType = hostsAddressType(entry.IP)
 
 		entries = append(entries, entry)
 	}
 
-	return entries, nil
+	if p.Validate {
+		validateHostsEntries(entries)
+	}
+
+	if !p.Index {
+		return entries, nil
+	}
+
+	output := HostsOutput{
+		Entries: entries,
+		Names:   map[string][]string{},
+	}
+	for _, entry := range entries {
+		if entry.AddressType == AddressTypeInvalid {
+			continue
+		}
+		for _, name := range entry.Hostnames {
+			name = strings.ToLower(name)
+			if findInSlice(output.Names[name], entry.IP) == -1 {
+				output.Names[name] = append(output.Names[name], entry.IP)
+			}
+		}
+	}
+	if output.Entries == nil {
+		output.Entries = []HostsEntry{}
+	}
+
+	return output, nil
+}
+
+// hostsAddressType classifies an address as IPv4, IPv6 (including zoned
+// link-local addresses such as fe80::1%eth0) or invalid
+func hostsAddressType(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	addr, err := netip.ParseAddr(ip)
+	switch {
+	case err != nil:
+		return AddressTypeInvalid
+	case addr.Is4():
+		return AddressTypeIPv4
+	default:
+		return AddressTypeIPv6
+	}
+}
+
+// validateHostsEntries reports invalid addresses and hostnames, names
+// listed twice for the same address and names mapped to different addresses
+// of the same type. A name may have both an IPv4 and an IPv6 address.
+func validateHostsEntries(entries []HostsEntry) {
+	hostnameRe := regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_-]*[A-Za-z0-9_])?(\.[A-Za-z0-9_]([A-Za-z0-9_-]*[A-Za-z0-9_])?)*\.?$`)
+
+	// Addresses seen for each name, by address type
+	seen := map[string]map[string][]string{}
+
+	for i := range entries {
+		entry := &entries[i]
+		if entry.IP == "" {
+			continue
+		}
+
+		if entry.AddressType == AddressTypeInvalid {
+			entry.Issues = append(entry.Issues, fmt.Sprintf("invalid IP address %q", entry.IP))
+			continue
+		}
+		if len(entry.Hostnames) == 0 {
+			entry.Issues = append(entry.Issues, fmt.Sprintf("no hostnames for %s", entry.IP))
+		}
+
+		addr, _ := netip.ParseAddr(entry.IP)
+		for _, hostname := range entry.Hostnames {
+			if !hostnameRe.MatchString(hostname) || len(hostname) > 253 {
+				entry.Issues = append(entry.Issues, fmt.Sprintf("invalid hostname %q", hostname))
+			}
+
+			name := strings.ToLower(hostname)
+			if seen[name] == nil {
+				seen[name] = map[string][]string{}
+			}
+			duplicate := false
+			for _, other := range seen[name][entry.AddressType] {
+				if otherAddr, _ := netip.ParseAddr(other); otherAddr == addr {
+					entry.Issues = append(entry.Issues, fmt.Sprintf("duplicate mapping of %s to %s", hostname, entry.IP))
+					duplicate = true
+				} else {
+					entry.Issues = append(entry.Issues, fmt.Sprintf("%s is also mapped to %s", hostname, other))
+				}
+			}
+			if !duplicate {
+				seen[name][entry.AddressType] = append(seen[name][entry.AddressType], entry.IP)
+			}
+		}
+	}
 }
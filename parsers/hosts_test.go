@@ -140,3 +140,78 @@ func TestHostsParserEmpty(t *testing.T) {
 		t.Errorf("Expected 1 entry (comment), got %d", len(entries))
 	}
 }
+
+func TestHostsParserValidate(t *testing.T) {
+	parser := &HostsParser{Validate: true}
+
+	testInput := `127.0.0.1	localhost
+::1		localhost
+fe80::1%lo0	linklocal
+192.168.1.10	web web.example.com
+192.168.1.11	web
+192.168.1.10	WEB
+300.1.1.1	broken
+10.0.0.1	bad_host! ok-host`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]HostsEntry)
+	types := []string{AddressTypeIPv4, AddressTypeIPv6, AddressTypeIPv6, AddressTypeIPv4, AddressTypeIPv4, AddressTypeIPv4, AddressTypeInvalid, AddressTypeIPv4}
+	for i, want := range types {
+		if entries[i].AddressType != want {
+			t.Errorf("Entry %d: expected %s, got %s", i, want, entries[i].AddressType)
+		}
+	}
+
+	// localhost on IPv4 and IPv6 is not a conflict
+	for i := 0; i < 4; i++ {
+		if len(entries[i].Issues) != 0 {
+			t.Errorf("Entry %d: expected no issues, got %v", i, entries[i].Issues)
+		}
+	}
+	if len(entries[4].Issues) != 1 || entries[4].Issues[0] != "web is also mapped to 192.168.1.10" {
+		t.Errorf("Expected conflict, got %v", entries[4].Issues)
+	}
+	if len(entries[5].Issues) == 0 || entries[5].Issues[0] != "duplicate mapping of WEB to 192.168.1.10" {
+		t.Errorf("Expected duplicate, got %v", entries[5].Issues)
+	}
+	if len(entries[6].Issues) != 1 || entries[6].Issues[0] != `invalid IP address "300.1.1.1"` {
+		t.Errorf("Expected invalid address, got %v", entries[6].Issues)
+	}
+	if len(entries[7].Issues) != 1 || entries[7].Issues[0] != `invalid hostname "bad_host!"` {
+		t.Errorf("Expected invalid hostname, got %v", entries[7].Issues)
+	}
+}
+
+func TestHostsParserIndex(t *testing.T) {
+	parser := &HostsParser{Index: true}
+
+	testInput := `127.0.0.1	localhost
+::1		localhost ip6-localhost
+# comment
+192.168.1.10	Web`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	output, ok := result.(HostsOutput)
+	if !ok {
+		t.Fatalf("Expected HostsOutput, got %T", result)
+	}
+	if len(output.Entries) != 4 {
+		t.Errorf("Expected 4 entries, got %d", len(output.Entries))
+	}
+
+	localhost := output.Names["localhost"]
+	if len(localhost) != 2 || localhost[0] != "127.0.0.1" || localhost[1] != "::1" {
+		t.Errorf("Unexpected localhost addresses %v", localhost)
+	}
+	if web := output.Names["web"]; len(web) != 1 || web[0] != "192.168.1.10" {
+		t.Errorf("Unexpected web addresses %v", web)
+	}
+}
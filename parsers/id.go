@@ -7,17 +7,30 @@ import (
 	"strings"
 )
 
-// IdParser parses id command output
-type IdParser struct{}
+// IdParser parses id command output. The full "uid=... gid=... groups=..."
+// form (id, id -a) gives an IdEntry per user, as printed by "id alice bob".
+// The single value forms (-u, -g, -G, each with -n for names, and -Z) give
+// IdValues per user. Flags holds the options id was run with; without it
+// the form is guessed from the values.
+type IdParser struct {
+	Flags string
+}
 
-// IdEntry represents id command output
+// IdEntry represents id command output. The effective user and group are
+// only printed when they differ from the real ones. SELinux is the Context
+// split into its parts.
 type IdEntry struct {
-	UID      int      `json:"uid"`
-	User     string   `json:"user"`
-	GID      int      `json:"gid"`
-	Group    string   `json:"group"`
-	Groups   []IdGroup `json:"groups"`
-	Context  string   `json:"context,omitempty"`
+	UID            int             `json:"uid"`
+	User           string          `json:"user"`
+	GID            int             `json:"gid"`
+	Group          string          `json:"group"`
+	EUID           *int            `json:"euid,omitempty"`
+	EffectiveUser  string          `json:"effective_user,omitempty"`
+	EGID           *int            `json:"egid,omitempty"`
+	EffectiveGroup string          `json:"effective_group,omitempty"`
+	Groups         []IdGroup       `json:"groups"`
+	Context        string          `json:"context,omitempty"`
+	SELinux        *SELinuxContext `json:"selinux,omitempty"`
 }
 
 // IdGroup represents a group entry
@@ -26,6 +39,35 @@ type IdGroup struct {
 	Name string `json:"name"`
 }
 
+// SELinuxContext is a security context such as
+// "unconfined_u:unconfined_r:unconfined_t:s0-s0:c0.c1023". Level is the MLS
+// or MCS range, which may itself contain colons.
+type SELinuxContext struct {
+	User  string `json:"user"`
+	Role  string `json:"role"`
+	Type  string `json:"type"`
+	Level string `json:"level,omitempty"`
+}
+
+// IdValues is the output of a single value form of id. Field is the value
+// printed: the user (-u), the group (-g), all groups (-G) or the SELinux
+// context (-Z). Numbers are given in IDs, names (-n) in Names.
+type IdValues struct {
+	Field   string          `json:"field"`
+	IDs     []int           `json:"ids,omitempty"`
+	Names   []string        `json:"names,omitempty"`
+	Context string          `json:"context,omitempty"`
+	SELinux *SELinuxContext `json:"selinux,omitempty"`
+}
+
+// Fields printed by the single value forms of id
+const (
+	IdFieldUser    = "user"
+	IdFieldGroup   = "group"
+	IdFieldGroups  = "groups"
+	IdFieldContext = "context"
+)
+
 func (p *IdParser) Name() string {
 	return "id"
 }
@@ -36,52 +78,186 @@ func (p *IdParser) Parse(input string) (interface{}, error) {
 		return nil, fmt.Errorf("empty input")
 	}
 
-	entry := IdEntry{}
+	if !strings.Contains(input, "uid=") {
+		return parseIdValues(strings.TrimRight(input, "\x00"), p.Flags), nil
+	}
 
 	// Example: uid=1000(user) gid=1000(user) groups=1000(user),4(adm),24(cdrom),27(sudo)
-	
-	// Parse UID
-	uidRe := regexp.MustCompile(`uid=(\d+)\(([^)]+)\)`)
-	if matches := uidRe.FindStringSubmatch(input); len(matches) > 2 {
-		if uid, err := strconv.Atoi(matches[1]); err == nil {
-			entry.UID = uid
+	var entries []IdEntry
+	for _, line := range splitLines(input) {
+		// Errors such as "id: 'carol': no such user" are skipped
+		if !strings.Contains(line, "uid=") {
+			continue
 		}
-		entry.User = matches[2]
+		entries = append(entries, parseIdLine(line))
 	}
 
-	// Parse GID
-	gidRe := regexp.MustCompile(`gid=(\d+)\(([^)]+)\)`)
-	if matches := gidRe.FindStringSubmatch(input); len(matches) > 2 {
-		if gid, err := strconv.Atoi(matches[1]); err == nil {
-			entry.GID = gid
+	return entries, nil
+}
+
+// parseIdLine parses one "uid=... gid=... groups=... context=..." line.
+// Names may contain spaces ("1001(domain users)") and are missing for IDs
+// without a passwd or group entry.
+func parseIdLine(line string) IdEntry {
+	idRe := regexp.MustCompile(`\b(uid|gid|euid|egid)=(\d+)(?:\(([^)]*)\))?`)
+	groupsRe := regexp.MustCompile(`\bgroups=(.*?)(?:\s+context=|$)`)
+	groupRe := regexp.MustCompile(`(\d+)(?:\(([^)]*)\))?`)
+	contextRe := regexp.MustCompile(`\bcontext=(\S+)`)
+
+	entry := IdEntry{}
+
+	for _, matches := range idRe.FindAllStringSubmatch(line, -1) {
+		id, _ := strconv.Atoi(matches[2])
+		switch matches[1] {
+		case "uid":
+			entry.UID, entry.User = id, matches[3]
+		case "gid":
+			entry.GID, entry.Group = id, matches[3]
+		case "euid":
+			entry.EUID, entry.EffectiveUser = &id, matches[3]
+		case "egid":
+			entry.EGID, entry.EffectiveGroup = &id, matches[3]
 		}
-		entry.Group = matches[2]
 	}
 
-	// Parse groups
-	groupsRe := regexp.MustCompile(`groups=([^,\s]+(?:,[^,\s]+)*)`)
-	if matches := groupsRe.FindStringSubmatch(input); len(matches) > 1 {
-		groupStr := matches[1]
-		groupEntries := strings.Split(groupStr, ",")
-		
-		for _, groupEntry := range groupEntries {
-			groupRe := regexp.MustCompile(`(\d+)\(([^)]+)\)`)
-			if groupMatches := groupRe.FindStringSubmatch(groupEntry); len(groupMatches) > 2 {
-				group := IdGroup{}
-				if gid, err := strconv.Atoi(groupMatches[1]); err == nil {
-					group.GID = gid
-				}
-				group.Name = groupMatches[2]
-				entry.Groups = append(entry.Groups, group)
+	if matches := groupsRe.FindStringSubmatch(line); matches != nil {
+		for _, groupMatches := range groupRe.FindAllStringSubmatch(matches[1], -1) {
+			group := IdGroup{
+				Name: groupMatches[2],
 			}
+			group.GID, _ = strconv.Atoi(groupMatches[1])
+			entry.Groups = append(entry.Groups, group)
 		}
 	}
 
 	// Parse context (SELinux)
-	contextRe := regexp.MustCompile(`context=([^\s]+)`)
-	if matches := contextRe.FindStringSubmatch(input); len(matches) > 1 {
+	if matches := contextRe.FindStringSubmatch(line); matches != nil {
 		entry.Context = matches[1]
+		entry.SELinux = parseSELinuxContext(entry.Context)
+	}
+
+	return entry
+}
+
+// parseIdValues parses the single value forms of id. Users are separated by
+// newlines, or by two NULs with -z, which also separates groups by NULs.
+func parseIdValues(input, flags string) []IdValues {
+	field, names := parseIdFlags(flags)
+
+	var records [][]string
+	if strings.Contains(input, "\x00") {
+		for _, record := range strings.Split(input, "\x00\x00") {
+			records = append(records, strings.Split(strings.Trim(record, "\x00"), "\x00"))
+		}
+	} else {
+		for _, line := range splitLines(input) {
+			records = append(records, splitFields(line))
+		}
 	}
 
-	return entry, nil
+	var results []IdValues
+	for _, values := range records {
+		result := IdValues{
+			Field: field,
+		}
+		if result.Field == "" {
+			result.Field = guessIdField(values)
+		}
+
+		if result.Field == IdFieldContext {
+			result.Context = strings.Join(values, " ")
+			result.SELinux = parseSELinuxContext(result.Context)
+			results = append(results, result)
+			continue
+		}
+
+		var ids []int
+		for _, value := range values {
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				break
+			}
+			ids = append(ids, id)
+		}
+		if names || len(ids) != len(values) {
+			result.Names = values
+		} else {
+			result.IDs = ids
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// parseIdFlags returns the field selected by id's options and whether
+// names were requested. The field is empty when no option selects one.
+func parseIdFlags(flags string) (string, bool) {
+	field, names := "", false
+	for _, flag := range strings.Fields(flags) {
+		if strings.HasPrefix(flag, "--") {
+			switch strings.TrimPrefix(flag, "--") {
+			case "user":
+				field = IdFieldUser
+			case "group":
+				field = IdFieldGroup
+			case "groups":
+				field = IdFieldGroups
+			case "context":
+				field = IdFieldContext
+			case "name":
+				names = true
+			}
+			continue
+		}
+		if !strings.HasPrefix(flag, "-") {
+			continue
+		}
+		for _, c := range flag[1:] {
+			switch c {
+			case 'u':
+				field = IdFieldUser
+			case 'g':
+				field = IdFieldGroup
+			case 'G':
+				field = IdFieldGroups
+			case 'Z':
+				field = IdFieldContext
+			case 'n':
+				names = true
+			}
+		}
+	}
+	return field, names
+}
+
+// guessIdField picks the field for output parsed without Flags: a value
+// shaped like an SELinux context is -Z, several values are -G and a single
+// value is taken as the user (-u)
+func guessIdField(values []string) string {
+	switch {
+	case len(values) == 1 && strings.Count(values[0], ":") >= 2:
+		return IdFieldContext
+	case len(values) > 1:
+		return IdFieldGroups
+	default:
+		return IdFieldUser
+	}
+}
+
+// parseSELinuxContext splits a context into user, role, type and level
+func parseSELinuxContext(context string) *SELinuxContext {
+	parts := strings.SplitN(context, ":", 4)
+	if len(parts) < 3 {
+		return nil
+	}
+	selinux := &SELinuxContext{
+		User: parts[0],
+		Role: parts[1],
+		Type: parts[2],
+	}
+	if len(parts) == 4 {
+		selinux.Level = parts[3]
+	}
+	return selinux
 }
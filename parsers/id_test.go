@@ -2,6 +2,7 @@ package parsers
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 )
 
@@ -15,10 +16,11 @@ func TestIdParser(t *testing.T) {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	entry, ok := result.(IdEntry)
-	if !ok {
-		t.Fatalf("Expected IdEntry, got %T", result)
+	entries, ok := result.([]IdEntry)
+	if !ok || len(entries) != 1 {
+		t.Fatalf("Expected one IdEntry, got %#v", result)
 	}
+	entry := entries[0]
 
 	// Test UID
 	if entry.UID != 1000 {
@@ -85,10 +87,11 @@ func TestIdParserWithContext(t *testing.T) {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	entry, ok := result.(IdEntry)
-	if !ok {
-		t.Fatalf("Expected IdEntry, got %T", result)
+	entries, ok := result.([]IdEntry)
+	if !ok || len(entries) != 1 {
+		t.Fatalf("Expected one IdEntry, got %#v", result)
 	}
+	entry := entries[0]
 
 	// Test root user
 	if entry.UID != 0 {
@@ -114,10 +117,11 @@ func TestIdParserSimple(t *testing.T) {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	entry, ok := result.(IdEntry)
-	if !ok {
-		t.Fatalf("Expected IdEntry, got %T", result)
+	entries, ok := result.([]IdEntry)
+	if !ok || len(entries) != 1 {
+		t.Fatalf("Expected one IdEntry, got %#v", result)
 	}
+	entry := entries[0]
 
 	if entry.UID != 500 {
 		t.Errorf("Expected UID 500, got %d", entry.UID)
@@ -143,3 +147,129 @@ func TestIdParserEmpty(t *testing.T) {
 		t.Error("Expected error for whitespace-only input")
 	}
 }
+
+func TestIdParserContextParts(t *testing.T) {
+	parser := &IdParser{}
+
+	result, err := parser.Parse("uid=0(root) gid=0(root) groups=0(root) context=unconfined_u:unconfined_r:unconfined_t:s0-s0:c0.c1023")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entry := result.([]IdEntry)[0]
+	selinux := entry.SELinux
+	if selinux == nil || selinux.User != "unconfined_u" || selinux.Role != "unconfined_r" ||
+		selinux.Type != "unconfined_t" || selinux.Level != "s0-s0:c0.c1023" {
+		t.Errorf("Unexpected SELinux context %+v", selinux)
+	}
+}
+
+func TestIdParserGroupNamesAndEffectiveIds(t *testing.T) {
+	parser := &IdParser{}
+
+	testInput := "uid=1000(alice) gid=1000(alice) euid=0(root) egid=0(root) groups=1000(alice),1001(domain users),2000,27(sudo)"
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entry := result.([]IdEntry)[0]
+	if entry.UID != 1000 || entry.EUID == nil || *entry.EUID != 0 || entry.EffectiveUser != "root" ||
+		entry.EGID == nil || *entry.EGID != 0 || entry.EffectiveGroup != "root" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+
+	want := []IdGroup{{1000, "alice"}, {1001, "domain users"}, {2000, ""}, {27, "sudo"}}
+	if len(entry.Groups) != len(want) {
+		t.Fatalf("Expected %d groups, got %+v", len(want), entry.Groups)
+	}
+	for i, group := range want {
+		if entry.Groups[i] != group {
+			t.Errorf("Expected group %+v, got %+v", group, entry.Groups[i])
+		}
+	}
+}
+
+func TestIdParserMultipleUsers(t *testing.T) {
+	parser := &IdParser{}
+
+	testInput := `uid=1000(alice) gid=1000(alice) groups=1000(alice),27(sudo)
+id: 'carol': no such user
+uid=1001(bob) gid=1001(bob) groups=1001(bob)`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]IdEntry)
+	if !ok {
+		t.Fatalf("Expected []IdEntry, got %T", result)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].User != "alice" || len(entries[0].Groups) != 2 || entries[1].User != "bob" || entries[1].UID != 1001 {
+		t.Errorf("Unexpected entries %+v", entries)
+	}
+}
+
+func TestIdParserSingleValues(t *testing.T) {
+	tests := []struct {
+		flags, input string
+		field        string
+		ids          []int
+		names        []string
+	}{
+		{"-u", "1000", IdFieldUser, []int{1000}, nil},
+		{"-un", "alice", IdFieldUser, nil, []string{"alice"}},
+		{"-g", "1000", IdFieldGroup, []int{1000}, nil},
+		{"-G", "1000 4 27", IdFieldGroups, []int{1000, 4, 27}, nil},
+		{"-Gn", "alice adm sudo", IdFieldGroups, nil, []string{"alice", "adm", "sudo"}},
+		{"-Gz", "1000\x004\x0027\x00", IdFieldGroups, []int{1000, 4, 27}, nil},
+		// Guessed without flags
+		{"", "1000 4 27", IdFieldGroups, []int{1000, 4, 27}, nil},
+		{"", "alice", IdFieldUser, nil, []string{"alice"}},
+	}
+
+	for _, tt := range tests {
+		parser := &IdParser{Flags: tt.flags}
+		result, err := parser.Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+		}
+		results, ok := result.([]IdValues)
+		if !ok || len(results) != 1 {
+			t.Fatalf("Parse(%q): expected one IdValues, got %#v", tt.input, result)
+		}
+		values := results[0]
+		if values.Field != tt.field {
+			t.Errorf("Parse(%q) with %q: expected field %q, got %q", tt.input, tt.flags, tt.field, values.Field)
+		}
+		if fmt.Sprint(values.IDs) != fmt.Sprint(tt.ids) || fmt.Sprint(values.Names) != fmt.Sprint(tt.names) {
+			t.Errorf("Parse(%q) with %q: expected %v %v, got %v %v", tt.input, tt.flags, tt.ids, tt.names, values.IDs, values.Names)
+		}
+	}
+
+	// id -Gn for several users
+	parser := &IdParser{Flags: "-Gn"}
+	result, err := parser.Parse("alice adm\nbob")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if values, ok := result.([]IdValues); !ok || len(values) != 2 || len(values[0].Names) != 2 || values[1].Names[0] != "bob" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	// id -Z
+	parser = &IdParser{}
+	result, err = parser.Parse("system_u:system_r:sshd_t:s0")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	values := result.([]IdValues)[0]
+	if values.Field != IdFieldContext || values.SELinux == nil || values.SELinux.Type != "sshd_t" || values.SELinux.Level != "s0" {
+		t.Errorf("Unexpected context %+v", values)
+	}
+}
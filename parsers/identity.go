@@ -0,0 +1,298 @@
+package parsers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// IdentityTable resolves user and group IDs to names, and names to IDs,
+// using parsed passwd and group data. It lets output captured on another
+// host be enriched with that host's account database.
+type IdentityTable struct {
+	userNames  map[int]string
+	userIDs    map[string]int
+	groupNames map[int]string
+	groupIDs   map[string]int
+}
+
+// NewIdentityTable builds an IdentityTable from parsed passwd and group entries.
+// Either slice may be nil. The first entry wins when IDs or names repeat.
+func NewIdentityTable(users []PasswdEntry, groups []GroupEntry) *IdentityTable {
+	table := &IdentityTable{
+		userNames:  map[int]string{},
+		userIDs:    map[string]int{},
+		groupNames: map[int]string{},
+		groupIDs:   map[string]int{},
+	}
+
+	for _, user := range users {
+		if _, exists := table.userNames[user.UID]; !exists {
+			table.userNames[user.UID] = user.Username
+		}
+		if _, exists := table.userIDs[user.Username]; !exists {
+			table.userIDs[user.Username] = user.UID
+		}
+	}
+
+	for _, group := range groups {
+		if _, exists := table.groupNames[group.GID]; !exists {
+			table.groupNames[group.GID] = group.GroupName
+		}
+		if _, exists := table.groupIDs[group.GroupName]; !exists {
+			table.groupIDs[group.GroupName] = group.GID
+		}
+	}
+
+	return table
+}
+
+// LoadIdentityTable reads passwd and group files and builds an IdentityTable.
+// An empty path skips that file.
+func LoadIdentityTable(passwdFile, groupFile string) (*IdentityTable, error) {
+	var users []PasswdEntry
+	var groups []GroupEntry
+
+	if passwdFile != "" {
+		data, err := os.ReadFile(passwdFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading passwd file: %w", err)
+		}
+		if strings.TrimSpace(string(data)) != "" {
+			result, err := (&PasswdParser{}).Parse(string(data))
+			if err != nil {
+				return nil, fmt.Errorf("parsing passwd file: %w", err)
+			}
+			users = result.([]PasswdEntry)
+		}
+	}
+
+	if groupFile != "" {
+		data, err := os.ReadFile(groupFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading group file: %w", err)
+		}
+		if strings.TrimSpace(string(data)) != "" {
+			result, err := (&GroupParser{}).Parse(string(data))
+			if err != nil {
+				return nil, fmt.Errorf("parsing group file: %w", err)
+			}
+			groups = result.([]GroupEntry)
+		}
+	}
+
+	return NewIdentityTable(users, groups), nil
+}
+
+// UserName returns the user name for a UID
+func (t *IdentityTable) UserName(uid int) (string, bool) {
+	name, ok := t.userNames[uid]
+	return name, ok
+}
+
+// UserID returns the UID for a user name
+func (t *IdentityTable) UserID(name string) (int, bool) {
+	uid, ok := t.userIDs[name]
+	return uid, ok
+}
+
+// GroupName returns the group name for a GID
+func (t *IdentityTable) GroupName(gid int) (string, bool) {
+	name, ok := t.groupNames[gid]
+	return name, ok
+}
+
+// GroupID returns the GID for a group name
+func (t *IdentityTable) GroupID(name string) (int, bool) {
+	gid, ok := t.groupIDs[name]
+	return gid, ok
+}
+
+// Enrich adds resolved names and numeric IDs to parser results that carry
+// user or group fields (ls, find, stat, id and ps, and ls and find trees).
+// Numeric owners are replaced by their names, with the number kept in the
+// UID/GID field. Other results are returned unchanged.
+func (t *IdentityTable) Enrich(result interface{}) interface{} {
+	switch r := result.(type) {
+	case []LsEntry:
+		for i := range r {
+			r[i].Owner, r[i].UID = t.resolveUser(r[i].Owner, r[i].UID)
+			r[i].Group, r[i].GID = t.resolveGroup(r[i].Group, r[i].GID)
+		}
+	case []FindEntry:
+		for i := range r {
+			r[i].Owner, r[i].UID = t.resolveUser(r[i].Owner, r[i].UID)
+			r[i].Group, r[i].GID = t.resolveGroup(r[i].Group, r[i].GID)
+		}
+	case []FileTreeNode:
+		t.enrichTree(r)
+	case []StatEntry:
+		for i := range r {
+			t.enrichStat(&r[i])
+		}
+	case []PsEntry:
+		for i := range r {
+			r[i].User, r[i].UID = t.resolveUser(r[i].User, r[i].UID)
+		}
+	case IdEntry:
+		t.enrichId(&r)
+		return r
+	case []IdEntry:
+		for i := range r {
+			t.enrichId(&r[i])
+		}
+	case []IdValues:
+		for i := range r {
+			t.enrichIdValues(&r[i])
+		}
+	}
+
+	return result
+}
+
+func (t *IdentityTable) enrichTree(nodes []FileTreeNode) {
+	for i := range nodes {
+		// Directories added for section headers have no owner of their own
+		if nodes[i].Owner != "" {
+			nodes[i].Owner, nodes[i].UID = t.resolveUser(nodes[i].Owner, nodes[i].UID)
+		}
+		if nodes[i].Group != "" {
+			nodes[i].Group, nodes[i].GID = t.resolveGroup(nodes[i].Group, nodes[i].GID)
+		}
+		t.enrichTree(nodes[i].Children)
+	}
+}
+
+// enrichStat resolves the IDs and names stat printed; --format output may
+// carry neither
+func (t *IdentityTable) enrichStat(entry *StatEntry) {
+	if entry.User == "" && entry.UID != nil {
+		if name, ok := t.UserName(*entry.UID); ok {
+			entry.User = name
+		}
+	} else if entry.User != "" && entry.UID == nil {
+		// BSD stat and %U print names only
+		if uid, ok := t.UserID(entry.User); ok {
+			entry.UID = &uid
+		}
+	}
+	if entry.Group == "" && entry.GID != nil {
+		if name, ok := t.GroupName(*entry.GID); ok {
+			entry.Group = name
+		}
+	} else if entry.Group != "" && entry.GID == nil {
+		if gid, ok := t.GroupID(entry.Group); ok {
+			entry.GID = &gid
+		}
+	}
+}
+
+func (t *IdentityTable) enrichId(entry *IdEntry) {
+	if entry.User == "" {
+		if name, ok := t.UserName(entry.UID); ok {
+			entry.User = name
+		}
+	}
+	if entry.Group == "" {
+		if name, ok := t.GroupName(entry.GID); ok {
+			entry.Group = name
+		}
+	}
+	for i := range entry.Groups {
+		if entry.Groups[i].Name == "" {
+			if name, ok := t.GroupName(entry.Groups[i].GID); ok {
+				entry.Groups[i].Name = name
+			}
+		}
+	}
+}
+
+// enrichIdValues adds names to the IDs printed by id -u, -g and -G, or the
+// IDs to the names printed with -n. IDs without a name keep the number as
+// their name; IDs are only added when every name resolves.
+func (t *IdentityTable) enrichIdValues(values *IdValues) {
+	var lookupName func(int) (string, bool)
+	var lookupID func(string) (int, bool)
+	switch values.Field {
+	case IdFieldUser:
+		lookupName, lookupID = t.UserName, t.UserID
+	case IdFieldGroup, IdFieldGroups:
+		lookupName, lookupID = t.GroupName, t.GroupID
+	default:
+		return
+	}
+
+	if len(values.Names) == 0 {
+		for _, id := range values.IDs {
+			name, ok := lookupName(id)
+			if !ok {
+				name = strconv.Itoa(id)
+			}
+			values.Names = append(values.Names, name)
+		}
+		return
+	}
+
+	if len(values.IDs) == 0 {
+		var ids []int
+		for _, name := range values.Names {
+			id, ok := lookupID(name)
+			if !ok {
+				return
+			}
+			ids = append(ids, id)
+		}
+		values.IDs = ids
+	}
+}
+
+// resolveUser returns the user name and UID for a printed owner, which may
+// be a name, a numeric UID (ls -n) or a name truncated with "+" (ps)
+func (t *IdentityTable) resolveUser(owner string, uid *int) (string, *int) {
+	if id, err := strconv.Atoi(owner); err == nil {
+		if name, ok := t.UserName(id); ok {
+			owner = name
+		}
+		return owner, &id
+	}
+
+	if id, ok := t.UserID(owner); ok {
+		return owner, &id
+	}
+
+	if prefix := strings.TrimSuffix(owner, "+"); prefix != owner && prefix != "" {
+		match := ""
+		for name := range t.userIDs {
+			if strings.HasPrefix(name, prefix) {
+				if match != "" {
+					return owner, uid
+				}
+				match = name
+			}
+		}
+		if match != "" {
+			id := t.userIDs[match]
+			return match, &id
+		}
+	}
+
+	return owner, uid
+}
+
+// resolveGroup returns the group name and GID for a printed group, which may
+// be a name or a numeric GID
+func (t *IdentityTable) resolveGroup(group string, gid *int) (string, *int) {
+	if id, err := strconv.Atoi(group); err == nil {
+		if name, ok := t.GroupName(id); ok {
+			group = name
+		}
+		return group, &id
+	}
+
+	if id, ok := t.GroupID(group); ok {
+		return group, &id
+	}
+
+	return group, gid
+}
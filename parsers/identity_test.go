@@ -0,0 +1,188 @@
+package parsers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testIdentityTable() *IdentityTable {
+	users := []PasswdEntry{
+		{Username: "root", UID: 0, GID: 0},
+		{Username: "alice", UID: 1000, GID: 1000},
+		{Username: "systemd-network", UID: 100, GID: 102},
+	}
+	groups := []GroupEntry{
+		{GroupName: "root", GID: 0},
+		{GroupName: "alice", GID: 1000},
+		{GroupName: "adm", GID: 4},
+	}
+	return NewIdentityTable(users, groups)
+}
+
+func TestIdentityTableEnrichLs(t *testing.T) {
+	table := testIdentityTable()
+
+	result, err := (&LsParser{}).Parse(`-rw-r--r-- 1 1000 4    1234 Jan 14 09:15 report.txt
+drwxr-xr-x 2 root root 4096 Jan 15 10:30 docs
+-rw-r--r-- 1 2000 2000  10 Jan 15 10:30 orphan`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := table.Enrich(result).([]LsEntry)
+
+	if entries[0].Owner != "alice" || entries[0].UID == nil || *entries[0].UID != 1000 {
+		t.Errorf("Expected alice (1000), got %s (%v)", entries[0].Owner, entries[0].UID)
+	}
+	if entries[0].Group != "adm" || entries[0].GID == nil || *entries[0].GID != 4 {
+		t.Errorf("Expected adm (4), got %s (%v)", entries[0].Group, entries[0].GID)
+	}
+	if entries[1].UID == nil || *entries[1].UID != 0 || entries[1].GID == nil || *entries[1].GID != 0 {
+		t.Errorf("Expected root IDs 0/0, got %v/%v", entries[1].UID, entries[1].GID)
+	}
+
+	// Unknown IDs keep the number
+	if entries[2].Owner != "2000" || entries[2].UID == nil || *entries[2].UID != 2000 {
+		t.Errorf("Expected unresolved 2000, got %s (%v)", entries[2].Owner, entries[2].UID)
+	}
+}
+
+func TestIdentityTableEnrichTree(t *testing.T) {
+	table := testIdentityTable()
+
+	result, err := (&LsParser{Tree: true}).Parse(`.:
+total 8
+drwxr-xr-x 2 1000 1000 4096 Jan 15 10:30 docs
+-rw-r--r-- 1 0    0     120 Jan 15 10:30 notes.txt
+
+./docs:
+total 4
+-rw-r--r-- 1 1000 4    1234 Jan 14 09:15 report.txt`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	nodes := table.Enrich(result).([]FileTreeNode)
+	if len(nodes) != 1 || len(nodes[0].Children) != 2 {
+		t.Fatalf("Unexpected tree: %+v", nodes)
+	}
+
+	docs, notes := nodes[0].Children[0], nodes[0].Children[1]
+	if docs.Owner != "alice" || docs.UID == nil || *docs.UID != 1000 {
+		t.Errorf("Expected alice (1000), got %s (%v)", docs.Owner, docs.UID)
+	}
+	if notes.Owner != "root" || notes.Group != "root" || notes.GID == nil || *notes.GID != 0 {
+		t.Errorf("Expected root/root, got %s/%s (%v)", notes.Owner, notes.Group, notes.GID)
+	}
+
+	if len(docs.Children) != 1 {
+		t.Fatalf("Expected 1 entry under docs, got %d", len(docs.Children))
+	}
+	report := docs.Children[0]
+	if report.Owner != "alice" || report.Group != "adm" || report.GID == nil || *report.GID != 4 {
+		t.Errorf("Expected alice/adm (4), got %s/%s (%v)", report.Owner, report.Group, report.GID)
+	}
+}
+
+func TestIdentityTableEnrichStatIdPs(t *testing.T) {
+	table := testIdentityTable()
+
+	uid, gid := 1000, 4
+	stats := table.Enrich([]StatEntry{{File: "a", UID: &uid, GID: &gid}}).([]StatEntry)
+	if stats[0].User != "alice" || stats[0].Group != "adm" {
+		t.Errorf("Expected alice/adm, got %s/%s", stats[0].User, stats[0].Group)
+	}
+
+	// BSD stat prints names only
+	stats = table.Enrich([]StatEntry{{File: "b", User: "alice", Group: "adm"}}).([]StatEntry)
+	if stats[0].UID == nil || *stats[0].UID != 1000 || stats[0].GID == nil || *stats[0].GID != 4 {
+		t.Errorf("Expected 1000/4, got %v/%v", stats[0].UID, stats[0].GID)
+	}
+
+	// stat --format output without %u/%g has no IDs to resolve
+	result, err := (&StatParser{Format: "%s %n"}).Parse("12 /tmp/a")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	stats = table.Enrich(result).([]StatEntry)
+	if stats[0].User != "" || stats[0].Group != "" || stats[0].UID != nil || stats[0].GID != nil {
+		t.Errorf("Expected no owner, got %q/%q (%v/%v)", stats[0].User, stats[0].Group, stats[0].UID, stats[0].GID)
+	}
+
+	// A UID of 0 that was printed is not replaced from the name
+	root := 0
+	stats = table.Enrich([]StatEntry{{File: "c", UID: &root, User: "alice"}}).([]StatEntry)
+	if *stats[0].UID != 0 {
+		t.Errorf("Expected printed UID 0 to be kept, got %d", *stats[0].UID)
+	}
+
+	id := table.Enrich(IdEntry{UID: 1000, GID: 1000, Groups: []IdGroup{{GID: 4}}}).(IdEntry)
+	if id.User != "alice" || id.Group != "alice" || id.Groups[0].Name != "adm" {
+		t.Errorf("Unexpected id enrichment: %+v", id)
+	}
+
+	groups := table.Enrich([]IdValues{{Field: IdFieldGroups, IDs: []int{1000, 4, 2000}}}).([]IdValues)
+	if fmt.Sprint(groups[0].Names) != "[alice adm 2000]" {
+		t.Errorf("Expected id -G names [alice adm 2000], got %v", groups[0].Names)
+	}
+	users := table.Enrich([]IdValues{{Field: IdFieldUser, Names: []string{"alice"}}, {Field: IdFieldUser, Names: []string{"nobody"}}}).([]IdValues)
+	if fmt.Sprint(users[0].IDs) != "[1000]" || users[1].IDs != nil {
+		t.Errorf("Expected id -un IDs [1000] and none, got %v and %v", users[0].IDs, users[1].IDs)
+	}
+
+	ps := table.Enrich([]PsEntry{{User: "alice"}, {User: "systemd+"}, {User: "nobody"}}).([]PsEntry)
+	if ps[0].UID == nil || *ps[0].UID != 1000 {
+		t.Errorf("Expected UID 1000, got %v", ps[0].UID)
+	}
+	if ps[1].User != "systemd-network" || ps[1].UID == nil || *ps[1].UID != 100 {
+		t.Errorf("Expected truncated name to resolve, got %s (%v)", ps[1].User, ps[1].UID)
+	}
+	if ps[2].UID != nil {
+		t.Errorf("Expected unknown user to stay unresolved, got %v", *ps[2].UID)
+	}
+
+	// Unsupported results pass through untouched
+	if table.Enrich("text") != "text" {
+		t.Error("Expected unsupported result to be returned unchanged")
+	}
+}
+
+func TestLoadIdentityTable(t *testing.T) {
+	dir := t.TempDir()
+	passwdFile := filepath.Join(dir, "passwd")
+	groupFile := filepath.Join(dir, "group")
+
+	if err := os.WriteFile(passwdFile, []byte("deploy:x:1500:1500:Deploy:/home/deploy:/bin/sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(groupFile, []byte("deploy:x:1500:\nwww-data:x:33:deploy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := LoadIdentityTable(passwdFile, groupFile)
+	if err != nil {
+		t.Fatalf("LoadIdentityTable failed: %v", err)
+	}
+
+	if name, ok := table.UserName(1500); !ok || name != "deploy" {
+		t.Errorf("Expected deploy, got %s", name)
+	}
+	if gid, ok := table.GroupID("www-data"); !ok || gid != 33 {
+		t.Errorf("Expected GID 33, got %d", gid)
+	}
+
+	// Only a group file
+	table, err = LoadIdentityTable("", groupFile)
+	if err != nil {
+		t.Fatalf("LoadIdentityTable failed: %v", err)
+	}
+	if _, ok := table.UserName(1500); ok {
+		t.Error("Expected no users without a passwd file")
+	}
+
+	if _, err := LoadIdentityTable(filepath.Join(dir, "missing"), ""); err == nil {
+		t.Error("Expected error for missing passwd file")
+	}
+}
@@ -0,0 +1,258 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LastParser parses last and lastb login history output. last prints times
+// without a year unless run with -F; they are placed in the most recent year
+// up to ReferenceTime (the current time when zero) whose date falls on the
+// printed weekday. Times are read in Location (UTC when nil).
+type LastParser struct {
+	ReferenceTime time.Time
+	Location      *time.Location
+}
+
+// LastEntry represents a single login session record
+type LastEntry struct {
+	User            string     `json:"user"`
+	TTY             string     `json:"tty"`
+	Host            string     `json:"host,omitempty"`
+	Type            string     `json:"type"`
+	LoginTime       *time.Time `json:"login_time,omitempty"`
+	LogoutTime      *time.Time `json:"logout_time,omitempty"`
+	Status          string     `json:"status"`
+	Duration        string     `json:"duration,omitempty"`
+	DurationSeconds int64      `json:"duration_seconds,omitempty"`
+	Original        string     `json:"original"`
+}
+
+// LastOutput represents the complete last output including the trailer
+type LastOutput struct {
+	Entries []LastEntry `json:"entries"`
+	LogFile string      `json:"log_file,omitempty"`
+	Begins  *time.Time  `json:"begins,omitempty"`
+}
+
+// Session status values reported by last
+const (
+	LastStatusLoggedOut     = "logged_out"
+	LastStatusStillLoggedIn = "still_logged_in"
+	LastStatusStillRunning  = "still_running"
+	LastStatusCrash         = "crash"
+	LastStatusDown          = "down"
+	LastStatusGone          = "gone"
+)
+
+func (p *LastParser) Name() string {
+	return "last"
+}
+
+func (p *LastParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	// Login time: "Mon Jan 15 14:30" or, with last -F, "Mon Jan 15 14:30:25 2024"
+	loginRe := regexp.MustCompile(`[A-Z][a-z]{2}\s+[A-Z][a-z]{2}\s+\d{1,2}\s+\d{2}:\d{2}(?::\d{2}\s+\d{4})?`)
+	trailerRe := regexp.MustCompile(`^(\w+) begins (.+)$`)
+
+	clock := newLsClock(p.ReferenceTime, p.Location)
+
+	output := LastOutput{
+		Entries: []LastEntry{},
+	}
+
+	for _, line := range splitLines(input) {
+		// wtmp begins Sun Jan  1 00:00:00 2024
+		if matches := trailerRe.FindStringSubmatch(line); matches != nil {
+			output.LogFile = matches[1]
+			if begins, err := parseLastTime(matches[2], clock); err == nil {
+				output.Begins = &begins
+			}
+			continue
+		}
+
+		loc := loginRe.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+
+		entry := LastEntry{
+			Original: line,
+			Status:   LastStatusLoggedOut,
+		}
+
+		parseLastIdentity(&entry, strings.Fields(line[:loc[0]]))
+
+		login, err := parseLastTime(line[loc[0]:loc[1]], clock)
+		if err == nil {
+			entry.LoginTime = &login
+		}
+
+		parseLastSession(&entry, strings.TrimSpace(line[loc[1]:]), clock)
+
+		output.Entries = append(output.Entries, entry)
+	}
+
+	return output, nil
+}
+
+// parseLastIdentity fills user, tty, host and record type from the columns
+// before the login time
+func parseLastIdentity(entry *LastEntry, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+
+	entry.User = fields[0]
+	entry.Type = "login"
+	rest := fields[1:]
+
+	switch {
+	case (entry.User == "reboot" || entry.User == "shutdown") && len(rest) >= 2 && rest[0] == "system":
+		// reboot   system boot  5.15.0-91-generic
+		entry.Type = map[string]string{"reboot": "boot", "shutdown": "shutdown"}[entry.User]
+		entry.TTY = rest[0] + " " + rest[1]
+		rest = rest[2:]
+	case entry.User == "runlevel" && len(rest) > 0 && strings.HasPrefix(rest[0], "("):
+		// runlevel (to lvl 5)   5.15.0-91-generic
+		entry.Type = "runlevel"
+		end := 0
+		for end < len(rest) && !strings.HasSuffix(rest[end], ")") {
+			end++
+		}
+		if end == len(rest) {
+			end = len(rest) - 1
+		}
+		entry.TTY = strings.Join(rest[:end+1], " ")
+		rest = rest[end+1:]
+	case len(rest) > 0:
+		entry.TTY = rest[0]
+		rest = rest[1:]
+	}
+
+	entry.Host = strings.Join(rest, " ")
+}
+
+// parseLastSession decodes everything after the login time: the logout
+// time or session state, the duration and, with last -a, the host
+func parseLastSession(entry *LastEntry, rest string, clock lsClock) {
+	durationRe := regexp.MustCompile(`\(([^)]+)\)`)
+	if loc := durationRe.FindStringSubmatchIndex(rest); loc != nil {
+		entry.Duration = rest[loc[2]:loc[3]]
+		if seconds, err := parseLastDuration(entry.Duration); err == nil {
+			entry.DurationSeconds = seconds
+		}
+		// last -a prints the host in the last column
+		if host := strings.TrimSpace(rest[loc[1]:]); host != "" && entry.Host == "" {
+			entry.Host = host
+		}
+		rest = strings.TrimSpace(rest[:loc[0]])
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "still logged in"):
+		entry.Status = LastStatusStillLoggedIn
+	case strings.HasPrefix(rest, "still running"):
+		entry.Status = LastStatusStillRunning
+	case strings.HasPrefix(rest, "gone"):
+		entry.Status = LastStatusGone
+	case strings.HasPrefix(rest, "-"):
+		logout := strings.TrimSpace(strings.TrimPrefix(rest, "-"))
+		switch logout {
+		case "crash":
+			entry.Status = LastStatusCrash
+		case "down":
+			entry.Status = LastStatusDown
+		default:
+			if t, err := parseLastTime(logout, clock); err == nil {
+				entry.LogoutTime = &t
+			} else if t, err := time.Parse("15:04", logout); err == nil && entry.LoginTime != nil {
+				// Only the time of day is shown; the session ends on the login
+				// day or, if the clock wrapped, the day after
+				login := *entry.LoginTime
+				end := time.Date(login.Year(), login.Month(), login.Day(), t.Hour(), t.Minute(), 0, 0, login.Location())
+				if end.Before(login) {
+					end = end.AddDate(0, 0, 1)
+				}
+				entry.LogoutTime = &end
+			}
+		}
+	}
+}
+
+// parseLastTime parses "Mon Jan 15 14:30:25 2024" and "Mon Jan 15 14:30"
+func parseLastTime(value string, clock lsClock) (time.Time, error) {
+	value = strings.Join(strings.Fields(value), " ")
+	formats := []string{
+		"Mon Jan 2 15:04:05 2006",
+		"Mon Jan 2 15:04:05 -0700 2006",
+		"Mon Jan 2 15:04",
+	}
+
+	for _, format := range formats {
+		if t, err := time.ParseInLocation(format, value, clock.location); err == nil {
+			if t.Year() == 0 {
+				t = lastYear(t, value[:3], clock)
+			}
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse time: %s", value)
+}
+
+// lastYear places a time parsed without a year in the most recent year, up to
+// the reference time, whose date falls on weekday. A weekday repeats on the
+// same date within eleven years; without a match the latest year that is
+// not after the reference time is used.
+func lastYear(t time.Time, weekday string, clock lsClock) time.Time {
+	var fallback time.Time
+	for year := clock.now.Year(); year >= clock.now.Year()-11; year-- {
+		candidate := time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, clock.location)
+		if candidate.Month() != t.Month() || candidate.After(clock.now.Add(24*time.Hour)) {
+			continue
+		}
+		if candidate.Format("Mon") == weekday {
+			return candidate
+		}
+		if fallback.IsZero() {
+			fallback = candidate
+		}
+	}
+	return fallback
+}
+
+// parseLastDuration converts "02:14" or "1+01:00" (days+hours:minutes) into seconds
+func parseLastDuration(value string) (int64, error) {
+	var days int64
+	if plus := strings.Index(value, "+"); plus != -1 {
+		d, err := strconv.ParseInt(strings.TrimSpace(value[:plus]), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", value)
+		}
+		days = d
+		value = value[plus+1:]
+	}
+
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid duration: %s", value)
+	}
+	hours, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %s", value)
+	}
+	minutes, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %s", value)
+	}
+
+	return days*86400 + hours*3600 + minutes*60, nil
+}
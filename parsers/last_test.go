@@ -0,0 +1,174 @@
+package parsers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLastParser(t *testing.T) {
+	parser := &LastParser{}
+
+	testInput := `alice    pts/0        192.168.1.10     Mon Jan 15 14:30:25 2024 - Mon Jan 15 16:45:10 2024  (02:14)
+bob      pts/1        10.0.0.5         Mon Jan 15 15:00:00 2024   still logged in
+reboot   system boot  5.15.0-91-generic Mon Jan 15 10:00:00 2024   still running
+root     tty1                          Sun Jan 14 09:00:00 2024 - crash                    (1+01:00)
+carol    pts/2        build01          Sun Jan 14 08:00:00 2024 - down                     (00:30)
+
+wtmp begins Mon Jan  1 00:00:00 2024`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	output, ok := result.(LastOutput)
+	if !ok {
+		t.Fatalf("Expected LastOutput, got %T", result)
+	}
+
+	if len(output.Entries) != 5 {
+		t.Fatalf("Expected 5 entries, got %d", len(output.Entries))
+	}
+
+	// Test regular session
+	alice := output.Entries[0]
+	if alice.User != "alice" || alice.TTY != "pts/0" || alice.Host != "192.168.1.10" {
+		t.Errorf("Unexpected identity: %s %s %s", alice.User, alice.TTY, alice.Host)
+	}
+	if alice.LoginTime == nil || alice.LoginTime.Format("2006-01-02 15:04:05") != "2024-01-15 14:30:25" {
+		t.Errorf("Unexpected login time: %v", alice.LoginTime)
+	}
+	if alice.LogoutTime == nil || alice.LogoutTime.Format("15:04:05") != "16:45:10" {
+		t.Errorf("Unexpected logout time: %v", alice.LogoutTime)
+	}
+	if alice.Status != LastStatusLoggedOut || alice.Duration != "02:14" || alice.DurationSeconds != 8040 {
+		t.Errorf("Unexpected session: %s %s %d", alice.Status, alice.Duration, alice.DurationSeconds)
+	}
+
+	// Test special entries
+	if output.Entries[1].Status != LastStatusStillLoggedIn {
+		t.Errorf("Expected still logged in, got %s", output.Entries[1].Status)
+	}
+	reboot := output.Entries[2]
+	if reboot.Type != "boot" || reboot.TTY != "system boot" || reboot.Status != LastStatusStillRunning {
+		t.Errorf("Unexpected reboot entry: %+v", reboot)
+	}
+	if reboot.Host != "5.15.0-91-generic" {
+		t.Errorf("Expected kernel '5.15.0-91-generic' as host, got '%s'", reboot.Host)
+	}
+	root := output.Entries[3]
+	if root.Host != "" || root.Status != LastStatusCrash || root.DurationSeconds != 90000 {
+		t.Errorf("Unexpected crash entry: %+v", root)
+	}
+	if output.Entries[4].Status != LastStatusDown {
+		t.Errorf("Expected down, got %s", output.Entries[4].Status)
+	}
+
+	// Test trailer metadata
+	if output.LogFile != "wtmp" {
+		t.Errorf("Expected log file 'wtmp', got '%s'", output.LogFile)
+	}
+	if output.Begins == nil || output.Begins.Format("2006-01-02") != "2024-01-01" {
+		t.Errorf("Unexpected begins: %v", output.Begins)
+	}
+
+	// Test JSON marshaling
+	jsonData, err := json.Marshal(output)
+	if err != nil {
+		t.Fatalf("JSON marshal failed: %v", err)
+	}
+
+	if len(jsonData) == 0 {
+		t.Error("JSON output is empty")
+	}
+}
+
+func TestLastParserShortTimes(t *testing.T) {
+	parser := &LastParser{}
+
+	testInput := `alice    pts/0        Mon Jan 15 23:30 - 01:15  (01:45)     192.168.1.10
+baduser  ssh:notty    203.0.113.9      Mon Jan 15 03:12 - 03:12  (00:00)
+
+btmp begins Mon Jan  1 00:00:00 2024`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	output, ok := result.(LastOutput)
+	if !ok {
+		t.Fatalf("Expected LastOutput, got %T", result)
+	}
+
+	if len(output.Entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(output.Entries))
+	}
+
+	// last -a puts the host last; logout wraps past midnight
+	alice := output.Entries[0]
+	if alice.Host != "192.168.1.10" {
+		t.Errorf("Expected host '192.168.1.10', got '%s'", alice.Host)
+	}
+	if alice.LoginTime == nil || alice.LogoutTime == nil {
+		t.Fatalf("Expected login and logout times")
+	}
+	if alice.LogoutTime.Sub(*alice.LoginTime).Minutes() != 105 {
+		t.Errorf("Expected 105 minute session, got %v", alice.LogoutTime.Sub(*alice.LoginTime))
+	}
+
+	if output.Entries[1].TTY != "ssh:notty" || output.Entries[1].Host != "203.0.113.9" {
+		t.Errorf("Unexpected lastb entry: %+v", output.Entries[1])
+	}
+	if output.LogFile != "btmp" {
+		t.Errorf("Expected log file 'btmp', got '%s'", output.LogFile)
+	}
+}
+
+func TestLastParserYearInference(t *testing.T) {
+	// Run in early January: December sessions belong to the previous year
+	parser := &LastParser{ReferenceTime: time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)}
+
+	testInput := `alice    pts/0        192.168.1.10     Tue Jan  2 08:00   still logged in
+bob      pts/1        192.168.1.11     Sun Dec 31 23:30 - Mon Jan  1 00:45  (01:15)
+carol    pts/2        192.168.1.12     Fri Dec 15 10:00 - 11:00  (01:00)`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	entries := result.(LastOutput).Entries
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	expected := []string{"2024-01-02 08:00", "2023-12-31 23:30", "2023-12-15 10:00"}
+	for i, entry := range entries {
+		if entry.LoginTime == nil || entry.LoginTime.Format("2006-01-02 15:04") != expected[i] {
+			t.Errorf("Entry %d: expected login %s, got %v", i, expected[i], entry.LoginTime)
+		}
+	}
+
+	bob := entries[1]
+	if bob.LogoutTime == nil || bob.LogoutTime.Format("2006-01-02 15:04") != "2024-01-01 00:45" {
+		t.Errorf("Expected logout 2024-01-01 00:45, got %v", bob.LogoutTime)
+	}
+	if bob.LogoutTime != nil && bob.LogoutTime.Before(*bob.LoginTime) {
+		t.Errorf("Logout %v is before login %v", bob.LogoutTime, bob.LoginTime)
+	}
+}
+
+func TestLastParserEmpty(t *testing.T) {
+	parser := &LastParser{}
+
+	_, err := parser.Parse("")
+	if err == nil {
+		t.Error("Expected error for empty input")
+	}
+
+	_, err = parser.Parse("   ")
+	if err == nil {
+		t.Error("Expected error for whitespace-only input")
+	}
+}
@@ -0,0 +1,78 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LastlogParser parses lastlog command output
+type LastlogParser struct{}
+
+// LastlogEntry represents the most recent login of a single user
+type LastlogEntry struct {
+	User          string     `json:"user"`
+	Port          string     `json:"port,omitempty"`
+	From          string     `json:"from,omitempty"`
+	Latest        *time.Time `json:"latest,omitempty"`
+	NeverLoggedIn bool       `json:"never_logged_in"`
+	Original      string     `json:"original"`
+}
+
+func (p *LastlogParser) Name() string {
+	return "lastlog"
+}
+
+func (p *LastlogParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	// Mon Jan 15 14:30:25 +0000 2024
+	latestRe := regexp.MustCompile(`[A-Z][a-z]{2}\s+[A-Z][a-z]{2}\s+\d{1,2}\s+\d{2}:\d{2}:\d{2}\s+[+-]\d{4}\s+\d{4}$`)
+
+	entries := []LastlogEntry{}
+
+	for _, line := range splitLines(input) {
+		// Skip header line
+		if strings.HasPrefix(line, "Username") {
+			continue
+		}
+
+		entry := LastlogEntry{
+			Original: line,
+		}
+
+		rest := line
+		if strings.Contains(line, "**Never logged in**") {
+			entry.NeverLoggedIn = true
+			rest = strings.Replace(line, "**Never logged in**", "", 1)
+		} else if loc := latestRe.FindStringIndex(line); loc != nil {
+			if latest, err := parseLastTime(line[loc[0]:], newLsClock(time.Time{}, nil)); err == nil {
+				entry.Latest = &latest
+			}
+			rest = line[:loc[0]]
+		} else {
+			continue
+		}
+
+		// Username Port From; Port and From are empty for local or unused accounts
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		entry.User = fields[0]
+		if len(fields) > 1 {
+			entry.Port = fields[1]
+		}
+		if len(fields) > 2 {
+			entry.From = strings.Join(fields[2:], " ")
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
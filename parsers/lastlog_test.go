@@ -0,0 +1,55 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestLastlogParser(t *testing.T) {
+	parser := &LastlogParser{}
+
+	testInput := `Username         Port     From             Latest
+root             pts/0    192.168.1.10     Mon Jan 15 14:30:25 +0000 2024
+daemon                                     **Never logged in**
+alice            tty1                      Sun Jan 14 09:00:00 +0100 2024`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]LastlogEntry)
+	if !ok {
+		t.Fatalf("Expected []LastlogEntry, got %T", result)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	if entries[0].User != "root" || entries[0].Port != "pts/0" || entries[0].From != "192.168.1.10" {
+		t.Errorf("Unexpected root entry: %+v", entries[0])
+	}
+	if entries[0].Latest == nil || entries[0].Latest.Unix() != 1705329025 {
+		t.Errorf("Unexpected latest: %v", entries[0].Latest)
+	}
+
+	if !entries[1].NeverLoggedIn || entries[1].User != "daemon" || entries[1].Latest != nil {
+		t.Errorf("Unexpected never logged in entry: %+v", entries[1])
+	}
+
+	if entries[2].Port != "tty1" || entries[2].From != "" {
+		t.Errorf("Unexpected local entry: %+v", entries[2])
+	}
+	if _, offset := entries[2].Latest.Zone(); offset != 3600 {
+		t.Errorf("Expected +0100 offset, got %d", offset)
+	}
+}
+
+func TestLastlogParserEmpty(t *testing.T) {
+	parser := &LastlogParser{}
+
+	_, err := parser.Parse("")
+	if err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
@@ -2,13 +2,22 @@ package parsers
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// LsParser parses ls command output
-type LsParser struct{}
+// LsParser parses ls command output. With Tree set, recursive listings
+// (ls -lR) are returned as a nested FileTreeNode tree. Timestamps are read in
+// Location (UTC when nil), and dates printed without a year are placed
+// relative to ReferenceTime (the current time when zero).
+type LsParser struct {
+	Tree          bool
+	ReferenceTime time.Time
+	Location      *time.Location
+}
 
 // LsEntry represents a single ls output entry
 type LsEntry struct {
@@ -22,6 +31,71 @@ type LsEntry struct {
 	IsDirectory bool      `json:"is_directory"`
 	IsSymlink   bool      `json:"is_symlink"`
 	LinkTarget  string    `json:"link_target,omitempty"`
+	UID         *int      `json:"uid,omitempty"`
+	GID         *int      `json:"gid,omitempty"`
+	FileMode
+	Inode          *int64 `json:"inode,omitempty"`
+	Blocks         string `json:"blocks,omitempty"`
+	AllocatedBytes *int64 `json:"allocated_bytes,omitempty"`
+	DeviceMajor    *int   `json:"device_major,omitempty"`
+	DeviceMinor    *int   `json:"device_minor,omitempty"`
+	// Directory is the section header ("./dir:") the entry was listed under
+	Directory string `json:"directory,omitempty"`
+	// DirectoryTotalBytes is the "total N" line of that listing in bytes
+	DirectoryTotalBytes *int64   `json:"directory_total_bytes,omitempty"`
+	Issues              []string `json:"issues,omitempty"`
+}
+
+// FileMode is the file type and permission bits decoded from an ls style
+// mode string such as "drwxr-sr-t+"
+type FileMode struct {
+	FileType              string `json:"file_type,omitempty"`
+	Mode                  string `json:"mode,omitempty"`
+	Setuid                bool   `json:"setuid,omitempty"`
+	Setgid                bool   `json:"setgid,omitempty"`
+	Sticky                bool   `json:"sticky,omitempty"`
+	HasACL                bool   `json:"has_acl,omitempty"`
+	HasSecurityContext    bool   `json:"has_security_context,omitempty"`
+	HasExtendedAttributes bool   `json:"has_extended_attributes,omitempty"`
+}
+
+// File types decoded from the first character of a mode string
+const (
+	FileTypeRegular     = "file"
+	FileTypeDirectory   = "directory"
+	FileTypeSymlink     = "symlink"
+	FileTypeCharDevice  = "char_device"
+	FileTypeBlockDevice = "block_device"
+	FileTypeFIFO        = "fifo"
+	FileTypeSocket      = "socket"
+	FileTypeDoor        = "door"
+	FileTypeUnknown     = "unknown"
+)
+
+// FileTreeNode is a file or directory in a tree rebuilt from recursive ls
+// or find listings. Directories carry counts and sizes of everything below.
+type FileTreeNode struct {
+	Name           string         `json:"name"`
+	Path           string         `json:"path"`
+	Type           string         `json:"type"`
+	Permissions    string         `json:"permissions,omitempty"`
+	Owner          string         `json:"owner,omitempty"`
+	Group          string         `json:"group,omitempty"`
+	UID            *int           `json:"uid,omitempty"`
+	GID            *int           `json:"gid,omitempty"`
+	Size           int64          `json:"size"`
+	Modified       *time.Time     `json:"modified,omitempty"`
+	LinkTarget     string         `json:"link_target,omitempty"`
+	FileCount      int            `json:"file_count,omitempty"`
+	DirectoryCount int            `json:"directory_count,omitempty"`
+	TotalSize      int64          `json:"total_size,omitempty"`
+	Children       []FileTreeNode `json:"children,omitempty"`
+}
+
+// lsField is a whitespace separated field and its byte offset in the line
+type lsField struct {
+	text  string
+	start int
 }
 
 func (p *LsParser) Name() string {
@@ -33,92 +107,684 @@ func (p *LsParser) Parse(input string) (interface{}, error) {
 	if input == "" {
 		return nil, fmt.Errorf("empty input")
 	}
-	
-	lines := splitLines(input)
+
+	sections := parseLsSections(input, newLsClock(p.ReferenceTime, p.Location))
+	if p.Tree {
+		return buildLsTree(sections), nil
+	}
+
 	var entries []LsEntry
+	for _, section := range sections {
+		for _, entry := range section.Entries {
+			entry.Directory = section.Path
+			entry.DirectoryTotalBytes = section.TotalBytes
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// buildLsTree resolves each entry's path from its section header and nests
+// the entries. Sections without a header are taken to list ".".
+func buildLsTree(sections []lsSection) []FileTreeNode {
+	var items []FileTreeNode
+	for _, section := range sections {
+		dir := lsTreePath(section.Path)
+		items = append(items, FileTreeNode{
+			Path: dir,
+			Type: FileTypeDirectory,
+		})
+		for _, entry := range section.Entries {
+			node := FileTreeNode{
+				Path:        lsTreePath(dir + "/" + entry.Name),
+				Type:        entry.FileType,
+				Permissions: entry.Permissions,
+				Owner:       entry.Owner,
+				Group:       entry.Group,
+				UID:         entry.UID,
+				GID:         entry.GID,
+				Size:        entry.Size,
+				LinkTarget:  entry.LinkTarget,
+			}
+			if !entry.Modified.IsZero() {
+				modified := entry.Modified
+				node.Modified = &modified
+			}
+			items = append(items, node)
+		}
+	}
 
-	for _, line := range lines {
-		fields := splitFields(line)
-		
-		// Skip lines that don't look like ls -l output
-		if len(fields) < 9 {
+	return buildFileTree(items)
+}
+
+// lsTreePath cleans a path from an ls -R listing. Paths below "." keep the
+// "./" that ls -R prints for them (".:" then "./sub:"), so every path in
+// the tree starts with its parent's path.
+func lsTreePath(p string) string {
+	if p == "" || p == "." {
+		return "."
+	}
+	cleaned := path.Clean(p)
+	if (p == "./" || strings.HasPrefix(p, "./")) && cleaned != "." && !strings.HasPrefix(cleaned, "../") {
+		return "./" + cleaned
+	}
+	return cleaned
+}
+
+// buildFileTree nests nodes under the node whose path is their parent
+// directory and fills in the directory totals. A path listed more than once
+// (a directory entry and its own section) is merged into one node, keeping
+// the attributes that were set. Nodes without a listed parent are roots.
+func buildFileTree(items []FileTreeNode) []FileTreeNode {
+	index := map[string]int{}
+	var nodes []FileTreeNode
+	for _, item := range items {
+		key := path.Clean(item.Path)
+		if i, ok := index[key]; ok {
+			existing := &nodes[i]
+			if item.Permissions != "" {
+				existing.Permissions, existing.Owner, existing.Group = item.Permissions, item.Owner, item.Group
+				existing.Size, existing.Modified, existing.LinkTarget = item.Size, item.Modified, item.LinkTarget
+				existing.Type = item.Type
+			}
+			continue
+		}
+		index[key] = len(nodes)
+		nodes = append(nodes, item)
+	}
+
+	children := map[int][]int{}
+	var roots []int
+	for i := range nodes {
+		key := path.Clean(nodes[i].Path)
+		nodes[i].Name = path.Base(key)
+		parent, ok := index[path.Dir(key)]
+		if key == "/" || key == "." || !ok || parent == i {
+			roots = append(roots, i)
 			continue
 		}
+		children[parent] = append(children[parent], i)
+	}
 
-		entry := LsEntry{}
+	var build func(i int) FileTreeNode
+	build = func(i int) FileTreeNode {
+		node := nodes[i]
+		for _, child := range children[i] {
+			childNode := build(child)
+			if childNode.Type == FileTypeDirectory {
+				node.DirectoryCount += 1 + childNode.DirectoryCount
+			} else {
+				node.FileCount++
+			}
+			node.FileCount += childNode.FileCount
+			node.TotalSize += childNode.TotalSize
+			if childNode.Type != FileTypeDirectory {
+				node.TotalSize += childNode.Size
+			}
+			node.Children = append(node.Children, childNode)
+		}
+		return node
+	}
+
+	tree := []FileTreeNode{}
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree
+}
+
+// lsSection is one directory listing: the entries following a "dir:" header
+// (or the start of the output) and its "total N" line
+type lsSection struct {
+	Path       string
+	TotalBytes *int64
+	Entries    []LsEntry
+	// prefixes holds the single unlabeled number in front of each entry,
+	// which is either an inode (ls -i) or a block count (ls -s)
+	prefixes []string
+	total    string
+}
 
-		// Parse permissions
-		entry.Permissions = fields[0]
-		entry.IsDirectory = strings.HasPrefix(entry.Permissions, "d")
-		entry.IsSymlink = strings.HasPrefix(entry.Permissions, "l")
+// parseLsSections splits ls output into directory sections and parses each
+// long format line
+func parseLsSections(input string, clock lsClock) []lsSection {
+	totalRe := regexp.MustCompile(`^total (\S+)$`)
 
-		// Parse links
-		if links, err := strconv.Atoi(fields[1]); err == nil {
-			entry.Links = links
+	sections := []lsSection{{}}
+	current := &sections[0]
+
+	for _, line := range splitLines(input) {
+		if matches := totalRe.FindStringSubmatch(line); matches != nil {
+			current.total = matches[1]
+			if bytes, err := parseSizeToBytes(matches[1]); err == nil {
+				if _, err := strconv.ParseInt(matches[1], 10, 64); err == nil {
+					bytes *= 1024
+				}
+				current.TotalBytes = &bytes
+			}
+			continue
+		}
+
+		entry, prefix, ok := parseLsLine(line, clock)
+		if ok {
+			current.Entries = append(current.Entries, entry)
+			current.prefixes = append(current.prefixes, prefix)
+			continue
+		}
+
+		// ls -lR and multi-directory listings: "./dir:" starts a new section
+		if strings.HasSuffix(line, ":") {
+			resolveLsPrefixes(current)
+			sections = append(sections, lsSection{Path: unquoteLsName(strings.TrimSuffix(line, ":"))})
+			current = &sections[len(sections)-1]
+		}
+	}
+	resolveLsPrefixes(current)
+
+	// Drop the leading section when the output starts with a header
+	if len(sections) > 1 && len(sections[0].Entries) == 0 && sections[0].TotalBytes == nil {
+		sections = sections[1:]
+	}
+
+	return sections
+}
+
+// parseLsLine parses a single ls -l line, optionally preceded by the inode
+// (-i) and block count (-s) columns. An ambiguous single prefix column is
+// returned for resolveLsPrefixes to assign.
+func parseLsLine(line string, clock lsClock) (LsEntry, string, bool) {
+	permRe := regexp.MustCompile(`^[-dlcbpsDn?][-rwxsStTlL?]{9}[.+@]?$`)
+
+	fields := lsFields(line)
+	permIndex := -1
+	for i := 0; i < len(fields) && i < 3; i++ {
+		if permRe.MatchString(fields[i].text) {
+			permIndex = i
+			break
 		}
+	}
+	// permissions links owner group size date... name
+	if permIndex == -1 || len(fields) < permIndex+7 {
+		return LsEntry{}, "", false
+	}
+
+	entry := LsEntry{}
+	prefix := ""
+	switch permIndex {
+	case 1:
+		prefix = fields[0].text
+	case 2:
+		if inode, err := strconv.ParseInt(fields[0].text, 10, 64); err == nil {
+			entry.Inode = &inode
+		}
+		setLsBlocks(&entry, fields[1].text)
+	}
 
-		// Parse owner and group
-		entry.Owner = fields[2]
-		entry.Group = fields[3]
+	fields = fields[permIndex:]
 
-		// Parse size
-		if size, err := strconv.ParseInt(fields[4], 10, 64); err == nil {
+	// Parse permissions
+	entry.Permissions = fields[0].text
+	entry.FileMode = decodeFileMode(entry.Permissions)
+	entry.IsDirectory = entry.FileType == FileTypeDirectory
+	entry.IsSymlink = entry.FileType == FileTypeSymlink
+
+	// Parse links
+	if links, err := strconv.Atoi(fields[1].text); err == nil {
+		entry.Links = links
+	}
+
+	// Parse owner and group
+	entry.Owner = fields[2].text
+	entry.Group = fields[3].text
+
+	// Parse size, or major and minor numbers for device files ("4, 64")
+	next := 5
+	sizeField := fields[4].text
+	switch {
+	case strings.HasSuffix(sizeField, ","):
+		setLsDevice(&entry, strings.TrimSuffix(sizeField, ","), fields[5].text)
+		next = 6
+	case strings.Contains(sizeField, ","):
+		major, minor, _ := strings.Cut(sizeField, ",")
+		setLsDevice(&entry, major, minor)
+	default:
+		if size, err := strconv.ParseInt(sizeField, 10, 64); err == nil {
+			entry.Size = size
+		} else if size, err := parseSizeToBytes(sizeField); err == nil {
 			entry.Size = size
 		}
+	}
+	if next >= len(fields) {
+		return LsEntry{}, "", false
+	}
+
+	// Parse date/time in the default, long-iso, full-iso or iso style
+	var dateFields []string
+	for _, field := range fields[next:] {
+		dateFields = append(dateFields, field.text)
+	}
+	modified, consumed, err := parseLsTimestamp(dateFields, clock)
+	if consumed == 0 || next+consumed >= len(fields) {
+		return LsEntry{}, "", false
+	}
+	if err != nil {
+		entry.Issues = append(entry.Issues, err.Error())
+	}
+	entry.Modified = modified
+
+	// Parse filename and link target, keeping spaces in names intact
+	name := line[fields[next+consumed].start:]
+	if entry.IsSymlink {
+		if arrow := strings.Index(name, " -> "); arrow != -1 {
+			entry.LinkTarget = unquoteLsName(name[arrow+4:])
+			name = name[:arrow]
+		}
+	}
+	entry.Name = unquoteLsName(name)
+
+	return entry, prefix, true
+}
+
+// resolveLsPrefixes decides whether the single number in front of the
+// entries of a section is an inode or a block count. Block counts add up to
+// the "total" line; without one, a count that matches the file size is
+// taken as blocks.
+func resolveLsPrefixes(section *lsSection) {
+	var sum int64
+	numeric := true
+	for _, prefix := range section.prefixes {
+		if prefix == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			numeric = false
+			break
+		}
+		sum += n
+	}
+	sumMatches := numeric && section.total != "" && strconv.FormatInt(sum, 10) == section.total
+
+	for i, prefix := range section.prefixes {
+		if prefix == "" {
+			continue
+		}
+		entry := &section.Entries[i]
+		n, err := strconv.ParseInt(prefix, 10, 64)
+		isBlocks := err != nil || sumMatches
+		if section.total == "" && err == nil {
+			isBlocks = n == 0 || (n*1024 >= entry.Size && n*1024 <= entry.Size+65536)
+		}
+		if isBlocks {
+			setLsBlocks(entry, prefix)
+		} else {
+			entry.Inode = &n
+		}
+	}
+}
+
+func setLsBlocks(entry *LsEntry, blocks string) {
+	entry.Blocks = blocks
+	if n, err := strconv.ParseInt(blocks, 10, 64); err == nil {
+		// GNU ls counts in 1024 byte blocks by default
+		bytes := n * 1024
+		entry.AllocatedBytes = &bytes
+	} else if bytes, err := parseSizeToBytes(blocks); err == nil {
+		entry.AllocatedBytes = &bytes
+	}
+}
+
+func setLsDevice(entry *LsEntry, major, minor string) {
+	if n, err := strconv.Atoi(strings.TrimSpace(major)); err == nil {
+		entry.DeviceMajor = &n
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(minor)); err == nil {
+		entry.DeviceMinor = &n
+	}
+}
+
+// lsFields splits a line on whitespace, recording where each field starts
+func lsFields(line string) []lsField {
+	var fields []lsField
+	start := -1
+	for i, r := range line {
+		if r == ' ' || r == '\t' {
+			if start != -1 {
+				fields = append(fields, lsField{text: line[start:i], start: start})
+				start = -1
+			}
+		} else if start == -1 {
+			start = i
+		}
+	}
+	if start != -1 {
+		fields = append(fields, lsField{text: line[start:], start: start})
+	}
+	return fields
+}
+
+// parseLsTimestamp parses the timestamp at the start of fields and returns
+// how many fields it used:
+//
+//	Jan 15 10:30 / Jan 15  2023             (default)
+//	2024-01-15 10:30                        (long-iso)
+//	2024-01-15 10:30:25.123456789 +0100     (full-iso, --full-time)
+//	01-15 10:30 / 2023-01-15                (iso)
+//
+// Fields that look like a timestamp but do not parse are reported as an error
+// along with the number of fields they span.
+func parseLsTimestamp(fields []string, clock lsClock) (time.Time, int, error) {
+	dateRe := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	shortDateRe := regexp.MustCompile(`^\d{2}-\d{2}$`)
+	clockRe := regexp.MustCompile(`^\d{2}:\d{2}(:\d{2}(\.\d+)?)?$`)
+	zoneRe := regexp.MustCompile(`^[+-]\d{4}$`)
+	monthRe := regexp.MustCompile(`^[A-Z][a-z]{2}$`)
+
+	if len(fields) == 0 {
+		return time.Time{}, 0, nil
+	}
+
+	parse := func(layout string, count int) (time.Time, int, error) {
+		value := strings.Join(fields[:count], " ")
+		t, err := time.ParseInLocation(layout, value, clock.location)
+		if err != nil {
+			return time.Time{}, count, fmt.Errorf("unable to parse date: %s", value)
+		}
+		return t, count, nil
+	}
 
-		// Parse date/time (assumes format: Mon DD HH:MM or Mon DD YYYY)
-		dateStr := strings.Join(fields[5:8], " ")
-		if parsedTime, err := parseDate(dateStr); err == nil {
-			entry.Modified = parsedTime
+	switch {
+	case dateRe.MatchString(fields[0]):
+		if len(fields) > 2 && clockRe.MatchString(fields[1]) && zoneRe.MatchString(fields[2]) {
+			return parse("2006-01-02 15:04:05.999999999 -0700", 3)
 		}
+		if len(fields) > 1 && clockRe.MatchString(fields[1]) {
+			if strings.Count(fields[1], ":") == 1 {
+				return parse("2006-01-02 15:04", 2)
+			}
+			return parse("2006-01-02 15:04:05.999999999", 2)
+		}
+		return parse("2006-01-02", 1)
+	case shortDateRe.MatchString(fields[0]) && len(fields) > 1 && clockRe.MatchString(fields[1]):
+		t, count, err := parse("01-02 15:04", 2)
+		if err != nil {
+			return t, count, err
+		}
+		return clock.inferYear(t), count, nil
+	case monthRe.MatchString(fields[0]) && len(fields) > 2:
+		t, err := parseDate(strings.Join(fields[:3], " "), clock)
+		return t, 3, err
+	}
+
+	return time.Time{}, 0, nil
+}
 
-		// Parse filename and link target
-		nameFields := fields[8:]
-		if entry.IsSymlink && len(nameFields) >= 3 {
-			// Handle symlink: name -> target
-			arrowIndex := -1
-			for i, field := range nameFields {
-				if field == "->" {
-					arrowIndex = i
-					break
+// decodeFileMode decodes an ls style mode string into the file type, the
+// octal mode and the special bits and markers
+func decodeFileMode(perms string) FileMode {
+	mode := FileMode{
+		FileType: FileTypeUnknown,
+	}
+	if len(perms) < 10 {
+		return mode
+	}
+
+	switch perms[0] {
+	case '-':
+		mode.FileType = FileTypeRegular
+	case 'd':
+		mode.FileType = FileTypeDirectory
+	case 'l':
+		mode.FileType = FileTypeSymlink
+	case 'c':
+		mode.FileType = FileTypeCharDevice
+	case 'b':
+		mode.FileType = FileTypeBlockDevice
+	case 'p':
+		mode.FileType = FileTypeFIFO
+	case 's':
+		mode.FileType = FileTypeSocket
+	case 'D':
+		mode.FileType = FileTypeDoor
+	}
+
+	// Each triplet is rwx; the execute slot also carries setuid (s/S),
+	// setgid (s/S) and sticky (t/T), lowercase when execute is also set
+	bits := 0
+	for i, c := range perms[1:10] {
+		shift := uint(8 - i)
+		switch c {
+		case 'r', 'w', 'x':
+			bits |= 1 << shift
+		case 's', 't':
+			bits |= 1 << shift
+			fallthrough
+		case 'S', 'T', 'l', 'L':
+			switch i {
+			case 2:
+				mode.Setuid = true
+			case 5:
+				mode.Setgid = true
+			case 8:
+				mode.Sticky = true
+			}
+		}
+	}
+	if mode.Setuid {
+		bits |= 04000
+	}
+	if mode.Setgid {
+		bits |= 02000
+	}
+	if mode.Sticky {
+		bits |= 01000
+	}
+	mode.Mode = fmt.Sprintf("%04o", bits)
+
+	if len(perms) > 10 {
+		switch perms[10] {
+		case '+':
+			mode.HasACL = true
+		case '.':
+			mode.HasSecurityContext = true
+		case '@':
+			mode.HasExtendedAttributes = true
+		}
+	}
+
+	return mode
+}
+
+// applyOctalMode sets the octal mode and special bits from an octal mode
+// such as "4755"; the file type is left as is
+func applyOctalMode(mode *FileMode, value string) {
+	bits, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		return
+	}
+	mode.Mode = fmt.Sprintf("%04o", bits&07777)
+	mode.Setuid = bits&04000 != 0
+	mode.Setgid = bits&02000 != 0
+	mode.Sticky = bits&01000 != 0
+}
+
+// unquoteLsName removes the quoting GNU ls adds to names with special
+// characters: 'single quoted', "double quoted", $'\n' ANSI-C escapes and
+// backslash escapes (ls -b), including names built from several quoted parts.
+// Names that are not quoted as a whole are printed literally and kept as is.
+func unquoteLsName(name string) string {
+	quoted := (strings.HasPrefix(name, "'") || strings.HasPrefix(name, `"`) || strings.HasPrefix(name, "$'")) &&
+		(strings.HasSuffix(name, "'") || strings.HasSuffix(name, `"`)) && len(name) > 1
+	if !quoted {
+		if strings.Contains(name, `\ `) {
+			return decodeLsEscapes(name)
+		}
+		return name
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(name); {
+		switch {
+		case strings.HasPrefix(name[i:], "$'"):
+			end := i + 2
+			for end < len(name) && name[end] != '\'' {
+				if name[end] == '\\' {
+					end++
 				}
+				end++
 			}
-			if arrowIndex > 0 {
-				entry.Name = strings.Join(nameFields[:arrowIndex], " ")
-				entry.LinkTarget = strings.Join(nameFields[arrowIndex+1:], " ")
-			} else {
-				entry.Name = strings.Join(nameFields, " ")
+			out.WriteString(decodeLsEscapes(name[i+2 : min(end, len(name))]))
+			i = end + 1
+		case name[i] == '\'':
+			end := strings.IndexByte(name[i+1:], '\'')
+			if end == -1 {
+				out.WriteString(name[i+1:])
+				return out.String()
 			}
-		} else {
-			entry.Name = strings.Join(nameFields, " ")
+			out.WriteString(name[i+1 : i+1+end])
+			i += end + 2
+		case name[i] == '"':
+			end := i + 1
+			for end < len(name) && name[end] != '"' {
+				if name[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			out.WriteString(decodeLsEscapes(name[i+1 : min(end, len(name))]))
+			i = end + 1
+		case name[i] == '\\':
+			end := i + 2
+			for end < len(name) && end < i+4 && name[end] >= '0' && name[end] <= '7' {
+				end++
+			}
+			end = min(end, len(name))
+			out.WriteString(decodeLsEscapes(name[i:end]))
+			i = end
+		default:
+			out.WriteByte(name[i])
+			i++
 		}
+	}
+
+	return out.String()
+}
 
-		entries = append(entries, entry)
+// decodeLsEscapes decodes C style backslash escapes (\n, \t, \\, \NNN octal,
+// \xHH hex)
+func decodeLsEscapes(value string) string {
+	var out strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			out.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch c := value[i]; c {
+		case 'n':
+			out.WriteByte('\n')
+		case 't':
+			out.WriteByte('\t')
+		case 'r':
+			out.WriteByte('\r')
+		case 'a':
+			out.WriteByte('\a')
+		case 'b':
+			out.WriteByte('\b')
+		case 'f':
+			out.WriteByte('\f')
+		case 'v':
+			out.WriteByte('\v')
+		case 'e':
+			out.WriteByte(0x1b)
+		case 'x':
+			end := i + 1
+			for end < len(value) && end < i+3 && strings.ContainsRune("0123456789abcdefABCDEF", rune(value[end])) {
+				end++
+			}
+			if end == i+1 {
+				out.WriteByte(c)
+				continue
+			}
+			n, _ := strconv.ParseUint(value[i+1:end], 16, 8)
+			out.WriteByte(byte(n))
+			i = end - 1
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			end := i
+			for end < len(value) && end < i+3 && value[end] >= '0' && value[end] <= '7' {
+				end++
+			}
+			n, _ := strconv.ParseUint(value[i:end], 8, 8)
+			out.WriteByte(byte(n))
+			i = end - 1
+		default:
+			out.WriteByte(c)
+		}
 	}
+	return out.String()
+}
 
-	return entries, nil
+// lsClock holds the reference time and location used to read ls and find
+// timestamps. Timestamps without a year are placed in the latest year that
+// is not after the reference time (see inferYear).
+type lsClock struct {
+	now      time.Time
+	location *time.Location
+}
+
+// newLsClock returns a clock for the given reference time and location,
+// defaulting to the current time and UTC
+func newLsClock(reference time.Time, location *time.Location) lsClock {
+	if location == nil {
+		location = time.UTC
+	}
+	if reference.IsZero() {
+		reference = time.Now()
+	}
+	return lsClock{
+		now:      reference.In(location),
+		location: location,
+	}
+}
+
+// inferYear moves a timestamp parsed without a year (year 0) into the most
+// recent year that does not put it in the future. GNU ls only leaves out
+// the year for timestamps within the past six months, so an undated
+// timestamp is never months ahead; a day of slack covers clock skew and
+// zone differences. Feb 29 only lands on leap years.
+func (c lsClock) inferYear(t time.Time) time.Time {
+	if t.Year() != 0 {
+		return t
+	}
+
+	limit := c.now.Add(24 * time.Hour)
+	for year := c.now.Year() + 1; year >= c.now.Year()-8; year-- {
+		candidate := time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), c.location)
+		if candidate.Month() != t.Month() || candidate.Day() != t.Day() || candidate.After(limit) {
+			continue
+		}
+		return candidate
+	}
+
+	return time.Time{}
 }
 
 // parseDate attempts to parse various date formats from ls output
-func parseDate(dateStr string) (time.Time, error) {
+func parseDate(dateStr string, clock lsClock) (time.Time, error) {
+	dateStr = strings.Join(strings.Fields(dateStr), " ")
+
 	// Common ls date formats
 	formats := []string{
 		"Jan 2 15:04",
 		"Jan 2 2006",
-		"Jan  2 15:04",
-		"Jan  2 2006",
 	}
 
 	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			// If year is not specified, assume current year
-			if t.Year() == 0 {
-				now := time.Now()
-				t = t.AddDate(now.Year(), 0, 0)
-			}
-			return t, nil
+		if t, err := time.ParseInLocation(format, dateStr, clock.location); err == nil {
+			return clock.inferYear(t), nil
 		}
 	}
 
-	return time.Time{}, nil
+	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
 }
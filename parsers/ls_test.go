@@ -2,7 +2,9 @@ package parsers
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLsParser(t *testing.T) {
@@ -93,3 +95,360 @@ func TestLsParserEmpty(t *testing.T) {
 		t.Errorf("Expected 0 entries, got %d", len(entries))
 	}
 }
+
+func TestLsParserPrefixesAndDevices(t *testing.T) {
+	parser := &LsParser{}
+
+	testInput := `total 12
+1835009 4 drwxr-xr-x  2 root root 4096 Jan 15 10:30 bin
+1835010 8 -rwsr-xr-x+ 1 root root 5120 Jan 14 09:15 passwd
+     14 0 crw--w----. 1 root tty  4, 0 Jan 13 14:20 tty0
+   1234 0 brw-rw----  1 root disk 8,16 Jan 13 14:20 sdb
+   5678 0 drwxrwxrwt  9 root root  180 Jan 13 14:20 tmp`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]LsEntry)
+	if len(entries) != 5 {
+		t.Fatalf("Expected 5 entries, got %d", len(entries))
+	}
+
+	bin := entries[0]
+	if bin.Inode == nil || *bin.Inode != 1835009 || bin.Blocks != "4" || *bin.AllocatedBytes != 4096 {
+		t.Errorf("Unexpected inode/blocks %+v", bin)
+	}
+	if bin.DirectoryTotalBytes == nil || *bin.DirectoryTotalBytes != 12*1024 {
+		t.Errorf("Expected directory total 12K, got %v", bin.DirectoryTotalBytes)
+	}
+	if bin.FileType != FileTypeDirectory || bin.Mode != "0755" {
+		t.Errorf("Unexpected mode %q %q", bin.FileType, bin.Mode)
+	}
+
+	passwd := entries[1]
+	if !passwd.Setuid || !passwd.HasACL || passwd.Mode != "4755" {
+		t.Errorf("Expected setuid with ACL, got %+v", passwd.FileMode)
+	}
+
+	tty := entries[2]
+	if tty.FileType != FileTypeCharDevice || !tty.HasSecurityContext || tty.Mode != "0620" {
+		t.Errorf("Unexpected tty mode %+v", tty.FileMode)
+	}
+	if tty.DeviceMajor == nil || *tty.DeviceMajor != 4 || *tty.DeviceMinor != 0 || tty.Name != "tty0" {
+		t.Errorf("Unexpected device numbers %+v", tty)
+	}
+
+	sdb := entries[3]
+	if sdb.FileType != FileTypeBlockDevice || *sdb.DeviceMajor != 8 || *sdb.DeviceMinor != 16 || sdb.Name != "sdb" {
+		t.Errorf("Unexpected block device %+v", sdb)
+	}
+
+	if !entries[4].Sticky || entries[4].Mode != "1777" {
+		t.Errorf("Expected sticky bit, got %+v", entries[4].FileMode)
+	}
+}
+
+func TestLsParserSinglePrefix(t *testing.T) {
+	parser := &LsParser{}
+
+	// ls -li: the inodes do not add up to the total
+	result, err := parser.Parse(`total 8
+1835009 -rw-r--r-- 1 user group 1234 Jan 15 10:30 a.txt
+1835010 -rw-r--r-- 1 user group 5678 Jan 15 10:30 b.txt`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	entries := result.([]LsEntry)
+	if entries[0].Inode == nil || *entries[0].Inode != 1835009 || entries[0].Blocks != "" {
+		t.Errorf("Expected inode prefix, got %+v", entries[0])
+	}
+
+	// ls -ls: the block counts add up to the total
+	result, err = parser.Parse(`total 12
+4 -rw-r--r-- 1 user group 1234 Jan 15 10:30 a.txt
+8 -rw-r--r-- 1 user group 5678 Jan 15 10:30 b.txt`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	entries = result.([]LsEntry)
+	if entries[1].Inode != nil || entries[1].Blocks != "8" {
+		t.Errorf("Expected block prefix, got %+v", entries[1])
+	}
+
+	// ls -lsh: human readable block counts
+	result, err = parser.Parse(`total 12K
+4.0K -rw-r--r-- 1 user group 1.2K Jan 15 10:30 a.txt`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	entries = result.([]LsEntry)
+	if entries[0].Blocks != "4.0K" || *entries[0].AllocatedBytes != 4096 || entries[0].Size != 1228 {
+		t.Errorf("Unexpected human readable entry %+v", entries[0])
+	}
+}
+
+func TestLsParserTimeStyles(t *testing.T) {
+	parser := &LsParser{}
+
+	testInput := `-rw-r--r-- 1 user group 1234 2024-01-15 10:30:25.123456789 +0100 full.txt
+-rw-r--r-- 1 user group 1234 2024-01-15 10:30 long.txt
+-rw-r--r-- 1 user group 1234 2023-03-01 iso-old.txt
+-rw-r--r-- 1 user group 1234 Mar  1  2023 default-old.txt`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]LsEntry)
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+
+	full := entries[0]
+	if full.Name != "full.txt" || full.Modified.Nanosecond() != 123456789 {
+		t.Errorf("Unexpected full-iso entry %q %v", full.Name, full.Modified)
+	}
+	if _, offset := full.Modified.Zone(); offset != 3600 {
+		t.Errorf("Expected +0100 offset, got %d", offset)
+	}
+	if entries[1].Name != "long.txt" || entries[1].Modified.Minute() != 30 {
+		t.Errorf("Unexpected long-iso entry %q %v", entries[1].Name, entries[1].Modified)
+	}
+	if entries[2].Name != "iso-old.txt" || entries[2].Modified.Year() != 2023 {
+		t.Errorf("Unexpected iso entry %q %v", entries[2].Name, entries[2].Modified)
+	}
+	if entries[3].Name != "default-old.txt" || entries[3].Modified.Year() != 2023 {
+		t.Errorf("Unexpected default entry %q %v", entries[3].Name, entries[3].Modified)
+	}
+}
+
+func TestLsParserQuotedNamesAndSections(t *testing.T) {
+	parser := &LsParser{}
+
+	testInput := `.:
+total 8
+-rw-r--r-- 1 user group   12 Jan 15 10:30 'my file.txt'
+-rw-r--r-- 1 user group   12 Jan 15 10:30 "it's"
+-rw-r--r-- 1 user group   12 Jan 15 10:30 'line'$'\n''break'
+-rw-r--r-- 1 user group   12 Jan 15 10:30 plain  two  spaces
+lrwxrwxrwx 1 user group   11 Jan 15 10:30 'the link' -> 'my file.txt'
+drwxr-xr-x 2 user group 4096 Jan 15 10:30 sub
+
+./sub:
+total 0`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]LsEntry)
+	if len(entries) != 6 {
+		t.Fatalf("Expected 6 entries, got %d", len(entries))
+	}
+
+	expected := []string{"my file.txt", "it's", "line\nbreak", "plain  two  spaces", "the link", "sub"}
+	for i, name := range expected {
+		if entries[i].Name != name {
+			t.Errorf("Expected name %q, got %q", name, entries[i].Name)
+		}
+		if entries[i].Directory != "." {
+			t.Errorf("Expected directory '.', got %q", entries[i].Directory)
+		}
+	}
+	if entries[4].LinkTarget != "my file.txt" {
+		t.Errorf("Expected unquoted link target, got %q", entries[4].LinkTarget)
+	}
+}
+
+func TestLsParserTree(t *testing.T) {
+	parser := &LsParser{Tree: true}
+
+	testInput := `/opt/app:
+total 12
+drwxr-xr-x 3 deploy deploy 4096 Jan 15 10:30 lib
+-rw-r--r-- 1 deploy deploy  100 Jan 15 10:30 VERSION
+
+/opt/app/lib:
+total 8
+-rw-r--r-- 1 deploy deploy 2000 Jan 15 10:30 core.jar
+drwxr-xr-x 2 deploy deploy 4096 Jan 15 10:30 plugins
+
+/opt/app/lib/plugins:
+total 4
+-rw-r--r-- 1 deploy deploy  500 Jan 15 10:30 extra.jar
+lrwxrwxrwx 1 deploy deploy    9 Jan 15 10:30 latest.jar -> extra.jar`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	roots, ok := result.([]FileTreeNode)
+	if !ok {
+		t.Fatalf("Expected []FileTreeNode, got %T", result)
+	}
+	if len(roots) != 1 {
+		t.Fatalf("Expected 1 root, got %d", len(roots))
+	}
+
+	root := roots[0]
+	if root.Path != "/opt/app" || root.Name != "app" || root.Type != FileTypeDirectory {
+		t.Errorf("Unexpected root %+v", root)
+	}
+	if root.FileCount != 4 || root.DirectoryCount != 2 || root.TotalSize != 2609 {
+		t.Errorf("Unexpected root totals files=%d dirs=%d size=%d", root.FileCount, root.DirectoryCount, root.TotalSize)
+	}
+	if len(root.Children) != 2 {
+		t.Fatalf("Expected 2 children, got %d", len(root.Children))
+	}
+
+	lib := root.Children[0]
+	if lib.Path != "/opt/app/lib" || lib.Permissions != "drwxr-xr-x" || lib.Owner != "deploy" {
+		t.Errorf("Expected merged lib directory, got %+v", lib)
+	}
+	if lib.FileCount != 3 || lib.DirectoryCount != 1 || lib.TotalSize != 2509 {
+		t.Errorf("Unexpected lib totals files=%d dirs=%d size=%d", lib.FileCount, lib.DirectoryCount, lib.TotalSize)
+	}
+
+	plugins := lib.Children[1]
+	if plugins.Path != "/opt/app/lib/plugins" || len(plugins.Children) != 2 {
+		t.Fatalf("Unexpected plugins directory %+v", plugins)
+	}
+	if plugins.Children[1].LinkTarget != "extra.jar" || plugins.Children[1].Type != FileTypeSymlink {
+		t.Errorf("Unexpected symlink %+v", plugins.Children[1])
+	}
+}
+
+func TestLsParserTreeWithoutHeader(t *testing.T) {
+	parser := &LsParser{Tree: true}
+
+	result, err := parser.Parse(`total 4
+-rw-r--r-- 1 user group 10 Jan 15 10:30 a.txt
+drwxr-xr-x 2 user group 4096 Jan 15 10:30 docs`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	roots := result.([]FileTreeNode)
+	if len(roots) != 1 || roots[0].Path != "." || len(roots[0].Children) != 2 {
+		t.Fatalf("Expected '.' with 2 children, got %+v", roots)
+	}
+	if roots[0].Children[0].Path != "./a.txt" || roots[0].FileCount != 1 || roots[0].DirectoryCount != 1 {
+		t.Errorf("Unexpected tree %+v", roots[0])
+	}
+}
+
+func TestLsParserTreeRelativePaths(t *testing.T) {
+	parser := &LsParser{Tree: true}
+
+	result, err := parser.Parse(`.:
+total 8
+-rw-r--r-- 1 user group   10 Jan 15 10:30 a.txt
+drwxr-xr-x 2 user group 4096 Jan 15 10:30 sub
+
+./sub:
+total 4
+-rw-r--r-- 1 user group  512 Jan 15 10:30 b.bin`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	roots := result.([]FileTreeNode)
+	if len(roots) != 1 || roots[0].Path != "." || len(roots[0].Children) != 2 {
+		t.Fatalf("Expected '.' with 2 children, got %+v", roots)
+	}
+
+	a, sub := roots[0].Children[0], roots[0].Children[1]
+	if a.Path != "./a.txt" || sub.Path != "./sub" {
+		t.Errorf("Expected ./a.txt and ./sub, got %q and %q", a.Path, sub.Path)
+	}
+	if sub.Permissions != "drwxr-xr-x" || len(sub.Children) != 1 {
+		t.Fatalf("Expected merged sub directory with 1 child, got %+v", sub)
+	}
+	if b := sub.Children[0]; b.Path != "./sub/b.bin" || !strings.HasPrefix(b.Path, sub.Path+"/") {
+		t.Errorf("Expected ./sub/b.bin, got %q", b.Path)
+	}
+}
+
+func TestLsParserYearInference(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	parser := &LsParser{
+		ReferenceTime: time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+		Location:      berlin,
+	}
+
+	testInput := `-rw-r--r-- 1 user group 1 Dec 20 10:00 last-year.txt
+-rw-r--r-- 1 user group 1 Jan 15 10:30 this-year.txt
+-rw-r--r-- 1 user group 1 Feb 29 08:00 leap.txt
+-rw-r--r-- 1 user group 1 Jun  1  2021 old.txt
+-rw-r--r-- 1 user group 1 01-05 09:00 iso.txt
+-rw-r--r-- 1 user group 1 Foo 32 25:61 broken.txt`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]LsEntry)
+	if len(entries) != 6 {
+		t.Fatalf("Expected 6 entries, got %d", len(entries))
+	}
+
+	expected := []time.Time{
+		time.Date(2023, 12, 20, 10, 0, 0, 0, berlin),
+		time.Date(2024, 1, 15, 10, 30, 0, 0, berlin),
+		time.Date(2024, 2, 29, 8, 0, 0, 0, berlin),
+		time.Date(2021, 6, 1, 0, 0, 0, 0, berlin),
+		time.Date(2024, 1, 5, 9, 0, 0, 0, berlin),
+	}
+	for i, want := range expected {
+		if !entries[i].Modified.Equal(want) {
+			t.Errorf("%s: expected %v, got %v", entries[i].Name, want, entries[i].Modified)
+		}
+		if len(entries[i].Issues) != 0 {
+			t.Errorf("%s: unexpected issues %v", entries[i].Name, entries[i].Issues)
+		}
+	}
+
+	broken := entries[5]
+	if broken.Name != "broken.txt" || !broken.Modified.IsZero() {
+		t.Errorf("Unexpected broken entry %+v", broken)
+	}
+	if len(broken.Issues) != 1 || broken.Issues[0] != "unable to parse date: Foo 32 25:61" {
+		t.Errorf("Expected unparseable date issue, got %v", broken.Issues)
+	}
+}
+
+func TestLsParserYearInferenceAcrossNewYear(t *testing.T) {
+	// Undated timestamps are in the past six months: Jan 3 seen on Dec 31
+	// is from the start of the same year, Dec 31 23:00 is within the day
+	// allowed for clock skew, and Jan 15 seen in October is not next year's
+	parser := &LsParser{ReferenceTime: time.Date(2023, 12, 31, 1, 0, 0, 0, time.UTC)}
+
+	result, err := parser.Parse(`-rw-r--r-- 1 user group 1 Jan  3 10:00 a
+-rw-r--r-- 1 user group 1 Dec 31 23:00 b`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]LsEntry)
+	if entries[0].Modified.Format("2006-01-02") != "2023-01-03" {
+		t.Errorf("Expected Jan 3 2023, got %v", entries[0].Modified)
+	}
+	if entries[1].Modified.Format("2006-01-02") != "2023-12-31" {
+		t.Errorf("Expected Dec 31 2023, got %v", entries[1].Modified)
+	}
+
+	parser = &LsParser{ReferenceTime: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)}
+	result, err = parser.Parse(`-rw-r--r-- 1 user group 1 Jan 15 10:30 c`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := result.([]LsEntry)[0].Modified.Format("2006-01-02"); got != "2026-01-15" {
+		t.Errorf("Expected Jan 15 2026, got %s", got)
+	}
+}
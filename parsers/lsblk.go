@@ -1,22 +1,50 @@
 package parsers
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
-// LsblkParser parses lsblk command output
+// LsblkParser parses lsblk command output: the default tree table, the list
+// form (-l), custom columns (-f, -o), key="value" pairs (-P) and JSON (-J)
 type LsblkParser struct{}
 
-// LsblkEntry represents a single lsblk output entry
+// LsblkEntry represents a single block device. Devices nested in the tree
+// (partitions, LVM volumes, crypt mappings) are listed in Children.
 type LsblkEntry struct {
-	Name       string `json:"name"`
-	MajMin     string `json:"maj_min"`
-	Rm         string `json:"rm"`
-	Size       string `json:"size"`
-	Ro         string `json:"ro"`
-	Type       string `json:"type"`
-	Mountpoint string `json:"mountpoint"`
+	Name         string            `json:"name"`
+	KName        string            `json:"kname,omitempty"`
+	Path         string            `json:"path,omitempty"`
+	MajMin       string            `json:"maj_min"`
+	Rm           bool              `json:"rm"`
+	Size         string            `json:"size"`
+	SizeBytes    *int64            `json:"size_bytes,omitempty"`
+	Ro           bool              `json:"ro"`
+	Type         string            `json:"type"`
+	Mountpoint   string            `json:"mountpoint"`
+	Mountpoints  []string          `json:"mountpoints,omitempty"`
+	FSType       string            `json:"fstype,omitempty"`
+	FSVersion    string            `json:"fsver,omitempty"`
+	Label        string            `json:"label,omitempty"`
+	UUID         string            `json:"uuid,omitempty"`
+	PartUUID     string            `json:"partuuid,omitempty"`
+	PartLabel    string            `json:"partlabel,omitempty"`
+	FSAvail      string            `json:"fsavail,omitempty"`
+	FSAvailBytes *int64            `json:"fsavail_bytes,omitempty"`
+	FSSize       string            `json:"fssize,omitempty"`
+	FSSizeBytes  *int64            `json:"fssize_bytes,omitempty"`
+	FSUsed       string            `json:"fsused,omitempty"`
+	FSUsedBytes  *int64            `json:"fsused_bytes,omitempty"`
+	FSUsePercent *int              `json:"fsuse_percent,omitempty"`
+	Model        string            `json:"model,omitempty"`
+	Serial       string            `json:"serial,omitempty"`
+	Transport    string            `json:"tran,omitempty"`
+	ParentName   string            `json:"pkname,omitempty"`
+	Extra        map[string]string `json:"extra,omitempty"`
+	Children     []LsblkEntry      `json:"children,omitempty"`
 }
 
 func (p *LsblkParser) Name() string {
@@ -28,40 +56,286 @@ func (p *LsblkParser) Parse(input string) (interface{}, error) {
 	if input == "" {
 		return nil, fmt.Errorf("empty input")
 	}
-	
-	lines := splitLines(input)
-	if len(lines) == 0 {
-		return []LsblkEntry{}, nil
+
+	if strings.HasPrefix(input, "{") {
+		return parseLsblkJSON(input)
 	}
 
-	// Skip header line
-	dataLines := lines[1:]
-	var entries []LsblkEntry
+	if regexp.MustCompile(`^[A-Z:_%-]+="`).MatchString(input) {
+		return parseLsblkPairs(input), nil
+	}
+
+	return parseLsblkTable(input), nil
+}
 
-	for _, line := range dataLines {
-		fields := splitFields(line)
-		
-		// lsblk output typically has 7 fields: NAME MAJ:MIN RM SIZE RO TYPE MOUNTPOINT
-		if len(fields) < 6 {
+// parseLsblkTable parses the column output, rebuilding the device tree from
+// the ├─/└─ (or -i ASCII |-/`-) prefixes in the NAME column
+func parseLsblkTable(input string) []LsblkEntry {
+	lines := strings.Split(input, "\n")
+	names := strings.Fields(lines[0])
+	starts := columnStarts(lines[0], names)
+
+	roots := []LsblkEntry{}
+	var stack []*LsblkEntry
+	var last *LsblkEntry
+
+	for _, line := range lines[1:] {
+		line = strings.TrimRight(line, " \t\r")
+		if strings.TrimSpace(line) == "" {
 			continue
 		}
 
-		entry := LsblkEntry{
-			Name:   fields[0],
-			MajMin: fields[1],
-			Rm:     fields[2],
-			Size:   fields[3],
-			Ro:     fields[4],
-			Type:   fields[5],
+		// Additional mount points of a device are printed on their own line
+		// under the MOUNTPOINTS column, behind the │ of an open tree branch
+		prefix := lsblkTreePrefix(line)
+		rest := strings.TrimSpace(line[len(prefix):])
+		if last != nil && len(strings.Fields(rest)) == 1 && (strings.HasPrefix(rest, "/") || strings.HasPrefix(rest, "[")) {
+			last.Mountpoints = append(last.Mountpoints, rest)
+			continue
 		}
 
-		// Mountpoint is optional (can be empty)
-		if len(fields) >= 7 {
-			entry.Mountpoint = fields[6]
+		depth := len([]rune(prefix)) / 2
+		line = strings.Repeat(" ", len([]rune(prefix))) + line[len(prefix):]
+
+		values := strings.Fields(line)
+		if len(values) != len(names) {
+			values = alignFields(line, names, starts)
 		}
 
+		entry := LsblkEntry{}
+		for i, name := range names {
+			applyLsblkValue(&entry, name, values[i])
+		}
+
+		if depth > len(stack) {
+			depth = len(stack)
+		}
+		stack = stack[:depth]
+		if depth == 0 {
+			roots = append(roots, entry)
+			last = &roots[len(roots)-1]
+		} else {
+			parent := stack[depth-1]
+			parent.Children = append(parent.Children, entry)
+			last = &parent.Children[len(parent.Children)-1]
+		}
+		stack = append(stack, last)
+	}
+
+	return roots
+}
+
+// lsblkTreePrefix returns the tree drawing characters and indentation in
+// front of a device name
+func lsblkTreePrefix(line string) string {
+	for i, r := range line {
+		if !strings.ContainsRune("├└│─ |`-", r) {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseLsblkPairs parses lsblk -P output. Devices are nested under their
+// parent when the PKNAME column is present.
+func parseLsblkPairs(input string) []LsblkEntry {
+	pairRe := regexp.MustCompile(`([A-Z:_%-]+)="((?:[^"\\]|\\.)*)"`)
+
+	var entries []LsblkEntry
+	for _, line := range splitLines(input) {
+		entry := LsblkEntry{}
+		for _, match := range pairRe.FindAllStringSubmatch(line, -1) {
+			applyLsblkValue(&entry, match[1], decodeMountEscapes(match[2]))
+		}
 		entries = append(entries, entry)
 	}
 
+	return nestLsblkEntries(entries)
+}
+
+// nestLsblkEntries moves devices under the entry named by their PKNAME
+func nestLsblkEntries(entries []LsblkEntry) []LsblkEntry {
+	children := map[string][]LsblkEntry{}
+	var roots []LsblkEntry
+	known := map[string]bool{}
+	for _, entry := range entries {
+		known[entry.KName] = true
+		known[entry.Name] = true
+	}
+
+	for _, entry := range entries {
+		if entry.ParentName != "" && known[entry.ParentName] {
+			children[entry.ParentName] = append(children[entry.ParentName], entry)
+		} else {
+			roots = append(roots, entry)
+		}
+	}
+
+	var attach func(entry *LsblkEntry, seen map[string]bool)
+	attach = func(entry *LsblkEntry, seen map[string]bool) {
+		key := entry.KName
+		if key == "" {
+			key = entry.Name
+		}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		entry.Children = append(entry.Children, children[key]...)
+		for i := range entry.Children {
+			attach(&entry.Children[i], seen)
+		}
+		delete(seen, key)
+	}
+
+	for i := range roots {
+		attach(&roots[i], map[string]bool{})
+	}
+
+	if roots == nil {
+		roots = []LsblkEntry{}
+	}
+	return roots
+}
+
+// parseLsblkJSON parses lsblk -J output. Older util-linux versions print
+// booleans and numbers as strings; both forms are accepted.
+func parseLsblkJSON(input string) ([]LsblkEntry, error) {
+	var doc struct {
+		BlockDevices []map[string]interface{} `json:"blockdevices"`
+	}
+	if err := json.Unmarshal([]byte(input), &doc); err != nil {
+		return nil, fmt.Errorf("invalid lsblk JSON: %w", err)
+	}
+
+	entries := []LsblkEntry{}
+	for _, device := range doc.BlockDevices {
+		entries = append(entries, lsblkEntryFromJSON(device))
+	}
+
 	return entries, nil
 }
+
+func lsblkEntryFromJSON(device map[string]interface{}) LsblkEntry {
+	entry := LsblkEntry{}
+
+	for key, value := range device {
+		switch v := value.(type) {
+		case []interface{}:
+			if key == "children" {
+				for _, child := range v {
+					if childMap, ok := child.(map[string]interface{}); ok {
+						entry.Children = append(entry.Children, lsblkEntryFromJSON(childMap))
+					}
+				}
+				continue
+			}
+			// mountpoints: [null] for an unmounted device
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					applyLsblkValue(&entry, key, s)
+				}
+			}
+		case string:
+			applyLsblkValue(&entry, key, v)
+		case bool:
+			if v {
+				applyLsblkValue(&entry, key, "1")
+			} else {
+				applyLsblkValue(&entry, key, "0")
+			}
+		case float64:
+			applyLsblkValue(&entry, key, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+	}
+
+	return entry
+}
+
+// applyLsblkValue stores a column value by its header name, as printed in
+// the table header, -P keys or lowercase -J keys
+func applyLsblkValue(entry *LsblkEntry, column, value string) {
+	column = strings.ToUpper(column)
+	switch column {
+	case "MAJ_MIN":
+		column = "MAJ:MIN"
+	case "FSUSE_":
+		column = "FSUSE%"
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+
+	switch column {
+	case "NAME":
+		entry.Name = value
+	case "KNAME":
+		entry.KName = value
+	case "PATH":
+		entry.Path = value
+	case "MAJ:MIN":
+		entry.MajMin = value
+	case "RM":
+		entry.Rm = value == "1" || value == "true"
+	case "RO":
+		entry.Ro = value == "1" || value == "true"
+	case "SIZE":
+		entry.Size = value
+		entry.SizeBytes = lsblkBytes(value)
+	case "TYPE":
+		entry.Type = value
+	case "MOUNTPOINT", "MOUNTPOINTS":
+		if entry.Mountpoint == "" {
+			entry.Mountpoint = value
+		}
+		entry.Mountpoints = append(entry.Mountpoints, value)
+	case "FSTYPE":
+		entry.FSType = value
+	case "FSVER":
+		entry.FSVersion = value
+	case "LABEL":
+		entry.Label = value
+	case "UUID":
+		entry.UUID = value
+	case "PARTUUID":
+		entry.PartUUID = value
+	case "PARTLABEL":
+		entry.PartLabel = value
+	case "FSAVAIL":
+		entry.FSAvail = value
+		entry.FSAvailBytes = lsblkBytes(value)
+	case "FSSIZE":
+		entry.FSSize = value
+		entry.FSSizeBytes = lsblkBytes(value)
+	case "FSUSED":
+		entry.FSUsed = value
+		entry.FSUsedBytes = lsblkBytes(value)
+	case "FSUSE%":
+		if percent, err := strconv.Atoi(strings.TrimSuffix(value, "%")); err == nil {
+			entry.FSUsePercent = &percent
+		}
+	case "MODEL":
+		entry.Model = value
+	case "SERIAL":
+		entry.Serial = value
+	case "TRAN":
+		entry.Transport = value
+	case "PKNAME":
+		entry.ParentName = value
+	default:
+		if entry.Extra == nil {
+			entry.Extra = map[string]string{}
+		}
+		entry.Extra[strings.ToLower(column)] = value
+	}
+}
+
+func lsblkBytes(size string) *int64 {
+	bytes, err := parseSizeToBytes(size)
+	if err != nil {
+		return nil
+	}
+	return &bytes
+}
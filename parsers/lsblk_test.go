@@ -27,8 +27,11 @@ nvme0n1   259:0    0   256G  0 disk
 		t.Fatalf("Expected []LsblkEntry, got %T", result)
 	}
 
-	if len(entries) != 7 {
-		t.Fatalf("Expected 7 entries, got %d", len(entries))
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 top-level devices, got %d", len(entries))
+	}
+	if len(entries[0].Children) != 3 {
+		t.Fatalf("Expected 3 partitions under sda, got %d", len(entries[0].Children))
 	}
 
 	// Test first entry (sda)
@@ -38,14 +41,17 @@ nvme0n1   259:0    0   256G  0 disk
 	if entries[0].MajMin != "8:0" {
 		t.Errorf("Expected maj:min '8:0', got '%s'", entries[0].MajMin)
 	}
-	if entries[0].Rm != "0" {
-		t.Errorf("Expected rm '0', got '%s'", entries[0].Rm)
+	if entries[0].Rm {
+		t.Error("Expected rm false")
 	}
 	if entries[0].Size != "931.5G" {
 		t.Errorf("Expected size '931.5G', got '%s'", entries[0].Size)
 	}
-	if entries[0].Ro != "0" {
-		t.Errorf("Expected ro '0', got '%s'", entries[0].Ro)
+	if entries[0].Ro {
+		t.Error("Expected ro false")
+	}
+	if entries[0].SizeBytes == nil || *entries[0].SizeBytes != 1000190509056 {
+		t.Errorf("Expected size_bytes 1000190509056, got %v", entries[0].SizeBytes)
 	}
 	if entries[0].Type != "disk" {
 		t.Errorf("Expected type 'disk', got '%s'", entries[0].Type)
@@ -54,15 +60,23 @@ nvme0n1   259:0    0   256G  0 disk
 		t.Errorf("Expected empty mountpoint, got '%s'", entries[0].Mountpoint)
 	}
 
-	// Test partition with mountpoint (sda1)
-	if entries[1].Name != "├─sda1" {
-		t.Errorf("Expected name '├─sda1', got '%s'", entries[1].Name)
+	// Test partition with mountpoint (sda1), tree prefix stripped
+	sda1 := entries[0].Children[0]
+	if sda1.Name != "sda1" {
+		t.Errorf("Expected name 'sda1', got '%s'", sda1.Name)
+	}
+	if sda1.Type != "part" {
+		t.Errorf("Expected type 'part', got '%s'", sda1.Type)
 	}
-	if entries[1].Type != "part" {
-		t.Errorf("Expected type 'part', got '%s'", entries[1].Type)
+	if sda1.Mountpoint != "/boot/efi" {
+		t.Errorf("Expected mountpoint '/boot/efi', got '%s'", sda1.Mountpoint)
 	}
-	if entries[1].Mountpoint != "/boot/efi" {
-		t.Errorf("Expected mountpoint '/boot/efi', got '%s'", entries[1].Mountpoint)
+
+	if entries[1].Name != "sr0" || !entries[1].Rm {
+		t.Errorf("Expected removable sr0, got %+v", entries[1])
+	}
+	if len(entries[2].Children) != 1 || entries[2].Children[0].Mountpoint != "/home" {
+		t.Errorf("Expected nvme0n1p1 mounted on /home, got %+v", entries[2].Children)
 	}
 
 	// Test ROM device
@@ -148,3 +162,194 @@ func TestLsblkParserEmpty(t *testing.T) {
 		t.Errorf("Expected 0 entries, got %d", len(entries))
 	}
 }
+
+func TestLsblkParserNested(t *testing.T) {
+	parser := &LsblkParser{}
+
+	testInput := `NAME                  MAJ:MIN RM   SIZE RO TYPE  MOUNTPOINTS
+nvme0n1               259:0    0 476.9G  0 disk
+├─nvme0n1p1           259:1    0   512M  0 part  /boot/efi
+└─nvme0n1p2           259:2    0 476.4G  0 part
+  └─luks-1234         253:0    0 476.4G  0 crypt
+    ├─vg-root         253:1    0    50G  0 lvm   /
+    └─vg-home         253:2    0 426.4G  0 lvm   /home
+                                                 /var/lib/docker
+sdb                     8:16   1  14.9G  0 disk
+` + "`-sdb1                  8:17   1  14.9G  1 part"
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]LsblkEntry)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 top-level devices, got %d", len(entries))
+	}
+
+	nvme := entries[0]
+	if len(nvme.Children) != 2 {
+		t.Fatalf("Expected 2 partitions, got %d", len(nvme.Children))
+	}
+	if nvme.Children[1].Mountpoint != "" {
+		t.Errorf("Expected empty mountpoint for nvme0n1p2, got %q", nvme.Children[1].Mountpoint)
+	}
+
+	luks := nvme.Children[1].Children
+	if len(luks) != 1 || luks[0].Name != "luks-1234" || luks[0].Type != "crypt" {
+		t.Fatalf("Expected crypt device under nvme0n1p2, got %+v", luks)
+	}
+	if len(luks[0].Children) != 2 {
+		t.Fatalf("Expected 2 logical volumes, got %d", len(luks[0].Children))
+	}
+
+	home := luks[0].Children[1]
+	if home.Name != "vg-home" || home.Mountpoint != "/home" {
+		t.Errorf("Unexpected home volume %+v", home)
+	}
+	if len(home.Mountpoints) != 2 || home.Mountpoints[1] != "/var/lib/docker" {
+		t.Errorf("Expected 2 mountpoints, got %v", home.Mountpoints)
+	}
+
+	sdb := entries[1]
+	if !sdb.Rm || len(sdb.Children) != 1 || sdb.Children[0].Name != "sdb1" || !sdb.Children[0].Ro {
+		t.Errorf("Unexpected ASCII tree entry %+v", sdb)
+	}
+}
+
+func TestLsblkParserMountpointsInBranch(t *testing.T) {
+	parser := &LsblkParser{}
+
+	testInput := `NAME   MAJ:MIN RM   SIZE RO TYPE MOUNTPOINTS
+sda      8:0    0   100G  0 disk
+├─sda1   8:1    0     1G  0 part /boot
+├─sda2   8:2    0    50G  0 part /
+│                                /srv
+│                                /var/lib/containers
+└─sda3   8:3    0    49G  0 part [SWAP]`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]LsblkEntry)
+	if len(entries) != 1 || len(entries[0].Children) != 3 {
+		t.Fatalf("Expected sda with 3 partitions, got %+v", entries)
+	}
+
+	sda2 := entries[0].Children[1]
+	if sda2.Name != "sda2" || len(sda2.Children) != 0 {
+		t.Errorf("Expected sda2 without children, got %+v", sda2)
+	}
+	if len(sda2.Mountpoints) != 3 || sda2.Mountpoints[1] != "/srv" || sda2.Mountpoints[2] != "/var/lib/containers" {
+		t.Errorf("Expected 3 mountpoints, got %v", sda2.Mountpoints)
+	}
+	if entries[0].Children[2].Name != "sda3" {
+		t.Errorf("Expected sda3 after sda2, got %+v", entries[0].Children[2])
+	}
+}
+
+func TestLsblkParserFilesystems(t *testing.T) {
+	parser := &LsblkParser{}
+
+	testInput := `NAME        FSTYPE      FSVER    LABEL       UUID                                 FSAVAIL FSUSE% MOUNTPOINTS
+sda
+├─sda1      vfat        FAT32    EFI         1A2B-3C4D                             505.9M     1% /boot/efi
+├─sda2      ext4        1.0      My Root     0f1e2d3c-4b5a-6978-8a9b-0c1d2e3f4a5b   20.3G    54% /
+└─sda3      swap        1                    9e8d7c6b-5a49-3827-1605-f4e3d2c1b0a9                [SWAP]`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]LsblkEntry)
+	if len(entries) != 1 || len(entries[0].Children) != 3 {
+		t.Fatalf("Expected sda with 3 partitions, got %+v", entries)
+	}
+
+	efi := entries[0].Children[0]
+	if efi.FSType != "vfat" || efi.FSVersion != "FAT32" || efi.Label != "EFI" || efi.UUID != "1A2B-3C4D" {
+		t.Errorf("Unexpected EFI partition %+v", efi)
+	}
+	if efi.FSAvailBytes == nil || *efi.FSAvailBytes != 530474598 {
+		t.Errorf("Expected fsavail_bytes 530474598, got %v", efi.FSAvailBytes)
+	}
+
+	root := entries[0].Children[1]
+	if root.Label != "My Root" {
+		t.Errorf("Expected label 'My Root', got %q", root.Label)
+	}
+	if root.FSUsePercent == nil || *root.FSUsePercent != 54 {
+		t.Errorf("Expected fsuse 54, got %v", root.FSUsePercent)
+	}
+
+	swap := entries[0].Children[2]
+	if swap.Label != "" || swap.FSAvail != "" || swap.Mountpoint != "[SWAP]" {
+		t.Errorf("Unexpected swap partition %+v", swap)
+	}
+}
+
+func TestLsblkParserPairs(t *testing.T) {
+	parser := &LsblkParser{}
+
+	testInput := `NAME="sda" MAJ:MIN="8:0" RM="0" SIZE="100G" RO="0" TYPE="disk" MOUNTPOINT="" PKNAME=""
+NAME="sda1" MAJ:MIN="8:1" RM="0" SIZE="100G" RO="0" TYPE="part" MOUNTPOINT="/mnt/my\x20disk" PKNAME="sda"`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]LsblkEntry)
+	if len(entries) != 1 || len(entries[0].Children) != 1 {
+		t.Fatalf("Expected sda with 1 partition, got %+v", entries)
+	}
+	if entries[0].Children[0].Mountpoint != "/mnt/my disk" {
+		t.Errorf("Expected decoded mountpoint, got %q", entries[0].Children[0].Mountpoint)
+	}
+}
+
+func TestLsblkParserJSON(t *testing.T) {
+	parser := &LsblkParser{}
+
+	testInput := `{
+   "blockdevices": [
+      {"name":"sda", "maj:min":"8:0", "rm":false, "size":"931.5G", "ro":false, "type":"disk", "mountpoints":[null],
+         "children": [
+            {"name":"sda1", "maj:min":"8:1", "rm":false, "size":"512M", "ro":false, "type":"part", "mountpoints":["/boot/efi"]},
+            {"name":"sda2", "maj:min":"8:2", "rm":"1", "size":"931G", "ro":"1", "type":"part", "mountpoints":["/", "/home"], "fsuse%":"12%"}
+         ]
+      }
+   ]
+}`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]LsblkEntry)
+	if len(entries) != 1 || len(entries[0].Children) != 2 {
+		t.Fatalf("Expected sda with 2 partitions, got %+v", entries)
+	}
+	if entries[0].Mountpoint != "" || entries[0].MajMin != "8:0" {
+		t.Errorf("Unexpected disk entry %+v", entries[0])
+	}
+
+	sda2 := entries[0].Children[1]
+	if !sda2.Rm || !sda2.Ro {
+		t.Error("Expected string booleans to be decoded")
+	}
+	if len(sda2.Mountpoints) != 2 || sda2.Mountpoint != "/" {
+		t.Errorf("Unexpected mountpoints %v", sda2.Mountpoints)
+	}
+	if sda2.FSUsePercent == nil || *sda2.FSUsePercent != 12 {
+		t.Errorf("Expected fsuse 12, got %v", sda2.FSUsePercent)
+	}
+
+	if _, err := parser.Parse("{not json"); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
@@ -0,0 +1,64 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LsbReleaseParser parses lsb_release -a output, or any of its single
+// field forms (-i, -d, -r, -c, -v)
+type LsbReleaseParser struct{}
+
+// LsbRelease is the distribution information printed by lsb_release.
+// LSBVersion lists the LSB modules supported; Messages holds notes such
+// as "No LSB modules are available."
+type LsbRelease struct {
+	DistributorID string   `json:"distributor_id,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	Release       string   `json:"release,omitempty"`
+	Codename      string   `json:"codename,omitempty"`
+	LSBVersion    []string `json:"lsb_version,omitempty"`
+	Messages      []string `json:"messages,omitempty"`
+}
+
+func (p *LsbReleaseParser) Name() string {
+	return "lsbrelease"
+}
+
+func (p *LsbReleaseParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	release := LsbRelease{}
+
+	for _, line := range splitLines(input) {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			release.Messages = append(release.Messages, line)
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "Distributor ID":
+			release.DistributorID = value
+		case "Description":
+			release.Description = value
+		case "Release":
+			release.Release = value
+		case "Codename":
+			release.Codename = value
+		case "LSB Version":
+			// Modules are separated by colons, "n/a" when there are none
+			if value != "n/a" {
+				release.LSBVersion = strings.Split(value, ":")
+			}
+		default:
+			release.Messages = append(release.Messages, line)
+		}
+	}
+
+	return release, nil
+}
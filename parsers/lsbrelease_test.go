@@ -0,0 +1,52 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestLsbReleaseParser(t *testing.T) {
+	parser := &LsbReleaseParser{}
+
+	testInput := `No LSB modules are available.
+Distributor ID:	Ubuntu
+Description:	Ubuntu 22.04.3 LTS
+Release:	22.04
+Codename:	jammy`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	release, ok := result.(LsbRelease)
+	if !ok {
+		t.Fatalf("Expected LsbRelease, got %T", result)
+	}
+
+	if release.DistributorID != "Ubuntu" || release.Description != "Ubuntu 22.04.3 LTS" ||
+		release.Release != "22.04" || release.Codename != "jammy" {
+		t.Errorf("Unexpected release %+v", release)
+	}
+	if len(release.Messages) != 1 || release.Messages[0] != "No LSB modules are available." {
+		t.Errorf("Unexpected messages %v", release.Messages)
+	}
+
+	// lsb_release -v lists the supported modules
+	result, err = parser.Parse("LSB Version:	core-4.1-amd64:core-4.1-noarch")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	release = result.(LsbRelease)
+	if len(release.LSBVersion) != 2 || release.LSBVersion[1] != "core-4.1-noarch" {
+		t.Errorf("Unexpected LSB version %v", release.LSBVersion)
+	}
+}
+
+func TestLsbReleaseParserEmpty(t *testing.T) {
+	parser := &LsbReleaseParser{}
+
+	_, err := parser.Parse("")
+	if err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
@@ -1,19 +1,46 @@
 package parsers
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
-// MountParser parses mount command output
+// MountParser parses mount command output, /proc/mounts, /proc/self/mountinfo
+// and findmnt (tree, -l and -J) output into one mount model
 type MountParser struct{}
 
 // MountEntry represents a single mount output entry
 type MountEntry struct {
-	Device      string `json:"device"`
-	MountPoint  string `json:"mount_point"`
-	FilesystemType string `json:"filesystem_type"`
-	Options     string `json:"options"`
+	Device         string                 `json:"device"`
+	MountPoint     string                 `json:"mount_point"`
+	FilesystemType string                 `json:"filesystem_type"`
+	Options        string                 `json:"options"`
+	OptionsMap     map[string]interface{} `json:"options_map,omitempty"`
+	// /proc/mounts dump and pass fields
+	Dump *int `json:"dump,omitempty"`
+	Pass *int `json:"pass,omitempty"`
+	// mountinfo and findmnt fields
+	MountID         int                    `json:"mount_id,omitempty"`
+	ParentID        int                    `json:"parent_id,omitempty"`
+	Parent          string                 `json:"parent,omitempty"`
+	MajorMinor      string                 `json:"maj_min,omitempty"`
+	Root            string                 `json:"root,omitempty"`
+	Propagation     *MountPropagation      `json:"propagation,omitempty"`
+	SuperOptions    string                 `json:"super_options,omitempty"`
+	SuperOptionsMap map[string]interface{} `json:"super_options_map,omitempty"`
+}
+
+// MountPropagation represents the optional propagation fields of mountinfo
+type MountPropagation struct {
+	Shared        bool `json:"shared"`
+	PeerGroup     int  `json:"peer_group,omitempty"`
+	Slave         bool `json:"slave"`
+	MasterGroup   int  `json:"master_group,omitempty"`
+	PropagateFrom int  `json:"propagate_from,omitempty"`
+	Unbindable    bool `json:"unbindable"`
 }
 
 func (p *MountParser) Name() string {
@@ -25,60 +52,352 @@ func (p *MountParser) Parse(input string) (interface{}, error) {
 	if input == "" {
 		return nil, fmt.Errorf("empty input")
 	}
-	
+
 	lines := splitLines(input)
+	mountinfoRe := regexp.MustCompile(`^\d+ \d+ \d+:\d+ `)
+
+	switch {
+	case strings.HasPrefix(input, "{"):
+		return parseFindmntJSON(input)
+	case findInSlice(strings.Fields(lines[0]), "TARGET") >= 0:
+		return parseFindmntTable(strings.Split(input, "\n"))
+	case mountinfoRe.MatchString(lines[0]):
+		return parseMountinfo(lines), nil
+	}
+
+	var entries []MountEntry
+
+	for _, line := range lines {
+		var entry *MountEntry
+		if strings.Contains(line, " on ") {
+			entry = parseMountLine(line)
+		} else {
+			entry = parseProcMountsLine(line)
+		}
+		if entry != nil {
+			entries = append(entries, *entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// parseMountLine parses a line of mount command output:
+//
+//	/dev/sda1 on / type ext4 (rw,relatime)
+//	/dev/disk1s1 on / (apfs, local, journaled)     (BSD/macOS)
+func parseMountLine(line string) *MountEntry {
+	// The mount point is matched greedily so it may itself contain " type "
+	typeRe := regexp.MustCompile(`^(.*?) on (.*) type (\S+)(?: \((.*)\))?$`)
+	bsdRe := regexp.MustCompile(`^(.*?) on (.*) \(([^,)]+)(?:, (.*))?\)$`)
+
+	if matches := typeRe.FindStringSubmatch(line); matches != nil {
+		return newMountEntry(matches[1], matches[2], matches[3], matches[4])
+	}
+
+	if matches := bsdRe.FindStringSubmatch(line); matches != nil {
+		options := strings.ReplaceAll(matches[4], ", ", ",")
+		return newMountEntry(matches[1], matches[2], matches[3], options)
+	}
+
+	return nil
+}
+
+// parseProcMountsLine parses a /proc/mounts line: device mountpoint fstype options dump pass
+func parseProcMountsLine(line string) *MountEntry {
+	fields := splitFields(line)
+	if len(fields) < 4 {
+		return nil
+	}
+
+	entry := newMountEntry(fields[0], fields[1], fields[2], fields[3])
+	if len(fields) >= 6 {
+		if dump, err := strconv.Atoi(fields[4]); err == nil {
+			entry.Dump = &dump
+		}
+		if pass, err := strconv.Atoi(fields[5]); err == nil {
+			entry.Pass = &pass
+		}
+	}
+
+	return entry
+}
+
+// parseMountinfo parses /proc/self/mountinfo lines:
+//
+//	36 35 98:0 /mnt1 /mnt/parent rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+func parseMountinfo(lines []string) []MountEntry {
 	var entries []MountEntry
+	mountPoints := map[int]string{}
 
 	for _, line := range lines {
-		// Mount output format: device on mountpoint type filesystem (options)
-		// Example: /dev/sda1 on / type ext4 (rw,relatime)
-		
-		// Find " on " to split device from rest
-		onIndex := strings.Index(line, " on ")
-		if onIndex == -1 {
+		fields := splitFields(line)
+		separator := findInSlice(fields, "-")
+		if separator < 6 || separator+2 >= len(fields) {
 			continue
 		}
-		
-		device := line[:onIndex]
-		remainder := line[onIndex+4:] // Skip " on "
-		
-		// Find " type " to split mountpoint from filesystem and options
-		typeIndex := strings.Index(remainder, " type ")
-		if typeIndex == -1 {
+
+		superOptions := ""
+		if separator+3 < len(fields) {
+			superOptions = fields[separator+3]
+		}
+
+		entry := newMountEntry(fields[separator+2], fields[4], fields[separator+1], fields[5])
+		entry.MountID, _ = strconv.Atoi(fields[0])
+		entry.ParentID, _ = strconv.Atoi(fields[1])
+		entry.MajorMinor = fields[2]
+		entry.Root = decodeMountEscapes(fields[3])
+		entry.Propagation = parseMountPropagation(fields[6:separator])
+		entry.SuperOptions = superOptions
+		entry.SuperOptionsMap = parseMountOptions(superOptions)
+
+		mountPoints[entry.MountID] = entry.MountPoint
+		entries = append(entries, *entry)
+	}
+
+	// Resolve parent mount points now that every mount ID is known
+	for i := range entries {
+		if entries[i].ParentID != entries[i].MountID {
+			entries[i].Parent = mountPoints[entries[i].ParentID]
+		}
+	}
+
+	return entries
+}
+
+// parseMountPropagation decodes mountinfo optional fields such as shared:1 or master:2
+func parseMountPropagation(tags []string) *MountPropagation {
+	propagation := &MountPropagation{}
+	for _, tag := range tags {
+		parts := strings.SplitN(tag, ":", 2)
+		value := 0
+		if len(parts) == 2 {
+			value, _ = strconv.Atoi(parts[1])
+		}
+		switch parts[0] {
+		case "shared":
+			propagation.Shared = true
+			propagation.PeerGroup = value
+		case "master":
+			propagation.Slave = true
+			propagation.MasterGroup = value
+		case "propagate_from":
+			propagation.PropagateFrom = value
+		case "unbindable":
+			propagation.Unbindable = true
+		}
+	}
+	return propagation
+}
+
+// findmntColumns lists the findmnt column headers understood by the parser
+var findmntColumns = []string{
+	"TARGET", "SOURCE", "FSTYPE", "OPTIONS", "VFS-OPTIONS", "FS-OPTIONS",
+	"PROPAGATION", "ID", "PARENT", "MAJ:MIN", "FSROOT",
+}
+
+// parseFindmntTable parses findmnt default tree and -l list output
+func parseFindmntTable(rawLines []string) (interface{}, error) {
+	header := rawLines[0]
+
+	// Only keep the columns present in the header, in header order
+	var names []string
+	for _, field := range strings.Fields(header) {
+		if findInSlice(findmntColumns, field) >= 0 {
+			names = append(names, field)
+		}
+	}
+	if findInSlice(names, "TARGET") == -1 {
+		return nil, fmt.Errorf("findmnt output without TARGET column")
+	}
+	positions := columnStarts(header, names)
+
+	var entries []MountEntry
+	// Mount points of the current branch, indexed by tree depth
+	var branch []string
+
+	for _, line := range rawLines[1:] {
+		if strings.TrimSpace(line) == "" {
 			continue
 		}
-		
-		mountPoint := remainder[:typeIndex]
-		fsAndOptions := remainder[typeIndex+6:] // Skip " type "
-		
-		// Find space and opening paren to split filesystem from options
-		openParenIndex := strings.Index(fsAndOptions, " (")
-		if openParenIndex == -1 {
-			// No options provided
-			entry := MountEntry{
-				Device:         device,
-				MountPoint:     mountPoint,
-				FilesystemType: fsAndOptions,
-				Options:        "",
+
+		columns := sliceColumns(line, positions)
+		values := map[string]string{}
+		for i, name := range names {
+			values[name] = columns[i]
+		}
+
+		// Tree prefixes are two characters per level: "├─", "└─", "│ " or "  "
+		depth := 0
+		if targetAt := positions[findInSlice(names, "TARGET")]; targetAt >= 0 {
+			runes := []rune(line)
+			end := targetAt
+			for end < len(runes) && strings.ContainsRune("│├└─ ", runes[end]) {
+				end++
 			}
-			entries = append(entries, entry)
-			continue
+			depth = (end - targetAt) / 2
 		}
-		
-		filesystem := fsAndOptions[:openParenIndex]
-		optionsWithParen := fsAndOptions[openParenIndex+2:] // Skip " ("
-		
-		// Remove closing parenthesis
-		options := strings.TrimSuffix(optionsWithParen, ")")
-		
-		entry := MountEntry{
-			Device:         device,
-			MountPoint:     mountPoint,
-			FilesystemType: filesystem,
-			Options:        options,
+		target := strings.TrimLeft(values["TARGET"], "│├└─ ")
+
+		entry := newMountEntry(values["SOURCE"], target, values["FSTYPE"], values["OPTIONS"])
+		applyFindmntValues(entry, values)
+
+		if depth > 0 {
+			if depth > len(branch) {
+				depth = len(branch)
+			}
+			entry.Parent = branch[depth-1]
 		}
-		entries = append(entries, entry)
+		branch = append(branch[:depth], entry.MountPoint)
+
+		entries = append(entries, *entry)
 	}
 
 	return entries, nil
 }
+
+// findmntFilesystem is a node of findmnt -J output
+type findmntFilesystem map[string]interface{}
+
+// parseFindmntJSON parses findmnt -J output, flattening nested children
+func parseFindmntJSON(input string) (interface{}, error) {
+	var document struct {
+		Filesystems []findmntFilesystem `json:"filesystems"`
+	}
+	if err := json.Unmarshal([]byte(input), &document); err != nil {
+		return nil, fmt.Errorf("invalid findmnt JSON: %w", err)
+	}
+
+	var entries []MountEntry
+	var walk func(nodes []findmntFilesystem, parent string)
+	walk = func(nodes []findmntFilesystem, parent string) {
+		for _, node := range nodes {
+			values := map[string]string{}
+			var children []findmntFilesystem
+			for key, value := range node {
+				if key == "children" {
+					if data, err := json.Marshal(value); err == nil {
+						json.Unmarshal(data, &children)
+					}
+					continue
+				}
+				if value != nil {
+					values[strings.ToUpper(key)] = fmt.Sprint(value)
+				}
+			}
+
+			entry := newMountEntry(values["SOURCE"], values["TARGET"], values["FSTYPE"], values["OPTIONS"])
+			applyFindmntValues(entry, values)
+			entry.Parent = parent
+			entries = append(entries, *entry)
+
+			walk(children, entry.MountPoint)
+		}
+	}
+	walk(document.Filesystems, "")
+
+	return entries, nil
+}
+
+// applyFindmntValues copies the optional findmnt columns into a mount entry
+func applyFindmntValues(entry *MountEntry, values map[string]string) {
+	// Bind mounts show the filesystem root as /dev/sda1[/var/lib]
+	if open := strings.Index(entry.Device, "["); open > 0 && strings.HasSuffix(entry.Device, "]") {
+		entry.Root = entry.Device[open+1 : len(entry.Device)-1]
+		entry.Device = entry.Device[:open]
+	}
+	if root := values["FSROOT"]; root != "" {
+		entry.Root = decodeMountEscapes(root)
+	}
+	if entry.Options == "" && values["VFS-OPTIONS"] != "" {
+		entry.Options = values["VFS-OPTIONS"]
+		entry.OptionsMap = parseMountOptions(entry.Options)
+	}
+	if super := values["FS-OPTIONS"]; super != "" {
+		entry.SuperOptions = super
+		entry.SuperOptionsMap = parseMountOptions(super)
+	}
+	if id, err := strconv.Atoi(values["ID"]); err == nil {
+		entry.MountID = id
+	}
+	if parent, err := strconv.Atoi(values["PARENT"]); err == nil {
+		entry.ParentID = parent
+	}
+	entry.MajorMinor = values["MAJ:MIN"]
+	if propagation := values["PROPAGATION"]; propagation != "" {
+		entry.Propagation = &MountPropagation{}
+		for _, mode := range strings.Split(propagation, ",") {
+			switch mode {
+			case "shared":
+				entry.Propagation.Shared = true
+			case "slave":
+				entry.Propagation.Slave = true
+			case "unbindable":
+				entry.Propagation.Unbindable = true
+			}
+		}
+	}
+}
+
+// newMountEntry builds a mount entry, decoding escaped paths and options
+func newMountEntry(device, mountPoint, fsType, options string) *MountEntry {
+	return &MountEntry{
+		Device:         decodeMountEscapes(device),
+		MountPoint:     decodeMountEscapes(mountPoint),
+		FilesystemType: fsType,
+		Options:        options,
+		OptionsMap:     parseMountOptions(options),
+	}
+}
+
+// parseMountOptions decodes a comma separated option string. Flags such as
+// ro, nosuid or noexec map to true, key=value options map to their value.
+func parseMountOptions(options string) map[string]interface{} {
+	if options == "" {
+		return nil
+	}
+
+	decoded := map[string]interface{}{}
+	for _, option := range strings.Split(options, ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		if eq := strings.Index(option, "="); eq != -1 {
+			decoded[option[:eq]] = option[eq+1:]
+		} else {
+			decoded[option] = true
+		}
+	}
+	return decoded
+}
+
+// decodeMountEscapes decodes the octal (\040) and hex (\x20) escapes used for
+// spaces and other special characters in mount tables
+func decodeMountEscapes(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var decoded strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' {
+			if i+3 < len(value) && value[i+1] == 'x' {
+				if b, err := strconv.ParseUint(value[i+2:i+4], 16, 8); err == nil {
+					decoded.WriteByte(byte(b))
+					i += 3
+					continue
+				}
+			}
+			if i+3 < len(value) {
+				if b, err := strconv.ParseUint(value[i+1:i+4], 8, 8); err == nil {
+					decoded.WriteByte(byte(b))
+					i += 3
+					continue
+				}
+			}
+		}
+		decoded.WriteByte(value[i])
+	}
+	return decoded.String()
+}
@@ -145,3 +145,178 @@ func TestMountParserEmpty(t *testing.T) {
 		t.Errorf("Expected 0 entries, got %d", len(entries))
 	}
 }
+
+func TestMountParserOptionsAndEscapes(t *testing.T) {
+	parser := &MountParser{}
+
+	testInput := `/dev/sdb1 on /mnt/my type disk type vfat (ro,nosuid,noexec,uid=1000,fmask=0022)
+/dev/disk1s1 on / (apfs, local, journaled)`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]MountEntry)
+	if !ok {
+		t.Fatalf("Expected []MountEntry, got %T", result)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	// Mount point containing " type "
+	if entries[0].MountPoint != "/mnt/my type disk" || entries[0].FilesystemType != "vfat" {
+		t.Errorf("Unexpected mount point/type: %q %q", entries[0].MountPoint, entries[0].FilesystemType)
+	}
+	if entries[0].OptionsMap["ro"] != true || entries[0].OptionsMap["noexec"] != true {
+		t.Errorf("Expected ro and noexec flags, got %v", entries[0].OptionsMap)
+	}
+	if entries[0].OptionsMap["uid"] != "1000" || entries[0].OptionsMap["fmask"] != "0022" {
+		t.Errorf("Expected uid and fmask values, got %v", entries[0].OptionsMap)
+	}
+
+	// BSD/macOS layout
+	if entries[1].FilesystemType != "apfs" || entries[1].Options != "local,journaled" {
+		t.Errorf("Unexpected BSD entry: %+v", entries[1])
+	}
+}
+
+func TestMountParserProcMounts(t *testing.T) {
+	parser := &MountParser{}
+
+	testInput := `sysfs /sys sysfs rw,nosuid,nodev,noexec,relatime 0 0
+/dev/sda2 /media/USB\040Drive ext4 rw,relatime 0 0`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]MountEntry)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	if entries[0].Device != "sysfs" || entries[0].FilesystemType != "sysfs" || entries[0].OptionsMap["nodev"] != true {
+		t.Errorf("Unexpected sysfs entry: %+v", entries[0])
+	}
+	if entries[0].Dump == nil || *entries[0].Dump != 0 || entries[0].Pass == nil || *entries[0].Pass != 0 {
+		t.Errorf("Expected dump/pass 0, got %v/%v", entries[0].Dump, entries[0].Pass)
+	}
+	if entries[1].MountPoint != "/media/USB Drive" {
+		t.Errorf("Expected decoded mount point '/media/USB Drive', got '%s'", entries[1].MountPoint)
+	}
+}
+
+func TestMountParserMountinfo(t *testing.T) {
+	parser := &MountParser{}
+
+	testInput := `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw,errors=remount-ro
+36 22 98:0 /mnt1 /mnt/parent\040dir rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+40 22 0:35 / /sys/fs/cgroup ro,nosuid,nodev,noexec - cgroup2 cgroup2 rw,nsdelegate`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]MountEntry)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+
+	root := entries[0]
+	if root.MountID != 22 || root.ParentID != 1 || root.MajorMinor != "8:1" {
+		t.Errorf("Unexpected IDs: %d %d %s", root.MountID, root.ParentID, root.MajorMinor)
+	}
+	if root.Device != "/dev/sda1" || root.FilesystemType != "ext4" || root.MountPoint != "/" {
+		t.Errorf("Unexpected root entry: %+v", root)
+	}
+	if root.Propagation == nil || !root.Propagation.Shared || root.Propagation.PeerGroup != 1 {
+		t.Errorf("Expected shared:1 propagation, got %+v", root.Propagation)
+	}
+	if root.SuperOptionsMap["errors"] != "remount-ro" {
+		t.Errorf("Unexpected super options: %v", root.SuperOptionsMap)
+	}
+
+	child := entries[1]
+	if child.MountPoint != "/mnt/parent dir" || child.Root != "/mnt1" || child.Parent != "/" {
+		t.Errorf("Unexpected child entry: %+v", child)
+	}
+	if !child.Propagation.Slave || child.Propagation.MasterGroup != 1 {
+		t.Errorf("Expected master:1 propagation, got %+v", child.Propagation)
+	}
+
+	if entries[2].OptionsMap["ro"] != true || entries[2].Propagation.Shared {
+		t.Errorf("Unexpected cgroup entry: %+v", entries[2])
+	}
+}
+
+func TestMountParserFindmnt(t *testing.T) {
+	parser := &MountParser{}
+
+	treeInput := `TARGET                SOURCE              FSTYPE   OPTIONS
+/                     /dev/sda1           ext4     rw,relatime
+├─/sys                sysfs               sysfs    rw,nosuid,nodev,noexec,relatime
+│ └─/sys/kernel/debug debugfs             debugfs  rw,relatime
+└─/srv/data           /dev/sda1[/data]    ext4     rw,relatime`
+
+	result, err := parser.Parse(treeInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]MountEntry)
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+
+	if entries[1].MountPoint != "/sys" || entries[1].Parent != "/" {
+		t.Errorf("Unexpected /sys entry: %+v", entries[1])
+	}
+	if entries[2].MountPoint != "/sys/kernel/debug" || entries[2].Parent != "/sys" || entries[2].FilesystemType != "debugfs" {
+		t.Errorf("Unexpected debugfs entry: %+v", entries[2])
+	}
+	if entries[3].Device != "/dev/sda1" || entries[3].Root != "/data" || entries[3].Parent != "/" {
+		t.Errorf("Unexpected bind mount entry: %+v", entries[3])
+	}
+
+	listInput := `TARGET     SOURCE    FSTYPE OPTIONS
+/          /dev/sda1 ext4   rw,relatime
+/boot/efi  /dev/sda2 vfat   rw,fmask=0077`
+
+	result, err = parser.Parse(listInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries = result.([]MountEntry)
+	if len(entries) != 2 || entries[1].MountPoint != "/boot/efi" || entries[1].Parent != "" {
+		t.Errorf("Unexpected list entries: %+v", entries)
+	}
+
+	jsonInput := `{
+   "filesystems": [
+      {"target": "/", "source": "/dev/sda1", "fstype": "ext4", "options": "rw,relatime",
+         "children": [
+            {"target": "/home", "source": "/dev/sda3", "fstype": "xfs", "options": "rw,nosuid"}
+         ]
+      }
+   ]
+}`
+
+	result, err = parser.Parse(jsonInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries = result.([]MountEntry)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[1].MountPoint != "/home" || entries[1].Parent != "/" || entries[1].OptionsMap["nosuid"] != true {
+		t.Errorf("Unexpected JSON child entry: %+v", entries[1])
+	}
+}
@@ -2,25 +2,101 @@ package parsers
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
-// NetstatParser parses netstat command output
+// NetstatParser parses netstat command output. The socket table gives
+// []NetstatEntry, netstat -rn gives []NetstatRoute, netstat -i gives
+// []NetstatInterface and netstat -s gives NetstatStatistics. Output with
+// more than one table, such as netstat -a with its UNIX domain socket
+// section, gives a NetstatOutput.
 type NetstatParser struct{}
 
 // NetstatEntry represents a single netstat output entry
 type NetstatEntry struct {
-	Protocol     string `json:"protocol"`
-	LocalAddress string `json:"local_address"`
+	Protocol       string `json:"protocol"`
+	LocalAddress   string `json:"local_address"`
+	LocalHost      string `json:"local_host,omitempty"`
+	LocalPort      string `json:"local_port,omitempty"`
 	ForeignAddress string `json:"foreign_address"`
-	State        string `json:"state,omitempty"`
-	PID          int    `json:"pid,omitempty"`
-	Program      string `json:"program,omitempty"`
-	RecvQ        int    `json:"recv_q,omitempty"`
-	SendQ        int    `json:"send_q,omitempty"`
+	ForeignHost    string `json:"foreign_host,omitempty"`
+	ForeignPort    string `json:"foreign_port,omitempty"`
+	State          string `json:"state,omitempty"`
+	PID            int    `json:"pid,omitempty"`
+	Program        string `json:"program,omitempty"`
+	RecvQ          int    `json:"recv_q,omitempty"`
+	SendQ          int    `json:"send_q,omitempty"`
 }
 
+// NetstatUnixSocket represents a row of the "Active UNIX domain sockets"
+// section. Flags are the bracketed socket flags, e.g. ACC for a listening
+// socket.
+type NetstatUnixSocket struct {
+	Protocol string   `json:"protocol"`
+	RefCnt   int      `json:"ref_cnt"`
+	Flags    []string `json:"flags"`
+	Type     string   `json:"type"`
+	State    string   `json:"state,omitempty"`
+	Inode    int64    `json:"inode"`
+	PID      int      `json:"pid,omitempty"`
+	Program  string   `json:"program,omitempty"`
+	Path     string   `json:"path,omitempty"`
+}
+
+// NetstatRoute represents a routing table entry from netstat -r or route -n
+// on Linux, or netstat -r on BSD/macOS, where Family is the section the
+// route is listed under (Internet, Internet6)
+type NetstatRoute struct {
+	Family      string `json:"family,omitempty"`
+	Destination string `json:"destination"`
+	Gateway     string `json:"gateway"`
+	Genmask     string `json:"genmask,omitempty"`
+	Flags       string `json:"flags"`
+	Metric      *int   `json:"metric,omitempty"`
+	Ref         *int   `json:"ref,omitempty"`
+	Use         *int64 `json:"use,omitempty"`
+	MSS         *int   `json:"mss,omitempty"`
+	Window      *int   `json:"window,omitempty"`
+	IRTT        *int   `json:"irtt,omitempty"`
+	Interface   string `json:"interface"`
+	Expire      string `json:"expire,omitempty"`
+}
+
+// NetstatInterface represents a row of netstat -i on Linux or BSD/macOS
+type NetstatInterface struct {
+	Interface  string `json:"interface"`
+	MTU        int    `json:"mtu"`
+	Metric     *int   `json:"metric,omitempty"`
+	Network    string `json:"network,omitempty"`
+	Address    string `json:"address,omitempty"`
+	RXPackets  *int64 `json:"rx_packets,omitempty"`
+	RXErrors   *int64 `json:"rx_errors,omitempty"`
+	RXDropped  *int64 `json:"rx_dropped,omitempty"`
+	RXOverruns *int64 `json:"rx_overruns,omitempty"`
+	TXPackets  *int64 `json:"tx_packets,omitempty"`
+	TXErrors   *int64 `json:"tx_errors,omitempty"`
+	TXDropped  *int64 `json:"tx_dropped,omitempty"`
+	TXOverruns *int64 `json:"tx_overruns,omitempty"`
+	Collisions *int64 `json:"collisions,omitempty"`
+	Flags      string `json:"flags,omitempty"`
+}
+
+// NetstatOutput holds the tables of netstat output listing several
+type NetstatOutput struct {
+	Connections []NetstatEntry      `json:"connections,omitempty"`
+	UnixSockets []NetstatUnixSocket `json:"unix_sockets,omitempty"`
+	Routes      []NetstatRoute      `json:"routes,omitempty"`
+	Interfaces  []NetstatInterface  `json:"interfaces,omitempty"`
+}
+
+// NetstatStatistics holds netstat -s counters by protocol section
+// ("Ip", "Tcp", "TcpExt", ...). Counters are keyed by their description
+// with the number left out, e.g. "total packets received". Histograms such
+// as "ICMP input histogram" are nested maps.
+type NetstatStatistics map[string]map[string]interface{}
+
 func (p *NetstatParser) Name() string {
 	return "netstat"
 }
@@ -31,74 +107,378 @@ func (p *NetstatParser) Parse(input string) (interface{}, error) {
 		return nil, fmt.Errorf("empty input")
 	}
 
+	sectionRe := regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*:$`)
 	lines := splitLines(input)
+	if sectionRe.MatchString(lines[0]) && !strings.HasPrefix(lines[0], "Internet") {
+		return parseNetstatStatistics(input), nil
+	}
+
+	unixRe := regexp.MustCompile(`^(unix)\s+(\d+)\s+\[([^\]]*)\]\s+(\S+)\s+(?:([A-Z_]+)\s+)?(\d+)(?:\s+(\d+/\S+|-))?(?:\s+(.*))?$`)
+
+	var output NetstatOutput
 	var entries []NetstatEntry
+	var mode, family string
+	var columns []string
+	var starts []int
 
 	for _, line := range lines {
-		// Skip header lines and empty lines
-		if strings.HasPrefix(line, "Active") || 
-		   strings.HasPrefix(line, "Proto") ||
-		   strings.Contains(line, "Local Address") ||
-		   strings.TrimSpace(line) == "" {
+		fields := splitFields(line)
+
+		switch {
+		case strings.HasPrefix(line, "Active UNIX"):
+			mode = "unix"
+			continue
+		case strings.HasPrefix(line, "Active"):
+			mode = ""
+			continue
+		case strings.HasPrefix(line, "Kernel IP routing") || line == "Routing tables":
+			mode = "route"
+			continue
+		case strings.HasPrefix(line, "Kernel Interface"):
+			mode = "interface"
+			continue
+		case mode == "route" && sectionRe.MatchString(line):
+			family = strings.TrimSuffix(line, ":")
+			continue
+		case fields[0] == "Destination":
+			mode, columns = "route", fields
+			starts = columnStarts(line, columns)
+			continue
+		case (fields[0] == "Iface" || fields[0] == "Name") && len(fields) > 1 && strings.EqualFold(fields[1], "MTU"):
+			mode, columns = "interface", fields
+			starts = columnStarts(line, columns)
+			continue
+		case fields[0] == "Proto" || strings.Contains(line, "Local Address"):
 			continue
 		}
 
-		fields := splitFields(line)
-		if len(fields) < 4 {
-			continue
+		switch {
+		case fields[0] == "unix":
+			if m := unixRe.FindStringSubmatch(line); m != nil {
+				output.UnixSockets = append(output.UnixSockets, parseNetstatUnixSocket(m))
+			}
+		case mode == "route":
+			route := parseNetstatRoute(line, columns, starts)
+			route.Family = family
+			output.Routes = append(output.Routes, route)
+		case mode == "interface":
+			output.Interfaces = append(output.Interfaces, parseNetstatInterface(line, columns, starts))
+		case mode == "unix":
+			// BSD lists UNIX domain sockets by kernel address; not supported
+		default:
+			if entry, ok := parseNetstatConnection(fields); ok {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	output.Connections = entries
+	tables := 0
+	for _, n := range []int{len(output.UnixSockets), len(output.Routes), len(output.Interfaces)} {
+		if n > 0 {
+			tables++
 		}
+	}
+	switch {
+	case tables == 0:
+		return entries, nil
+	case len(entries) > 0 || tables > 1:
+		return output, nil
+	case output.Routes != nil:
+		return output.Routes, nil
+	case output.Interfaces != nil:
+		return output.Interfaces, nil
+	default:
+		return output.UnixSockets, nil
+	}
+}
+
+// parseNetstatConnection parses a row of the Internet socket table
+func parseNetstatConnection(fields []string) (NetstatEntry, bool) {
+	if len(fields) < 4 {
+		return NetstatEntry{}, false
+	}
 
-		entry := NetstatEntry{}
-		entry.Protocol = fields[0]
+	entry := NetstatEntry{}
+	entry.Protocol = fields[0]
 
-		fieldIndex := 1
+	fieldIndex := 1
+
+	// Check if RecvQ and SendQ are present
+	if len(fields) >= 6 && isNumeric(fields[1]) {
+		if recvQ, err := strconv.Atoi(fields[1]); err == nil {
+			entry.RecvQ = recvQ
+		}
+		if sendQ, err := strconv.Atoi(fields[2]); err == nil {
+			entry.SendQ = sendQ
+		}
+		fieldIndex = 3
+	}
+
+	// Local and Foreign addresses
+	if fieldIndex < len(fields) {
+		entry.LocalAddress = fields[fieldIndex]
+		entry.LocalHost, entry.LocalPort = splitNetstatAddress(entry.LocalAddress)
+	}
+	if fieldIndex+1 < len(fields) {
+		entry.ForeignAddress = fields[fieldIndex+1]
+		entry.ForeignHost, entry.ForeignPort = splitNetstatAddress(entry.ForeignAddress)
+	}
+
+	// State; connected UDP sockets show one too
+	if fieldIndex+2 < len(fields) {
+		possibleState := fields[fieldIndex+2]
+		if isNetstatState(possibleState) {
+			entry.State = possibleState
+			fieldIndex++
+		}
+	}
+
+	// PID/Program (if present)
+	if fieldIndex+2 < len(fields) {
+		entry.PID, entry.Program = splitNetstatProgram(fields[fieldIndex+2])
+	}
+
+	return entry, true
+}
 
-		// Check if RecvQ and SendQ are present
-		if len(fields) >= 6 && isNumeric(fields[1]) {
-			if recvQ, err := strconv.Atoi(fields[1]); err == nil {
-				entry.RecvQ = recvQ
+// splitNetstatAddress splits a socket address into host and port. Linux
+// separates the port with a colon (192.168.1.1:22, :::22, 0.0.0.0:*), BSD
+// with a dot (192.168.1.1.22, fe80::1%lo0.123, *.*); whichever separator
+// comes last is the one.
+func splitNetstatAddress(address string) (string, string) {
+	i := max(strings.LastIndex(address, ":"), strings.LastIndex(address, "."))
+	if i <= 0 {
+		return address, ""
+	}
+	return address[:i], address[i+1:]
+}
+
+// splitNetstatProgram splits a PID/Program name column; "-" means the
+// process is unknown
+func splitNetstatProgram(value string) (int, string) {
+	pidText, program, found := strings.Cut(value, "/")
+	if !found {
+		return 0, ""
+	}
+	pid, err := strconv.Atoi(pidText)
+	if err != nil {
+		return 0, ""
+	}
+	return pid, program
+}
+
+// parseNetstatUnixSocket builds a NetstatUnixSocket from the fields matched
+// in a row of the UNIX domain socket section
+func parseNetstatUnixSocket(m []string) NetstatUnixSocket {
+	socket := NetstatUnixSocket{
+		Protocol: m[1],
+		Flags:    strings.Fields(m[3]),
+		Type:     m[4],
+		State:    m[5],
+		Path:     strings.TrimSpace(m[8]),
+	}
+	socket.RefCnt, _ = strconv.Atoi(m[2])
+	socket.Inode, _ = strconv.ParseInt(m[6], 10, 64)
+	socket.PID, socket.Program = splitNetstatProgram(m[7])
+	if socket.Flags == nil {
+		socket.Flags = []string{}
+	}
+	return socket
+}
+
+// netstatRow splits a table row into values under the header columns.
+// Blank cells are only recognised by position, so rows with fewer values
+// than columns are aligned under the header.
+func netstatRow(line string, columns []string, starts []int) []string {
+	fields := splitFields(line)
+	if len(fields) == len(columns) || starts == nil {
+		return fields
+	}
+	return alignFields(line, columns, starts)
+}
+
+// parseNetstatRoute parses a routing table row. Without a header the Linux
+// netstat -rn columns are assumed.
+func parseNetstatRoute(line string, columns []string, starts []int) NetstatRoute {
+	if columns == nil {
+		columns = []string{"Destination", "Gateway", "Genmask", "Flags", "MSS", "Window", "irtt", "Iface"}
+	}
+	values := netstatRow(line, columns, starts)
+
+	route := NetstatRoute{}
+	for i, column := range columns {
+		if i >= len(values) || values[i] == "" {
+			continue
+		}
+		value := values[i]
+		switch strings.ToLower(column) {
+		case "destination":
+			route.Destination = value
+		case "gateway":
+			route.Gateway = value
+		case "genmask":
+			route.Genmask = value
+		case "flags":
+			route.Flags = value
+		case "metric":
+			route.Metric = netstatInt(value)
+		case "ref", "refs":
+			route.Ref = netstatInt(value)
+		case "use":
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				route.Use = &n
 			}
-			if sendQ, err := strconv.Atoi(fields[2]); err == nil {
-				entry.SendQ = sendQ
+		case "mss":
+			route.MSS = netstatInt(value)
+		case "window":
+			route.Window = netstatInt(value)
+		case "irtt":
+			route.IRTT = netstatInt(value)
+		case "iface", "netif":
+			route.Interface = value
+		case "expire":
+			route.Expire = value
+		}
+	}
+	return route
+}
+
+// parseNetstatInterface parses a row of netstat -i. Linux and BSD name the
+// counters differently (RX-OK, Ipkts); both map to the same fields.
+func parseNetstatInterface(line string, columns []string, starts []int) NetstatInterface {
+	values := netstatRow(line, columns, starts)
+
+	iface := NetstatInterface{}
+	for i, column := range columns {
+		if i >= len(values) || values[i] == "" {
+			continue
+		}
+		value := values[i]
+		var counter **int64
+		switch strings.ToLower(column) {
+		case "iface", "name":
+			iface.Interface = value
+		case "mtu":
+			iface.MTU, _ = strconv.Atoi(value)
+		case "met":
+			iface.Metric = netstatInt(value)
+		case "network":
+			iface.Network = value
+		case "address":
+			iface.Address = value
+		case "flg":
+			iface.Flags = value
+		case "rx-ok", "ipkts":
+			counter = &iface.RXPackets
+		case "rx-err", "ierrs":
+			counter = &iface.RXErrors
+		case "rx-drp", "idrop":
+			counter = &iface.RXDropped
+		case "rx-ovr":
+			counter = &iface.RXOverruns
+		case "tx-ok", "opkts":
+			counter = &iface.TXPackets
+		case "tx-err", "oerrs":
+			counter = &iface.TXErrors
+		case "tx-drp", "odrop":
+			counter = &iface.TXDropped
+		case "tx-ovr":
+			counter = &iface.TXOverruns
+		case "coll":
+			counter = &iface.Collisions
+		}
+		if counter != nil {
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				*counter = &n
 			}
-			fieldIndex = 3
 		}
+	}
+	return iface
+}
+
+// parseNetstatStatistics parses netstat -s. Sections start at the left
+// margin; counters are indented below them, either "Key: value" or a
+// number within a description ("7740 total packets received", "Quick ack
+// mode was activated 3 times"). An indented line ending in a colon starts a
+// nested group for the lines indented further below it.
+func parseNetstatStatistics(input string) NetstatStatistics {
+	stats := NetstatStatistics{}
+	var section map[string]interface{}
+	var group map[string]interface{}
+	var groupName string
+	groupIndent := -1
+
+	for _, raw := range strings.Split(input, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		indent := len(raw) - len(strings.TrimLeft(raw, " \t"))
 
-		// Local and Foreign addresses
-		if fieldIndex < len(fields) {
-			entry.LocalAddress = fields[fieldIndex]
+		if indent == 0 {
+			name := strings.TrimSuffix(line, ":")
+			section = map[string]interface{}{}
+			stats[name] = section
+			group, groupIndent = nil, -1
+			continue
 		}
-		if fieldIndex+1 < len(fields) {
-			entry.ForeignAddress = fields[fieldIndex+1]
+		if section == nil {
+			continue
 		}
 
-		// State (for TCP connections)
-		if fieldIndex+2 < len(fields) && entry.Protocol == "tcp" {
-			possibleState := fields[fieldIndex+2]
-			if isNetstatState(possibleState) {
-				entry.State = possibleState
-				fieldIndex++
-			}
+		if groupIndent >= 0 && indent <= groupIndent {
+			group, groupIndent = nil, -1
+		}
+		if strings.HasSuffix(line, ":") {
+			groupName, groupIndent = strings.TrimSuffix(line, ":"), indent
+			group = nil
+			continue
 		}
 
-		// PID/Program (if present)
-		if fieldIndex+2 < len(fields) {
-			pidProgram := fields[fieldIndex+2]
-			if strings.Contains(pidProgram, "/") {
-				parts := strings.SplitN(pidProgram, "/", 2)
-				if len(parts) == 2 {
-					if pid, err := strconv.Atoi(parts[0]); err == nil {
-						entry.PID = pid
-					}
-					entry.Program = parts[1]
-				}
+		key, value := parseNetstatCounter(line)
+		if groupIndent >= 0 {
+			if group == nil {
+				group = map[string]interface{}{}
+				section[groupName] = group
 			}
+			group[key] = value
+		} else {
+			section[key] = value
 		}
+	}
 
-		entries = append(entries, entry)
+	return stats
+}
+
+// parseNetstatCounter splits a netstat -s line into its description and
+// value
+func parseNetstatCounter(line string) (string, interface{}) {
+	if key, value, found := strings.Cut(line, ": "); found {
+		value = strings.TrimSpace(value)
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return key, n
+		}
+		return key, value
 	}
 
-	return entries, nil
+	fields := strings.Fields(line)
+	for i, field := range fields {
+		if n, err := strconv.ParseInt(field, 10, 64); err == nil {
+			rest := append(append([]string{}, fields[:i]...), fields[i+1:]...)
+			return strings.Join(rest, " "), n
+		}
+	}
+	return line, true
+}
+
+// netstatInt parses an optional integer column
+func netstatInt(value string) *int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil
+	}
+	return &n
 }
 
 func isNumeric(s string) bool {
@@ -106,11 +486,14 @@ func isNumeric(s string) bool {
 	return err == nil
 }
 
+// isNetstatState reports whether s is a socket state, as printed by Linux
+// or BSD netstat
 func isNetstatState(s string) bool {
 	states := []string{
 		"ESTABLISHED", "SYN_SENT", "SYN_RECV", "FIN_WAIT1", "FIN_WAIT2",
 		"TIME_WAIT", "CLOSE", "CLOSE_WAIT", "LAST_ACK", "LISTEN",
-		"CLOSING", "UNKNOWN",
+		"CLOSING", "UNKNOWN", "SYN_RCVD", "FIN_WAIT_1", "FIN_WAIT_2",
+		"CLOSED",
 	}
 
 	for _, state := range states {
@@ -150,3 +150,228 @@ func TestNetstatParserEmpty(t *testing.T) {
 		t.Errorf("Expected 0 entries, got %d", len(entries))
 	}
 }
+
+func TestNetstatParserAddresses(t *testing.T) {
+	parser := &NetstatParser{}
+
+	testInput := `Proto Recv-Q Send-Q Local Address           Foreign Address         State
+tcp6       0      0 :::22                   :::*                    LISTEN
+tcp6       0      0 fe80::1:22              fe80::2:51234           ESTABLISHED
+udp        0      0 10.0.0.5:68             10.0.0.1:67             ESTABLISHED
+tcp4       0      0  192.168.1.5.52345      17.57.146.20.443       ESTABLISHED
+tcp46      0      0  *.80                   *.*                    LISTEN`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]NetstatEntry)
+	if len(entries) != 5 {
+		t.Fatalf("Expected 5 entries, got %d", len(entries))
+	}
+
+	tests := []struct {
+		localHost, localPort, foreignHost, foreignPort, state string
+	}{
+		{"::", "22", "::", "*", "LISTEN"},
+		{"fe80::1", "22", "fe80::2", "51234", "ESTABLISHED"},
+		{"10.0.0.5", "68", "10.0.0.1", "67", "ESTABLISHED"},
+		{"192.168.1.5", "52345", "17.57.146.20", "443", "ESTABLISHED"},
+		{"*", "80", "*", "*", "LISTEN"},
+	}
+	for i, want := range tests {
+		got := entries[i]
+		if got.LocalHost != want.localHost || got.LocalPort != want.localPort ||
+			got.ForeignHost != want.foreignHost || got.ForeignPort != want.foreignPort ||
+			got.State != want.state {
+			t.Errorf("Entry %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+}
+
+func TestNetstatParserUnixSockets(t *testing.T) {
+	parser := &NetstatParser{}
+
+	testInput := `Active Internet connections (servers and established)
+Proto Recv-Q Send-Q Local Address           Foreign Address         State       PID/Program name
+tcp        0      0 0.0.0.0:22              0.0.0.0:*               LISTEN      812/sshd
+Active UNIX domain sockets (servers and established)
+Proto RefCnt Flags       Type       State         I-Node   PID/Program name     Path
+unix  2      [ ACC ]     STREAM     LISTENING     20787    1/systemd            /run/systemd/private
+unix  3      [ ]         STREAM     CONNECTED     23456    -
+unix  2      [ ]         DGRAM                    12345    402/systemd-journal  /run/systemd/journal/dev-log`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	output, ok := result.(NetstatOutput)
+	if !ok {
+		t.Fatalf("Expected NetstatOutput, got %T", result)
+	}
+	if len(output.Connections) != 1 || output.Connections[0].Program != "sshd" {
+		t.Errorf("Unexpected connections %+v", output.Connections)
+	}
+	if len(output.UnixSockets) != 3 {
+		t.Fatalf("Expected 3 unix sockets, got %d", len(output.UnixSockets))
+	}
+
+	listening := output.UnixSockets[0]
+	if listening.RefCnt != 2 || len(listening.Flags) != 1 || listening.Flags[0] != "ACC" ||
+		listening.Type != "STREAM" || listening.State != "LISTENING" || listening.Inode != 20787 ||
+		listening.PID != 1 || listening.Program != "systemd" || listening.Path != "/run/systemd/private" {
+		t.Errorf("Unexpected socket %+v", listening)
+	}
+	if connected := output.UnixSockets[1]; connected.PID != 0 || connected.Path != "" || len(connected.Flags) != 0 {
+		t.Errorf("Unexpected socket %+v", connected)
+	}
+	if dgram := output.UnixSockets[2]; dgram.Type != "DGRAM" || dgram.State != "" || dgram.Inode != 12345 || dgram.Path != "/run/systemd/journal/dev-log" {
+		t.Errorf("Unexpected socket %+v", dgram)
+	}
+}
+
+func TestNetstatParserRoutes(t *testing.T) {
+	parser := &NetstatParser{}
+
+	testInput := `Kernel IP routing table
+Destination     Gateway         Genmask         Flags Metric Ref    Use Iface
+0.0.0.0         192.168.1.1     0.0.0.0         UG    100    0        0 eth0
+192.168.1.0     0.0.0.0         255.255.255.0   U     100    0        0 eth0`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	routes, ok := result.([]NetstatRoute)
+	if !ok {
+		t.Fatalf("Expected []NetstatRoute, got %T", result)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("Expected 2 routes, got %d", len(routes))
+	}
+	if routes[0].Destination != "0.0.0.0" || routes[0].Gateway != "192.168.1.1" || routes[0].Flags != "UG" ||
+		routes[0].Metric == nil || *routes[0].Metric != 100 || routes[0].Interface != "eth0" {
+		t.Errorf("Unexpected route %+v", routes[0])
+	}
+	if routes[1].Genmask != "255.255.255.0" {
+		t.Errorf("Expected genmask 255.255.255.0, got %q", routes[1].Genmask)
+	}
+
+	// BSD netstat -rn, with a blank Expire column
+	testInput = `Routing tables
+
+Internet:
+Destination        Gateway            Flags        Netif Expire
+default            192.168.1.1        UGScg          en0
+192.168.1.1        0:11:22:33:44:55   UHLWIir        en0   1184`
+
+	result, err = parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	routes = result.([]NetstatRoute)
+	if len(routes) != 2 {
+		t.Fatalf("Expected 2 routes, got %d", len(routes))
+	}
+	if routes[0].Family != "Internet" || routes[0].Destination != "default" || routes[0].Interface != "en0" || routes[0].Expire != "" {
+		t.Errorf("Unexpected route %+v", routes[0])
+	}
+	if routes[1].Expire != "1184" {
+		t.Errorf("Expected expire 1184, got %q", routes[1].Expire)
+	}
+}
+
+func TestNetstatParserInterfaces(t *testing.T) {
+	parser := &NetstatParser{}
+
+	testInput := `Kernel Interface table
+Iface             MTU    RX-OK RX-ERR RX-DRP RX-OVR    TX-OK TX-ERR TX-DRP TX-OVR Flg
+eth0             1500   123456      0     12 0         98765      0      0      0 BMRU
+lo              65536     7558      0      0 0          7558      0      0      0 LRU`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	interfaces, ok := result.([]NetstatInterface)
+	if !ok {
+		t.Fatalf("Expected []NetstatInterface, got %T", result)
+	}
+	if len(interfaces) != 2 {
+		t.Fatalf("Expected 2 interfaces, got %d", len(interfaces))
+	}
+	eth0 := interfaces[0]
+	if eth0.Interface != "eth0" || eth0.MTU != 1500 || eth0.Flags != "BMRU" ||
+		eth0.RXPackets == nil || *eth0.RXPackets != 123456 ||
+		eth0.RXDropped == nil || *eth0.RXDropped != 12 ||
+		eth0.TXPackets == nil || *eth0.TXPackets != 98765 {
+		t.Errorf("Unexpected interface %+v", eth0)
+	}
+
+	// BSD netstat -i, where the link row has no address
+	testInput = `Name  Mtu   Network       Address            Ipkts Ierrs    Opkts Oerrs  Coll
+lo0   16384 <Link#1>                          5678     0     5678     0     0
+en0   1500  192.168.1     192.168.1.5        12345     0     6789     0     0`
+
+	result, err = parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	interfaces = result.([]NetstatInterface)
+	if len(interfaces) != 2 {
+		t.Fatalf("Expected 2 interfaces, got %d", len(interfaces))
+	}
+	if interfaces[0].Network != "<Link#1>" || interfaces[0].Address != "" || *interfaces[0].RXPackets != 5678 {
+		t.Errorf("Unexpected interface %+v", interfaces[0])
+	}
+	if interfaces[1].Address != "192.168.1.5" || interfaces[1].Collisions == nil || *interfaces[1].Collisions != 0 {
+		t.Errorf("Unexpected interface %+v", interfaces[1])
+	}
+}
+
+func TestNetstatParserStatistics(t *testing.T) {
+	parser := &NetstatParser{}
+
+	testInput := `Ip:
+    Forwarding: 2
+    7740 total packets received
+    0 forwarded
+Icmp:
+    12 ICMP messages received
+    ICMP input histogram:
+        destination unreachable: 10
+        echo requests: 2
+    5 ICMP messages sent
+TcpExt:
+    Quick ack mode was activated 3 times
+    TCPPureAcks: 123`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	stats, ok := result.(NetstatStatistics)
+	if !ok {
+		t.Fatalf("Expected NetstatStatistics, got %T", result)
+	}
+	if stats["Ip"]["Forwarding"] != int64(2) || stats["Ip"]["total packets received"] != int64(7740) {
+		t.Errorf("Unexpected Ip section %v", stats["Ip"])
+	}
+	histogram, ok := stats["Icmp"]["ICMP input histogram"].(map[string]interface{})
+	if !ok || histogram["destination unreachable"] != int64(10) || histogram["echo requests"] != int64(2) {
+		t.Errorf("Unexpected histogram %v", stats["Icmp"]["ICMP input histogram"])
+	}
+	if stats["Icmp"]["ICMP messages sent"] != int64(5) {
+		t.Errorf("Expected counter after the histogram in the section, got %v", stats["Icmp"])
+	}
+	if stats["TcpExt"]["Quick ack mode was activated times"] != int64(3) || stats["TcpExt"]["TCPPureAcks"] != int64(123) {
+		t.Errorf("Unexpected TcpExt section %v", stats["TcpExt"])
+	}
+}
@@ -0,0 +1,96 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NsswitchParser parses /etc/nsswitch.conf
+type NsswitchParser struct{}
+
+// NsswitchEntry represents a database line such as
+// "hosts: files mdns4_minimal [NOTFOUND=return] dns"
+type NsswitchEntry struct {
+	Database string           `json:"database"`
+	Sources  []NsswitchSource `json:"sources"`
+	Comment  string           `json:"comment,omitempty"`
+	Original string           `json:"original"`
+}
+
+// NsswitchSource is a service in lookup order. Actions are the
+// [STATUS=action] criteria following it, e.g. NOTFOUND: return; negated
+// criteria keep their "!" prefix.
+type NsswitchSource struct {
+	Name    string            `json:"name"`
+	Actions map[string]string `json:"actions,omitempty"`
+}
+
+func (p *NsswitchParser) Name() string {
+	return "nsswitch"
+}
+
+func (p *NsswitchParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	entries := []NsswitchEntry{}
+
+	for _, line := range splitLines(input) {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entry := NsswitchEntry{
+			Original: line,
+		}
+		if i := strings.Index(line, "#"); i != -1 {
+			entry.Comment = strings.TrimSpace(line[i+1:])
+			line = line[:i]
+		}
+
+		database, services, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		entry.Database = strings.TrimSpace(database)
+		entry.Sources = []NsswitchSource{}
+
+		// Criteria may contain spaces inside the brackets
+		rest := strings.TrimSpace(services)
+		for rest != "" {
+			if strings.HasPrefix(rest, "[") {
+				end := strings.Index(rest, "]")
+				if end == -1 {
+					end = len(rest) - 1
+				}
+				if len(entry.Sources) > 0 {
+					source := &entry.Sources[len(entry.Sources)-1]
+					if source.Actions == nil {
+						source.Actions = map[string]string{}
+					}
+					for _, criterion := range strings.Fields(rest[1:end]) {
+						status, action, _ := strings.Cut(criterion, "=")
+						source.Actions[strings.ToUpper(status)] = strings.ToLower(action)
+					}
+				}
+				rest = strings.TrimSpace(rest[end+1:])
+				continue
+			}
+
+			name, remainder, _ := strings.Cut(rest, " ")
+			if i := strings.Index(name, "["); i > 0 {
+				name, remainder = name[:i], rest[i:]
+			}
+			entry.Sources = append(entry.Sources, NsswitchSource{
+				Name: name,
+			})
+			rest = strings.TrimSpace(remainder)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,60 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestNsswitchParser(t *testing.T) {
+	parser := &NsswitchParser{}
+
+	testInput := `# /etc/nsswitch.conf
+passwd:         files systemd
+group:          files [SUCCESS=merge] systemd
+hosts:          files mdns4_minimal [NOTFOUND=return] dns myhostname # local first
+networks:       files
+netgroup:       nis[!UNAVAIL=return notfound=Continue] files`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]NsswitchEntry)
+	if !ok {
+		t.Fatalf("Expected []NsswitchEntry, got %T", result)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("Expected 5 entries, got %d", len(entries))
+	}
+
+	if entries[0].Database != "passwd" || len(entries[0].Sources) != 2 || entries[0].Sources[1].Name != "systemd" {
+		t.Errorf("Unexpected passwd entry %+v", entries[0])
+	}
+
+	hosts := entries[2]
+	if len(hosts.Sources) != 4 || hosts.Sources[1].Name != "mdns4_minimal" {
+		t.Fatalf("Unexpected hosts sources %+v", hosts.Sources)
+	}
+	if hosts.Sources[1].Actions["NOTFOUND"] != "return" || hosts.Sources[0].Actions != nil {
+		t.Errorf("Unexpected hosts actions %+v", hosts.Sources)
+	}
+	if hosts.Comment != "local first" {
+		t.Errorf("Expected comment 'local first', got %q", hosts.Comment)
+	}
+
+	netgroup := entries[4]
+	if len(netgroup.Sources) != 2 || netgroup.Sources[0].Name != "nis" {
+		t.Fatalf("Unexpected netgroup sources %+v", netgroup.Sources)
+	}
+	if netgroup.Sources[0].Actions["!UNAVAIL"] != "return" || netgroup.Sources[0].Actions["NOTFOUND"] != "continue" {
+		t.Errorf("Unexpected netgroup actions %+v", netgroup.Sources[0].Actions)
+	}
+}
+
+func TestNsswitchParserEmpty(t *testing.T) {
+	parser := &NsswitchParser{}
+
+	if _, err := parser.Parse(""); err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
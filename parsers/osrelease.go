@@ -0,0 +1,119 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OsReleaseParser parses /etc/os-release (or /usr/lib/os-release)
+type OsReleaseParser struct{}
+
+// OsRelease is the operating system identification. IDLike lists the
+// distributions this one is derived from, closest first. Keys without a
+// field of their own are kept in Extra.
+type OsRelease struct {
+	Name             string            `json:"name,omitempty"`
+	ID               string            `json:"id,omitempty"`
+	IDLike           []string          `json:"id_like,omitempty"`
+	PrettyName       string            `json:"pretty_name,omitempty"`
+	Version          string            `json:"version,omitempty"`
+	VersionID        string            `json:"version_id,omitempty"`
+	VersionCodename  string            `json:"version_codename,omitempty"`
+	BuildID          string            `json:"build_id,omitempty"`
+	Variant          string            `json:"variant,omitempty"`
+	VariantID        string            `json:"variant_id,omitempty"`
+	CPEName          string            `json:"cpe_name,omitempty"`
+	HomeURL          string            `json:"home_url,omitempty"`
+	SupportURL       string            `json:"support_url,omitempty"`
+	BugReportURL     string            `json:"bug_report_url,omitempty"`
+	PrivacyPolicyURL string            `json:"privacy_policy_url,omitempty"`
+	SupportEnd       string            `json:"support_end,omitempty"`
+	Extra            map[string]string `json:"extra,omitempty"`
+}
+
+func (p *OsReleaseParser) Name() string {
+	return "osrelease"
+}
+
+func (p *OsReleaseParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	release := OsRelease{}
+
+	for _, line := range splitLines(input) {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		value = unquoteOsReleaseValue(value)
+
+		switch key {
+		case "NAME":
+			release.Name = value
+		case "ID":
+			release.ID = value
+		case "ID_LIKE":
+			release.IDLike = strings.Fields(value)
+		case "PRETTY_NAME":
+			release.PrettyName = value
+		case "VERSION":
+			release.Version = value
+		case "VERSION_ID":
+			release.VersionID = value
+		case "VERSION_CODENAME":
+			release.VersionCodename = value
+		case "BUILD_ID":
+			release.BuildID = value
+		case "VARIANT":
+			release.Variant = value
+		case "VARIANT_ID":
+			release.VariantID = value
+		case "CPE_NAME":
+			release.CPEName = value
+		case "HOME_URL":
+			release.HomeURL = value
+		case "SUPPORT_URL":
+			release.SupportURL = value
+		case "BUG_REPORT_URL":
+			release.BugReportURL = value
+		case "PRIVACY_POLICY_URL":
+			release.PrivacyPolicyURL = value
+		case "SUPPORT_END":
+			release.SupportEnd = value
+		default:
+			if release.Extra == nil {
+				release.Extra = map[string]string{}
+			}
+			release.Extra[key] = value
+		}
+	}
+
+	return release, nil
+}
+
+// unquoteOsReleaseValue removes shell quoting from a value. Double quoted
+// values may escape $, ", \ and ` with a backslash.
+func unquoteOsReleaseValue(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1]
+	}
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return value
+	}
+
+	var b strings.Builder
+	inner := value[1 : len(value)-1]
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' && i+1 < len(inner) && strings.IndexByte("$\"\\`", inner[i+1]) != -1 {
+			i++
+		}
+		b.WriteByte(inner[i])
+	}
+	return b.String()
+}
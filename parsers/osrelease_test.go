@@ -0,0 +1,57 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestOsReleaseParser(t *testing.T) {
+	parser := &OsReleaseParser{}
+
+	testInput := `# Managed by the image build
+NAME="Ubuntu"
+VERSION="22.04.3 LTS (Jammy Jellyfish)"
+ID=ubuntu
+ID_LIKE=debian
+PRETTY_NAME="Ubuntu 22.04.3 LTS"
+VERSION_ID="22.04"
+HOME_URL="https://www.ubuntu.com/"
+VERSION_CODENAME=jammy
+UBUNTU_CODENAME=jammy
+LOGO='ubuntu-logo'
+VARIANT="Say \"hi\" \\ \$HOME"`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	release, ok := result.(OsRelease)
+	if !ok {
+		t.Fatalf("Expected OsRelease, got %T", result)
+	}
+
+	if release.Name != "Ubuntu" || release.ID != "ubuntu" || release.VersionID != "22.04" || release.VersionCodename != "jammy" {
+		t.Errorf("Unexpected release %+v", release)
+	}
+	if len(release.IDLike) != 1 || release.IDLike[0] != "debian" {
+		t.Errorf("Expected id_like [debian], got %v", release.IDLike)
+	}
+	if release.PrettyName != "Ubuntu 22.04.3 LTS" || release.HomeURL != "https://www.ubuntu.com/" {
+		t.Errorf("Unexpected release %+v", release)
+	}
+	if release.Variant != `Say "hi" \ $HOME` {
+		t.Errorf("Expected escapes removed, got %q", release.Variant)
+	}
+	if release.Extra["UBUNTU_CODENAME"] != "jammy" || release.Extra["LOGO"] != "ubuntu-logo" {
+		t.Errorf("Unexpected extra %v", release.Extra)
+	}
+}
+
+func TestOsReleaseParserEmpty(t *testing.T) {
+	parser := &OsReleaseParser{}
+
+	_, err := parser.Parse("")
+	if err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
@@ -0,0 +1,83 @@
+package parsers
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// OUITable maps IEEE organizationally unique identifiers (the first three
+// octets of a MAC address) to vendor names
+type OUITable struct {
+	vendors map[string]string
+}
+
+// NewOUITable builds an OUITable from the IEEE oui.txt or oui.csv
+// registry, or a Wireshark style manuf file ("00:00:0C<tab>Cisco").
+// The first entry wins when a prefix repeats.
+func NewOUITable(data string) *OUITable {
+	// oui.txt: "00-00-0C   (hex)		Cisco Systems, Inc" and
+	// "00000C     (base 16)		Cisco Systems, Inc"
+	hexRe := regexp.MustCompile(`^([0-9A-Fa-f]{2})[-:]([0-9A-Fa-f]{2})[-:]([0-9A-Fa-f]{2})(?:\s+\(hex\))?\s+(.+)$`)
+	base16Re := regexp.MustCompile(`^([0-9A-Fa-f]{6})\s+\(base 16\)\s+(.+)$`)
+	// oui.csv: "MA-L,00000C,Cisco Systems, Inc,..." with quoted fields
+	csvRe := regexp.MustCompile(`^MA-L,([0-9A-Fa-f]{6}),("(?:[^"]|"")*"|[^,]*)`)
+
+	table := &OUITable{
+		vendors: map[string]string{},
+	}
+	add := func(prefix, vendor string) {
+		prefix = strings.ToLower(prefix)
+		vendor = strings.TrimSpace(vendor)
+		if _, exists := table.vendors[prefix]; !exists && vendor != "" {
+			table.vendors[prefix] = vendor
+		}
+	}
+
+	for _, line := range splitLines(data) {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := hexRe.FindStringSubmatch(line); m != nil {
+			// manuf files give a short name, then the full name after a tab
+			vendor := m[4]
+			if short, full, found := strings.Cut(vendor, "\t"); found && !strings.Contains(line, "(hex)") {
+				vendor = strings.TrimSpace(full)
+				if vendor == "" {
+					vendor = short
+				}
+			}
+			add(m[1]+m[2]+m[3], vendor)
+		} else if m := base16Re.FindStringSubmatch(line); m != nil {
+			add(m[1], m[2])
+		} else if m := csvRe.FindStringSubmatch(line); m != nil {
+			vendor := m[2]
+			if strings.HasPrefix(vendor, `"`) {
+				vendor = strings.ReplaceAll(strings.Trim(vendor, `"`), `""`, `"`)
+			}
+			add(m[1], vendor)
+		}
+	}
+
+	return table
+}
+
+// LoadOUITable reads an OUI registry file and builds an OUITable
+func LoadOUITable(path string) (*OUITable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading OUI file: %w", err)
+	}
+	return NewOUITable(string(data)), nil
+}
+
+// Vendor returns the vendor registered for a MAC address
+func (t *OUITable) Vendor(mac string) (string, bool) {
+	digits := strings.ReplaceAll(normalizeMAC(mac), ":", "")
+	if len(digits) < 6 {
+		return "", false
+	}
+	vendor, ok := t.vendors[digits[:6]]
+	return vendor, ok
+}
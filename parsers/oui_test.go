@@ -0,0 +1,28 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestOUITableFormats(t *testing.T) {
+	table := NewOUITable(`# manuf
+00:00:0C	Cisco	Cisco Systems, Inc
+00:1B:C5:00:00:00/36	Converging	Converging Systems Inc.
+MA-L,3C5AB4,Google, Inc.,1600 Amphitheatre Parkway
+MA-L,F0D1A9,"Apple, Inc.",1 Infinite Loop`)
+
+	tests := map[string]string{
+		"00:00:0c:12:34:56": "Cisco Systems, Inc",
+		"3c:5a:b4:00:00:01": "Google",
+		"f0-d1-a9-00-00-01": "Apple, Inc.",
+	}
+	for mac, want := range tests {
+		if got, ok := table.Vendor(mac); !ok || got != want {
+			t.Errorf("Vendor(%q) = %q, want %q", mac, got, want)
+		}
+	}
+
+	if _, ok := table.Vendor("00:1b:c5:00:00:01"); ok {
+		t.Error("Expected no vendor for a /36 block")
+	}
+}
@@ -2,7 +2,11 @@ package parsers
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // Parser defines the interface for all command line parsers
@@ -13,11 +17,26 @@ type Parser interface {
 
 // Parse is the main entry point for parsing command line output
 func Parse(parserName, input string) (interface{}, error) {
+	parser, err := NewParser(parserName)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseWith(parser, input)
+}
+
+// ParseWith parses command line output with an already configured parser
+func ParseWith(parser Parser, input string) (interface{}, error) {
 	input = strings.TrimSpace(input)
 	if input == "" {
 		return nil, fmt.Errorf("empty input")
 	}
 
+	return parser.Parse(input)
+}
+
+// NewParser returns the parser registered under the given name
+func NewParser(parserName string) (Parser, error) {
 	var parser Parser
 	switch parserName {
 	case "ls":
@@ -30,6 +49,12 @@ func Parse(parserName, input string) (interface{}, error) {
 		parser = &MountParser{}
 	case "lsblk":
 		parser = &LsblkParser{}
+	case "blkid":
+		parser = &BlkidParser{}
+	case "fdisk":
+		parser = &FdiskParser{}
+	case "parted":
+		parser = &PartedParser{}
 	case "du":
 		parser = &DuParser{}
 	case "find":
@@ -38,6 +63,12 @@ func Parse(parserName, input string) (interface{}, error) {
 		parser = &StatParser{}
 	case "uname":
 		parser = &UnameParser{}
+	case "osrelease":
+		parser = &OsReleaseParser{}
+	case "lsbrelease":
+		parser = &LsbReleaseParser{}
+	case "hostnamectl":
+		parser = &HostnamectlParser{}
 	case "uptime":
 		parser = &UptimeParser{}
 	case "who":
@@ -62,19 +93,39 @@ func Parse(parserName, input string) (interface{}, error) {
 		parser = &SystemctlParser{}
 	case "hosts":
 		parser = &HostsParser{}
+	case "resolvconf":
+		parser = &ResolvConfParser{}
+	case "nsswitch":
+		parser = &NsswitchParser{}
+	case "fstab":
+		parser = &FstabParser{}
+	case "crypttab":
+		parser = &CrypttabParser{}
 	case "passwd":
 		parser = &PasswdParser{}
+	case "group":
+		parser = &GroupParser{}
+	case "shadow":
+		parser = &ShadowParser{}
+	case "gshadow":
+		parser = &GshadowParser{}
 	case "env":
 		parser = &EnvParser{}
 	case "wc":
 		parser = &WcParser{}
 	case "dig":
 		parser = &DigParser{}
+	case "dmesg":
+		parser = &DmesgParser{}
+	case "last", "lastb":
+		parser = &LastParser{}
+	case "lastlog":
+		parser = &LastlogParser{}
 	default:
 		return nil, fmt.Errorf("unknown parser: %s", parserName)
 	}
 
-	return parser.Parse(input)
+	return parser, nil
 }
 
 // splitLines splits input into lines and filters out empty lines
@@ -94,3 +145,270 @@ func splitLines(input string) []string {
 func splitFields(line string) []string {
 	return strings.Fields(line)
 }
+
+// parseSizeToBytes converts a human readable size such as "123.4M", "1.5G",
+// "512K" or "4096" into bytes. Suffixes are interpreted as powers of 1024,
+// matching the output of systemctl, lsblk, df -h and du -h.
+func parseSizeToBytes(size string) (int64, error) {
+	size = strings.TrimSpace(size)
+	if size == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	multipliers := map[string]float64{
+		"":  1,
+		"B": 1,
+		"K": 1 << 10,
+		"M": 1 << 20,
+		"G": 1 << 30,
+		"T": 1 << 40,
+		"P": 1 << 50,
+		"E": 1 << 60,
+	}
+
+	// Accept "K", "KB", "KiB" and lowercase variants alike
+	unit := strings.ToUpper(strings.TrimLeft(size, "0123456789.,"))
+	number := strings.Replace(size[:len(size)-len(unit)], ",", ".", 1)
+	unit = strings.TrimSuffix(strings.TrimSuffix(unit, "B"), "I")
+	if unit == "" && strings.HasSuffix(strings.ToUpper(size), "B") {
+		unit = "B"
+	}
+
+	multiplier, ok := multipliers[unit]
+	if !ok {
+		return 0, fmt.Errorf("unknown size unit: %s", size)
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size: %s", size)
+	}
+
+	return int64(value * multiplier), nil
+}
+
+// columnStarts returns the position of each column name in a table header
+// line, counted in characters. Column names may contain spaces (e.g.
+// "UNIT FILE"). Names that are not found in the header get a position of -1.
+func columnStarts(header string, names []string) []int {
+	starts := make([]int, len(names))
+	searchFrom := 0
+	for i, name := range names {
+		idx := strings.Index(header[searchFrom:], name)
+		if idx == -1 {
+			starts[i] = -1
+			continue
+		}
+		starts[i] = utf8.RuneCountInString(header[:searchFrom+idx])
+		searchFrom += idx + len(name)
+	}
+	return starts
+}
+
+// sliceColumns cuts a table row into left-aligned columns using positions
+// computed by columnStarts. Positions are counted in characters so rows with
+// tree drawing characters line up with the header. Missing columns (position
+// -1) yield empty strings.
+func sliceColumns(line string, starts []int) []string {
+	runes := []rune(line)
+	values := make([]string, len(starts))
+	for i, start := range starts {
+		if start < 0 || start >= len(runes) {
+			continue
+		}
+		end := len(runes)
+		for _, next := range starts[i+1:] {
+			if next > start {
+				end = next
+				break
+			}
+		}
+		if end > len(runes) {
+			end = len(runes)
+		}
+		values[i] = strings.TrimSpace(string(runes[start:end]))
+	}
+	return values
+}
+
+// alignFields splits a table row into whitespace separated values and assigns
+// each value to the header column it overlaps most, so both left- and
+// right-aligned columns line up and empty cells stay empty. Values that do
+// not overlap any header name belong to the column they start in. Several
+// values in one column (e.g. a label with spaces) are kept together.
+func alignFields(line string, names []string, starts []int) []string {
+	runes := []rune(line)
+	values := make([]string, len(names))
+	first := make([]int, len(names))
+	last := make([]int, len(names))
+	for i := range first {
+		first[i] = -1
+	}
+
+	for pos := 0; pos < len(runes); {
+		if runes[pos] == ' ' || runes[pos] == '\t' {
+			pos++
+			continue
+		}
+		start := pos
+		for pos < len(runes) && runes[pos] != ' ' && runes[pos] != '\t' {
+			pos++
+		}
+
+		column, best := -1, 0
+		for i, colStart := range starts {
+			if colStart < 0 {
+				continue
+			}
+			colEnd := colStart + utf8.RuneCountInString(names[i])
+			overlap := min(pos, colEnd) - max(start, colStart)
+			if overlap > best {
+				column, best = i, overlap
+			}
+		}
+		if column == -1 {
+			column = 0
+			for i, colStart := range starts {
+				if colStart >= 0 && colStart <= start {
+					column = i
+				}
+			}
+		}
+
+		if first[column] == -1 {
+			first[column] = start
+		}
+		last[column] = pos
+	}
+
+	for i := range values {
+		if first[i] != -1 {
+			values[i] = string(runes[first[i]:last[i]])
+		}
+	}
+	return values
+}
+
+// formatDirective is one piece of a printf style format such as find -printf
+// or stat --printf: either literal text or a %-directive name such as "s"
+type formatDirective struct {
+	literal   string
+	directive string
+}
+
+// parseFormatDirectives splits a printf style format into literals and
+// directives. directiveRe matches a directive at the start of its input and
+// captures the directive name; field width and flags (e.g. %-10s) are
+// dropped and %% is kept as a literal percent sign.
+func parseFormatDirectives(format string, directiveRe *regexp.Regexp) []formatDirective {
+	var directives []formatDirective
+	var literal strings.Builder
+	for i := 0; i < len(format); {
+		match := directiveRe.FindStringSubmatch(format[i:])
+		if match == nil {
+			literal.WriteByte(format[i])
+			i++
+			continue
+		}
+		i += len(match[0])
+		if match[1] == "%" {
+			literal.WriteByte('%')
+			continue
+		}
+		if literal.Len() > 0 {
+			directives = append(directives, formatDirective{literal: literal.String()})
+			literal.Reset()
+		}
+		directives = append(directives, formatDirective{directive: match[1]})
+	}
+	if literal.Len() > 0 {
+		directives = append(directives, formatDirective{literal: literal.String()})
+	}
+
+	return directives
+}
+
+// matchFormatRecords matches input against repeated records of a printf
+// style format. pattern returns the capturing regexp for a directive. It
+// returns the directive names in order and, for each record, their values.
+// Matching the whole record lets values contain the separator characters as
+// long as the record as a whole is unambiguous.
+func matchFormatRecords(input, format string, directives []formatDirective, pattern func(string) string) ([]string, [][]string, error) {
+	if len(directives) == 0 {
+		return nil, nil, fmt.Errorf("empty format")
+	}
+
+	// The record terminator (usually \n or \0) must end the input as well
+	if last := directives[len(directives)-1]; last.literal != "" {
+		terminator := strings.TrimLeft(last.literal, " \t")
+		if terminator != "" && !strings.HasSuffix(input, terminator) {
+			input += terminator
+		}
+	}
+
+	var expr strings.Builder
+	expr.WriteString(`(?s)`)
+	var names []string
+	for _, d := range directives {
+		if d.directive == "" {
+			expr.WriteString(regexp.QuoteMeta(d.literal))
+			continue
+		}
+		expr.WriteString(pattern(d.directive))
+		names = append(names, d.directive)
+	}
+
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("format %q has no directives", format)
+	}
+
+	recordRe, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid format %q: %w", format, err)
+	}
+
+	var records [][]string
+	for _, match := range recordRe.FindAllStringSubmatch(input, -1) {
+		if strings.TrimSpace(match[0]) == "" {
+			continue
+		}
+		records = append(records, match[1:])
+	}
+
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("output does not match format %q", format)
+	}
+
+	return names, records, nil
+}
+
+// parseEpochTime parses seconds since the epoch with an optional fraction,
+// as printed by find %T@ and stat %Y
+func parseEpochTime(value string, location *time.Location) (time.Time, error) {
+	seconds, fraction, _ := strings.Cut(value, ".")
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse epoch time: %s", value)
+	}
+	var nsec int64
+	if fraction != "" {
+		fraction = (fraction + "000000000")[:9]
+		nsec, _ = strconv.ParseInt(fraction, 10, 64)
+	}
+	return time.Unix(sec, nsec).In(location), nil
+}
+
+// parseZoneTime parses value in location with a layout that may contain a
+// zone abbreviation ("MST"). time.Parse gives abbreviations it does not know
+// a zero offset, so only UTC, GMT and the abbreviations of location are
+// accepted; any other zone is an error rather than a time hours off.
+func parseZoneTime(layout, value string, location *time.Location) (time.Time, error) {
+	t, err := time.ParseInLocation(layout, value, location)
+	if err != nil || !strings.Contains(layout, "MST") {
+		return t, err
+	}
+	if name, offset := t.Zone(); t.Location() != location && offset == 0 && name != "UTC" && name != "GMT" {
+		return time.Time{}, fmt.Errorf("unknown time zone: %s", name)
+	}
+	return t, nil
+}
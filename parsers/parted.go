@@ -0,0 +1,148 @@
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PartedParser parses parted -m (machine readable) print output
+type PartedParser struct{}
+
+func (p *PartedParser) Name() string {
+	return "parted"
+}
+
+func (p *PartedParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	disks := []DiskEntry{}
+	var disk *DiskEntry
+
+	for _, line := range splitLines(input) {
+		line = strings.TrimSuffix(line, ";")
+
+		// Unit marker preceding each disk: BYT, CHS or CYL
+		if line == "BYT" || line == "CHS" || line == "CYL" {
+			disk = nil
+			continue
+		}
+
+		// path:size:transport:logical-sector:physical-sector:label:model:flags
+		if strings.HasPrefix(line, "/") {
+			fields := splitPartedFields(line, 8)
+			disks = append(disks, DiskEntry{
+				Device:     fields[0],
+				Size:       fields[1],
+				Transport:  fields[2],
+				LabelType:  fields[5],
+				Model:      fields[6],
+				Flags:      splitPartedFlags(fields[7]),
+				Partitions: []PartitionEntry{},
+			})
+			disk = &disks[len(disks)-1]
+			disk.LogicalSectorSize, _ = strconv.Atoi(fields[3])
+			disk.PhysicalSectorSize, _ = strconv.Atoi(fields[4])
+			if bytes, err := parsePartedSize(fields[1], disk.LogicalSectorSize); err == nil {
+				disk.SizeBytes = bytes
+			}
+			continue
+		}
+
+		if disk == nil {
+			continue
+		}
+
+		// number:start:end:size:filesystem:name:flags
+		fields := splitPartedFields(line, 7)
+		number, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+
+		partition := PartitionEntry{
+			Number:         number,
+			Size:           fields[3],
+			FilesystemType: fields[4],
+			Name:           fields[5],
+			Flags:          splitPartedFlags(fields[6]),
+		}
+		if start, err := parsePartedSize(fields[1], disk.LogicalSectorSize); err == nil {
+			partition.StartBytes = &start
+		}
+		if end, err := parsePartedSize(fields[2], disk.LogicalSectorSize); err == nil {
+			partition.EndBytes = &end
+		}
+		if size, err := parsePartedSize(fields[3], disk.LogicalSectorSize); err == nil {
+			partition.SizeBytes = &size
+		}
+		for _, flag := range partition.Flags {
+			if flag == "boot" {
+				partition.Boot = true
+			}
+		}
+
+		disk.Partitions = append(disk.Partitions, partition)
+	}
+
+	return disks, nil
+}
+
+// splitPartedFields splits a parted -m record into exactly count fields.
+// The last field keeps any remaining colons.
+func splitPartedFields(line string, count int) []string {
+	fields := strings.SplitN(line, ":", count)
+	for len(fields) < count {
+		fields = append(fields, "")
+	}
+	return fields
+}
+
+func splitPartedFlags(value string) []string {
+	var flags []string
+	for _, flag := range strings.Split(value, ",") {
+		if flag = strings.TrimSpace(flag); flag != "" {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// parsePartedSize converts parted sizes to bytes. parted uses decimal units
+// (kB, MB, GB), binary units (KiB, MiB, GiB), plain bytes ("B") and
+// sectors ("s").
+func parsePartedSize(value string, sectorSize int) (int64, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasSuffix(value, "s") && sectorSize > 0 {
+		sectors, err := strconv.ParseInt(strings.TrimSuffix(value, "s"), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid size: %s", value)
+		}
+		return sectors * int64(sectorSize), nil
+	}
+	if strings.HasSuffix(value, "iB") {
+		return parseSizeToBytes(value)
+	}
+
+	multipliers := map[string]float64{
+		"B":  1,
+		"kB": 1e3,
+		"MB": 1e6,
+		"GB": 1e9,
+		"TB": 1e12,
+		"PB": 1e15,
+	}
+	number := strings.TrimRight(value, "kMGTPB")
+	multiplier, ok := multipliers[value[len(number):]]
+	if !ok {
+		return 0, fmt.Errorf("unknown size unit: %s", value)
+	}
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size: %s", value)
+	}
+	return int64(n * multiplier), nil
+}
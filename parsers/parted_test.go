@@ -0,0 +1,74 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestPartedParser(t *testing.T) {
+	parser := &PartedParser{}
+
+	testInput := `BYT;
+/dev/sda:1000GB:scsi:512:4096:gpt:ATA Samsung SSD 860:;
+1:1049kB:538MB:537MB:fat32:EFI System Partition:boot, esp;
+2:538MB:1000GB:999GB:ext4::;
+BYT;
+/dev/sdb:2048s:usb:512:512:msdos:Generic Flash Disk:;
+1:1s:2047s:2047s:::lba;`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	disks, ok := result.([]DiskEntry)
+	if !ok {
+		t.Fatalf("Expected []DiskEntry, got %T", result)
+	}
+	if len(disks) != 2 {
+		t.Fatalf("Expected 2 disks, got %d", len(disks))
+	}
+
+	disk := disks[0]
+	if disk.Device != "/dev/sda" || disk.SizeBytes != 1000000000000 || disk.Transport != "scsi" {
+		t.Errorf("Unexpected disk %+v", disk)
+	}
+	if disk.LogicalSectorSize != 512 || disk.PhysicalSectorSize != 4096 || disk.LabelType != "gpt" {
+		t.Errorf("Unexpected disk geometry %+v", disk)
+	}
+	if disk.Model != "ATA Samsung SSD 860" {
+		t.Errorf("Unexpected model %q", disk.Model)
+	}
+
+	if len(disk.Partitions) != 2 {
+		t.Fatalf("Expected 2 partitions, got %d", len(disk.Partitions))
+	}
+	efi := disk.Partitions[0]
+	if efi.Number != 1 || efi.FilesystemType != "fat32" || efi.Name != "EFI System Partition" {
+		t.Errorf("Unexpected EFI partition %+v", efi)
+	}
+	if *efi.StartBytes != 1049000 || *efi.EndBytes != 538000000 || *efi.SizeBytes != 537000000 {
+		t.Errorf("Unexpected byte range %d-%d (%d)", *efi.StartBytes, *efi.EndBytes, *efi.SizeBytes)
+	}
+	if len(efi.Flags) != 2 || efi.Flags[1] != "esp" || !efi.Boot {
+		t.Errorf("Unexpected flags %v", efi.Flags)
+	}
+	if disk.Partitions[1].Name != "" || disk.Partitions[1].Flags != nil {
+		t.Errorf("Unexpected root partition %+v", disk.Partitions[1])
+	}
+
+	usb := disks[1]
+	if usb.SizeBytes != 1048576 || len(usb.Partitions) != 1 {
+		t.Fatalf("Unexpected sector based disk %+v", usb)
+	}
+	if *usb.Partitions[0].StartBytes != 512 || usb.Partitions[0].Flags[0] != "lba" {
+		t.Errorf("Unexpected sector based partition %+v", usb.Partitions[0])
+	}
+}
+
+func TestPartedParserEmpty(t *testing.T) {
+	parser := &PartedParser{}
+
+	if _, err := parser.Parse(""); err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
@@ -31,38 +31,26 @@ func (p *PasswdParser) Parse(input string) (interface{}, error) {
 		return nil, fmt.Errorf("empty input")
 	}
 
-	lines := strings.Split(input, "\n")
 	var entries []PasswdEntry
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
+	// passwd format: username:password:UID:GID:GECOS:directory:shell
+	for _, record := range parseColonRecords(input, 7) {
+		fields := record.Fields
 		entry := PasswdEntry{
-			Original: line,
-		}
-
-		// passwd format: username:password:UID:GID:GECOS:directory:shell
-		fields := strings.Split(line, ":")
-		if len(fields) != 7 {
-			continue
+			Original: record.Original,
 		}
 
 		entry.Username = fields[0]
 		entry.Password = fields[1]
-		
+
 		if uid, err := strconv.Atoi(fields[2]); err == nil {
 			entry.UID = uid
 		}
-		
+
 		if gid, err := strconv.Atoi(fields[3]); err == nil {
 			entry.GID = gid
 		}
-		
+
 		entry.GECOS = fields[4]
 		entry.HomeDir = fields[5]
 		entry.Shell = fields[6]
@@ -72,3 +60,114 @@ func (p *PasswdParser) Parse(input string) (interface{}, error) {
 
 	return entries, nil
 }
+
+// colonRecord is a single line of a colon-delimited database such as
+// /etc/passwd, /etc/group, /etc/shadow or /etc/gshadow
+type colonRecord struct {
+	Fields   []string
+	Original string
+}
+
+// parseColonRecords splits colon-delimited database lines into fields.
+// Empty lines, comments and lines without exactly fieldCount fields are skipped.
+func parseColonRecords(input string, fieldCount int) []colonRecord {
+	var records []colonRecord
+
+	for _, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ":")
+		if len(fields) != fieldCount {
+			continue
+		}
+
+		records = append(records, colonRecord{
+			Fields:   fields,
+			Original: line,
+		})
+	}
+
+	return records
+}
+
+// splitMemberList splits a comma separated user list, returning an empty
+// slice rather than nil for an empty field
+func splitMemberList(field string) []string {
+	members := []string{}
+	for _, member := range strings.Split(field, ",") {
+		if member = strings.TrimSpace(member); member != "" {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+// Password field states shared by the shadow and gshadow parsers
+const (
+	PasswordStatusHashed  = "hashed"
+	PasswordStatusLocked  = "locked"
+	PasswordStatusNoLogin = "no_login"
+	PasswordStatusEmpty   = "empty"
+)
+
+// describePasswordHash classifies a shadow password field and detects the
+// crypt(3) hash algorithm from its $id$ prefix without exposing the hash
+func describePasswordHash(field string) (status, algorithm string) {
+	if field == "" {
+		return PasswordStatusEmpty, ""
+	}
+
+	hash := field
+	status = PasswordStatusHashed
+	if strings.HasPrefix(hash, "!") {
+		status = PasswordStatusLocked
+		hash = strings.TrimLeft(hash, "!")
+	}
+
+	if hash == "" {
+		return status, ""
+	}
+	if hash == "*" || hash == "x" {
+		return PasswordStatusNoLogin, ""
+	}
+
+	algorithms := map[string]string{
+		"1":    "md5",
+		"2":    "bcrypt",
+		"2a":   "bcrypt",
+		"2b":   "bcrypt",
+		"2x":   "bcrypt",
+		"2y":   "bcrypt",
+		"5":    "sha256",
+		"6":    "sha512",
+		"7":    "scrypt",
+		"y":    "yescrypt",
+		"gy":   "gost-yescrypt",
+		"sha1": "sha1crypt",
+		"md5":  "sun-md5",
+	}
+
+	switch {
+	case strings.HasPrefix(hash, "$"):
+		id := strings.SplitN(hash[1:], "$", 2)[0]
+		id = strings.SplitN(id, ",", 2)[0]
+		if name, ok := algorithms[id]; ok {
+			algorithm = name
+		} else {
+			algorithm = "unknown"
+		}
+	case strings.HasPrefix(hash, "_") && len(hash) == 20:
+		algorithm = "bsdi-des"
+	case len(hash) == 13:
+		algorithm = "des"
+	default:
+		return PasswordStatusNoLogin, ""
+	}
+
+	return status, algorithm
+}
@@ -13,6 +13,7 @@ type PsEntry struct {
 	PID     int     `json:"pid"`
 	PPID    int     `json:"ppid,omitempty"`
 	User    string  `json:"user"`
+	UID     *int    `json:"uid,omitempty"`
 	CPU     float64 `json:"cpu_percent,omitempty"`
 	Memory  float64 `json:"memory_percent,omitempty"`
 	VSZ     int64   `json:"vsz,omitempty"`
@@ -0,0 +1,95 @@
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResolvConfParser parses /etc/resolv.conf
+type ResolvConfParser struct{}
+
+// ResolvConf is the resolver configuration. Options holds each option with
+// its value (ndots:2 gives 2) or true for flags such as rotate.
+type ResolvConf struct {
+	Nameservers []ResolvNameserver     `json:"nameservers"`
+	Domain      string                 `json:"domain,omitempty"`
+	Search      []string               `json:"search,omitempty"`
+	Sortlist    []string               `json:"sortlist,omitempty"`
+	Options     map[string]interface{} `json:"options,omitempty"`
+	Comments    []string               `json:"comments,omitempty"`
+	Unknown     []string               `json:"unknown,omitempty"`
+}
+
+// ResolvNameserver is a nameserver line and its address type
+type ResolvNameserver struct {
+	Address     string `json:"address"`
+	AddressType string `json:"address_type"`
+}
+
+func (p *ResolvConfParser) Name() string {
+	return "resolvconf"
+}
+
+func (p *ResolvConfParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	conf := ResolvConf{
+		Nameservers: []ResolvNameserver{},
+	}
+
+	for _, line := range splitLines(input) {
+		// Both # and ; start comments
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			conf.Comments = append(conf.Comments, strings.TrimSpace(line[1:]))
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "nameserver":
+			if len(fields) > 1 {
+				conf.Nameservers = append(conf.Nameservers, ResolvNameserver{
+					Address:     fields[1],
+					AddressType: hostsAddressType(fields[1]),
+				})
+			}
+		case "domain":
+			// domain and search override each other; the last one wins
+			if len(fields) > 1 {
+				conf.Domain = fields[1]
+				conf.Search = nil
+			}
+		case "search":
+			conf.Search = fields[1:]
+			conf.Domain = ""
+		case "sortlist":
+			conf.Sortlist = fields[1:]
+		case "options":
+			if conf.Options == nil {
+				conf.Options = map[string]interface{}{}
+			}
+			for _, option := range fields[1:] {
+				name, value, found := strings.Cut(option, ":")
+				if !found {
+					conf.Options[name] = true
+				} else if n, err := strconv.Atoi(value); err == nil {
+					conf.Options[name] = n
+				} else {
+					conf.Options[name] = value
+				}
+			}
+		default:
+			conf.Unknown = append(conf.Unknown, line)
+		}
+	}
+
+	return conf, nil
+}
@@ -0,0 +1,84 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestResolvConfParser(t *testing.T) {
+	parser := &ResolvConfParser{}
+
+	testInput := `# Generated by NetworkManager
+; legacy comment
+search example.com corp.example.com
+nameserver 192.168.1.1
+nameserver 2001:4860:4860::8888
+options ndots:2 timeout:3 rotate edns0 trust-ad
+sortlist 130.155.160.0/255.255.240.0 130.155.0.0
+lookup file bind`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	conf, ok := result.(ResolvConf)
+	if !ok {
+		t.Fatalf("Expected ResolvConf, got %T", result)
+	}
+
+	if len(conf.Nameservers) != 2 {
+		t.Fatalf("Expected 2 nameservers, got %d", len(conf.Nameservers))
+	}
+	if conf.Nameservers[0].Address != "192.168.1.1" || conf.Nameservers[0].AddressType != AddressTypeIPv4 {
+		t.Errorf("Unexpected nameserver %+v", conf.Nameservers[0])
+	}
+	if conf.Nameservers[1].AddressType != AddressTypeIPv6 {
+		t.Errorf("Expected IPv6 nameserver, got %+v", conf.Nameservers[1])
+	}
+	if len(conf.Search) != 2 || conf.Search[1] != "corp.example.com" {
+		t.Errorf("Unexpected search list %v", conf.Search)
+	}
+	if conf.Options["ndots"] != 2 || conf.Options["timeout"] != 3 || conf.Options["rotate"] != true {
+		t.Errorf("Unexpected options %v", conf.Options)
+	}
+	if len(conf.Sortlist) != 2 {
+		t.Errorf("Unexpected sortlist %v", conf.Sortlist)
+	}
+	if len(conf.Comments) != 2 || conf.Comments[1] != "legacy comment" {
+		t.Errorf("Unexpected comments %v", conf.Comments)
+	}
+	if len(conf.Unknown) != 1 || conf.Unknown[0] != "lookup file bind" {
+		t.Errorf("Unexpected unknown lines %v", conf.Unknown)
+	}
+}
+
+func TestResolvConfParserDomainSearch(t *testing.T) {
+	parser := &ResolvConfParser{}
+
+	// domain and search override each other; the last one wins
+	result, err := parser.Parse("search a.example\ndomain b.example")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	conf := result.(ResolvConf)
+	if conf.Domain != "b.example" || conf.Search != nil {
+		t.Errorf("Expected domain b.example only, got %q %v", conf.Domain, conf.Search)
+	}
+
+	result, err = parser.Parse("domain b.example\nsearch a.example c.example")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	conf = result.(ResolvConf)
+	if conf.Domain != "" || len(conf.Search) != 2 {
+		t.Errorf("Expected search list only, got %q %v", conf.Domain, conf.Search)
+	}
+}
+
+func TestResolvConfParserEmpty(t *testing.T) {
+	parser := &ResolvConfParser{}
+
+	if _, err := parser.Parse(""); err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
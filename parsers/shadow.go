@@ -0,0 +1,118 @@
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ShadowParser parses /etc/shadow file format. The password hash is only
+// included in the output when IncludeHash is set.
+type ShadowParser struct {
+	IncludeHash bool
+}
+
+// ShadowEntry represents a single shadow file entry. Day counts are kept as
+// they appear in the file; the dates derived from them are computed from
+// the last password change.
+type ShadowEntry struct {
+	Username        string     `json:"username"`
+	Password        string     `json:"password,omitempty"`
+	PasswordStatus  string     `json:"password_status"`
+	HashAlgorithm   string     `json:"hash_algorithm,omitempty"`
+	LastChange      *time.Time `json:"last_change,omitempty"`
+	ChangeRequired  bool       `json:"change_required"`
+	MinDays         *int       `json:"min_days,omitempty"`
+	MaxDays         *int       `json:"max_days,omitempty"`
+	WarnDays        *int       `json:"warn_days,omitempty"`
+	InactiveDays    *int       `json:"inactive_days,omitempty"`
+	MinChangeDate   *time.Time `json:"min_change_date,omitempty"`
+	PasswordExpires *time.Time `json:"password_expires,omitempty"`
+	WarnDate        *time.Time `json:"warn_date,omitempty"`
+	InactiveDate    *time.Time `json:"inactive_date,omitempty"`
+	AccountExpires  *time.Time `json:"account_expires,omitempty"`
+	Reserved        string     `json:"reserved,omitempty"`
+}
+
+// shadowNeverExpires is the conventional max_days value for passwords that never expire
+const shadowNeverExpires = 99999
+
+func (p *ShadowParser) Name() string {
+	return "shadow"
+}
+
+func (p *ShadowParser) Parse(input string) (interface{}, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	var entries []ShadowEntry
+
+	// shadow format: username:password:lastchg:min:max:warn:inactive:expire:reserved
+	for _, record := range parseColonRecords(input, 9) {
+		fields := record.Fields
+		entry := ShadowEntry{
+			Username: fields[0],
+			Reserved: fields[8],
+		}
+
+		entry.PasswordStatus, entry.HashAlgorithm = describePasswordHash(fields[1])
+		if p.IncludeHash {
+			entry.Password = fields[1]
+		}
+
+		entry.MinDays = parseShadowDays(fields[3])
+		entry.MaxDays = parseShadowDays(fields[4])
+		entry.WarnDays = parseShadowDays(fields[5])
+		entry.InactiveDays = parseShadowDays(fields[6])
+
+		if lastChange := parseShadowDays(fields[2]); lastChange != nil {
+			// 0 forces a password change at next login
+			entry.ChangeRequired = *lastChange == 0
+			changed := shadowDate(*lastChange)
+			entry.LastChange = &changed
+
+			if entry.MinDays != nil && *entry.MinDays > 0 {
+				minChange := changed.AddDate(0, 0, *entry.MinDays)
+				entry.MinChangeDate = &minChange
+			}
+			if entry.MaxDays != nil && *entry.MaxDays < shadowNeverExpires {
+				expires := changed.AddDate(0, 0, *entry.MaxDays)
+				entry.PasswordExpires = &expires
+				if entry.WarnDays != nil && *entry.WarnDays > 0 {
+					warn := expires.AddDate(0, 0, -*entry.WarnDays)
+					entry.WarnDate = &warn
+				}
+				if entry.InactiveDays != nil {
+					inactive := expires.AddDate(0, 0, *entry.InactiveDays)
+					entry.InactiveDate = &inactive
+				}
+			}
+		}
+
+		if expire := parseShadowDays(fields[7]); expire != nil {
+			expires := shadowDate(*expire)
+			entry.AccountExpires = &expires
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseShadowDays parses an optional day count field; empty fields return nil
+func parseShadowDays(field string) *int {
+	days, err := strconv.Atoi(strings.TrimSpace(field))
+	if err != nil {
+		return nil
+	}
+	return &days
+}
+
+// shadowDate converts days since Jan 1, 1970 into a UTC date
+func shadowDate(days int) time.Time {
+	return time.Unix(0, 0).UTC().AddDate(0, 0, days)
+}
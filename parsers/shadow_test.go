@@ -0,0 +1,120 @@
+package parsers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestShadowParser(t *testing.T) {
+	parser := &ShadowParser{}
+
+	testInput := `root:$6$saltsalt$hashhashhash:19000:0:99999:7:::
+daemon:*:18000:0:99999:7:::
+alice:$y$j9T$salt$hash:19500:1:90:14:30:20000:
+bob:!$2b$12$abcdefghijklmnopqrstuv:0:0:99999:7:::
+carol::19000::::::
+legacy:abCDefGH12345:17000:0:60:7:::`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]ShadowEntry)
+	if !ok {
+		t.Fatalf("Expected []ShadowEntry, got %T", result)
+	}
+
+	if len(entries) != 6 {
+		t.Fatalf("Expected 6 entries, got %d", len(entries))
+	}
+
+	// Test hash algorithm detection
+	expected := []struct {
+		status    string
+		algorithm string
+	}{
+		{PasswordStatusHashed, "sha512"},
+		{PasswordStatusNoLogin, ""},
+		{PasswordStatusHashed, "yescrypt"},
+		{PasswordStatusLocked, "bcrypt"},
+		{PasswordStatusEmpty, ""},
+		{PasswordStatusHashed, "des"},
+	}
+	for i, want := range expected {
+		if entries[i].PasswordStatus != want.status || entries[i].HashAlgorithm != want.algorithm {
+			t.Errorf("%s: expected %s/%s, got %s/%s", entries[i].Username, want.status, want.algorithm,
+				entries[i].PasswordStatus, entries[i].HashAlgorithm)
+		}
+	}
+
+	// Test root dates
+	root := entries[0]
+	if root.LastChange == nil || root.LastChange.Format("2006-01-02") != "2022-01-08" {
+		t.Errorf("Unexpected last change: %v", root.LastChange)
+	}
+	if root.PasswordExpires != nil {
+		t.Errorf("Expected no expiry for max 99999, got %v", root.PasswordExpires)
+	}
+	if root.MaxDays == nil || *root.MaxDays != 99999 || root.InactiveDays != nil {
+		t.Errorf("Unexpected day counts: max %v inactive %v", root.MaxDays, root.InactiveDays)
+	}
+
+	// Test ageing dates
+	alice := entries[2]
+	if alice.LastChange.Format("2006-01-02") != "2023-05-23" {
+		t.Errorf("Unexpected last change: %v", alice.LastChange)
+	}
+	if alice.MinChangeDate == nil || alice.MinChangeDate.Format("2006-01-02") != "2023-05-24" {
+		t.Errorf("Unexpected min change date: %v", alice.MinChangeDate)
+	}
+	if alice.PasswordExpires == nil || alice.PasswordExpires.Format("2006-01-02") != "2023-08-21" {
+		t.Errorf("Unexpected password expiry: %v", alice.PasswordExpires)
+	}
+	if alice.WarnDate == nil || alice.WarnDate.Format("2006-01-02") != "2023-08-07" {
+		t.Errorf("Unexpected warn date: %v", alice.WarnDate)
+	}
+	if alice.InactiveDate == nil || alice.InactiveDate.Format("2006-01-02") != "2023-09-20" {
+		t.Errorf("Unexpected inactive date: %v", alice.InactiveDate)
+	}
+	if alice.AccountExpires == nil || alice.AccountExpires.Format("2006-01-02") != "2024-10-04" {
+		t.Errorf("Unexpected account expiry: %v", alice.AccountExpires)
+	}
+
+	if !entries[3].ChangeRequired {
+		t.Error("Expected change required for last change 0")
+	}
+
+	// Hashes are never exposed by default
+	jsonData, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("JSON marshal failed: %v", err)
+	}
+	if strings.Contains(string(jsonData), "hashhashhash") {
+		t.Error("JSON output contains the password hash")
+	}
+}
+
+func TestShadowParserIncludeHash(t *testing.T) {
+	parser := &ShadowParser{IncludeHash: true}
+
+	result, err := parser.Parse(`root:$6$saltsalt$hashhashhash:19000:0:99999:7:::`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]ShadowEntry)
+	if entries[0].Password != "$6$saltsalt$hashhashhash" {
+		t.Errorf("Expected hash to be included, got '%s'", entries[0].Password)
+	}
+}
+
+func TestShadowParserEmpty(t *testing.T) {
+	parser := &ShadowParser{}
+
+	_, err := parser.Parse("")
+	if err == nil {
+		t.Error("Expected error for empty input")
+	}
+}
@@ -2,29 +2,80 @@ package parsers
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// StatParser parses stat command output
-type StatParser struct{}
+// StatParser parses stat command output: the default GNU and BSD formats,
+// BSD stat -x, GNU stat -f filesystem status and, when Format holds the
+// --format or --printf template that produced the output, custom formats
+type StatParser struct {
+	Format string
+}
 
 // StatEntry represents a single stat output entry
 type StatEntry struct {
-	File        string    `json:"file"`
-	Size        int64     `json:"size"`
-	Blocks      int64     `json:"blocks"`
-	IOBlock     int64     `json:"io_block"`
-	Device      string    `json:"device"`
-	Inode       int64     `json:"inode"`
-	Links       int       `json:"links"`
-	Permissions string    `json:"permissions"`
-	UID         int       `json:"uid"`
-	GID         int       `json:"gid"`
-	AccessTime  time.Time `json:"access_time"`
-	ModifyTime  time.Time `json:"modify_time"`
-	ChangeTime  time.Time `json:"change_time"`
+	File            string     `json:"file"`
+	LinkTarget      string     `json:"link_target,omitempty"`
+	Size            int64      `json:"size"`
+	Blocks          int64      `json:"blocks"`
+	IOBlock         int64      `json:"io_block"`
+	TypeDescription string     `json:"type_description,omitempty"`
+	Device          string     `json:"device"`
+	DeviceMajor     *int       `json:"device_major,omitempty"`
+	DeviceMinor     *int       `json:"device_minor,omitempty"`
+	Inode           int64      `json:"inode"`
+	Links           int        `json:"links"`
+	Permissions     string     `json:"permissions"`
+	UID             *int       `json:"uid,omitempty"`
+	GID             *int       `json:"gid,omitempty"`
+	User            string     `json:"user,omitempty"`
+	Group           string     `json:"group,omitempty"`
+	Context         string     `json:"context,omitempty"`
+	AccessTime      time.Time  `json:"access_time"`
+	ModifyTime      time.Time  `json:"modify_time"`
+	ChangeTime      time.Time  `json:"change_time"`
+	BirthTime       *time.Time `json:"birth_time,omitempty"`
+	Flags           string     `json:"flags,omitempty"`
+	FileMode
+	Extra map[string]string `json:"extra,omitempty"`
+}
+
+// StatFilesystemEntry represents a stat -f filesystem status entry. Block
+// counts are in units of the fundamental block size.
+type StatFilesystemEntry struct {
+	File                 string `json:"file"`
+	ID                   string `json:"id"`
+	NameMax              int    `json:"name_max"`
+	Type                 string `json:"type"`
+	BlockSize            int64  `json:"block_size"`
+	FundamentalBlockSize int64  `json:"fundamental_block_size"`
+	Blocks               int64  `json:"blocks"`
+	BlocksFree           int64  `json:"blocks_free"`
+	BlocksAvailable      int64  `json:"blocks_available"`
+	Inodes               int64  `json:"inodes"`
+	InodesFree           int64  `json:"inodes_free"`
+	SizeBytes            int64  `json:"size_bytes"`
+	FreeBytes            int64  `json:"free_bytes"`
+	AvailableBytes       int64  `json:"available_bytes"`
+}
+
+// statFileTypes maps the file type descriptions of GNU stat and BSD stat -x
+// to file types
+var statFileTypes = map[string]string{
+	"regular file":           FileTypeRegular,
+	"regular empty file":     FileTypeRegular,
+	"directory":              FileTypeDirectory,
+	"symbolic link":          FileTypeSymlink,
+	"character special file": FileTypeCharDevice,
+	"character device":       FileTypeCharDevice,
+	"block special file":     FileTypeBlockDevice,
+	"block device":           FileTypeBlockDevice,
+	"fifo":                   FileTypeFIFO,
+	"socket":                 FileTypeSocket,
+	"door":                   FileTypeDoor,
 }
 
 func (p *StatParser) Name() string {
@@ -36,30 +87,48 @@ func (p *StatParser) Parse(input string) (interface{}, error) {
 	if input == "" {
 		return nil, fmt.Errorf("empty input")
 	}
-	
+
+	if p.Format != "" {
+		return parseStatPrintf(input, p.Format)
+	}
+
 	lines := splitLines(input)
-	var entries []StatEntry
-	var currentEntry *StatEntry
 
 	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
+		if strings.HasPrefix(line, "ID:") && strings.Contains(line, "Namelen:") {
+			return parseStatFilesystem(lines), nil
+		}
+	}
+
+	// BSD stat: dev ino mode nlink user group rdev size "atime" "mtime"
+	// "ctime" "birthtime" blksize blocks flags name
+	bsdRe := regexp.MustCompile(`^(\d+) (\d+) (\S+) (\d+) (\S+) (\S+) (\d+) (\d+) "([^"]*)" "([^"]*)" "([^"]*)" "([^"]*)" (\d+) (\d+) (\S+) (.+)$`)
+	if bsdRe.MatchString(lines[0]) {
+		var entries []StatEntry
+		for _, line := range lines {
+			if matches := bsdRe.FindStringSubmatch(line); matches != nil {
+				entries = append(entries, parseBsdStatLine(matches))
+			}
 		}
+		return entries, nil
+	}
 
+	var entries []StatEntry
+	var currentEntry *StatEntry
+
+	for _, line := range lines {
 		// Check if this is a new file entry (starts with "File:")
 		if strings.HasPrefix(line, "File:") {
 			// Save previous entry if exists
 			if currentEntry != nil {
+				finishStatEntry(currentEntry)
 				entries = append(entries, *currentEntry)
 			}
-			// Start new entry
-			currentEntry = &StatEntry{}
-			// Extract filename from "File: 'filename'" or "File: filename"
-			fileStr := strings.TrimPrefix(line, "File:")
-			fileStr = strings.TrimSpace(fileStr)
-			fileStr = strings.Trim(fileStr, "'\"")
-			currentEntry.File = fileStr
+			// The name is unquoted once the file type is known, since
+			// symlinks are shown as "File: 'name' -> 'target'"
+			currentEntry = &StatEntry{
+				File: strings.TrimSpace(strings.TrimPrefix(line, "File:")),
+			}
 		} else if currentEntry != nil {
 			// Parse other stat fields
 			parseStatField(line, currentEntry)
@@ -68,6 +137,7 @@ func (p *StatParser) Parse(input string) (interface{}, error) {
 
 	// Add the last entry
 	if currentEntry != nil {
+		finishStatEntry(currentEntry)
 		entries = append(entries, *currentEntry)
 	}
 
@@ -76,122 +146,485 @@ func (p *StatParser) Parse(input string) (interface{}, error) {
 
 // parseStatField parses individual stat output fields
 func parseStatField(line string, entry *StatEntry) {
-	// Handle different stat output formats
-	if strings.Contains(line, "Size:") {
-		// Size: 1024 Blocks: 8 IO Block: 4096 regular file
-		fields := splitFields(line)
-		for i, field := range fields {
-			switch field {
+	idRe := regexp.MustCompile(`(Uid|Gid): \(\s*(\d+)/\s*([^)]*?)\s*\)`)
+
+	key, value, _ := strings.Cut(line, ":")
+	value = strings.TrimSpace(value)
+	fields := splitFields(line)
+
+	switch key {
+	case "Size":
+		// Size: 1024  Blocks: 8  IO Block: 4096   regular file
+		// BSD stat -x: Size: 1024  FileType: Regular File
+		for i := 0; i+1 < len(fields); i++ {
+			switch fields[i] {
 			case "Size:":
-				if i+1 < len(fields) {
-					if size, err := strconv.ParseInt(fields[i+1], 10, 64); err == nil {
-						entry.Size = size
-					}
+				if size, err := strconv.ParseInt(fields[i+1], 10, 64); err == nil {
+					entry.Size = size
 				}
 			case "Blocks:":
-				if i+1 < len(fields) {
-					if blocks, err := strconv.ParseInt(fields[i+1], 10, 64); err == nil {
-						entry.Blocks = blocks
-					}
+				if blocks, err := strconv.ParseInt(fields[i+1], 10, 64); err == nil {
+					entry.Blocks = blocks
 				}
 			case "Block:":
-				if i+1 < len(fields) {
-					if ioBlock, err := strconv.ParseInt(fields[i+1], 10, 64); err == nil {
-						entry.IOBlock = ioBlock
-					}
+				if ioBlock, err := strconv.ParseInt(fields[i+1], 10, 64); err == nil {
+					entry.IOBlock = ioBlock
+				}
+				if i+2 < len(fields) {
+					setStatFileType(entry, strings.Join(fields[i+2:], " "))
 				}
+				i = len(fields)
+			case "FileType:":
+				setStatFileType(entry, strings.Join(fields[i+1:], " "))
+				i = len(fields)
 			}
 		}
-	} else if strings.Contains(line, "Device:") {
-		// Device: 801h/2049d Inode: 123456 Links: 1
-		fields := splitFields(line)
-		for i, field := range fields {
-			switch field {
+	case "Device", "Device type":
+		// Device: 801h/2049d  Inode: 123456  Links: 1  Device type: 1,3
+		for i := 0; i+1 < len(fields); i++ {
+			switch fields[i] {
 			case "Device:":
-				if i+1 < len(fields) {
-					entry.Device = fields[i+1]
-				}
+				entry.Device = fields[i+1]
 			case "Inode:":
-				if i+1 < len(fields) {
-					if inode, err := strconv.ParseInt(fields[i+1], 10, 64); err == nil {
-						entry.Inode = inode
-					}
+				if inode, err := strconv.ParseInt(fields[i+1], 10, 64); err == nil {
+					entry.Inode = inode
 				}
 			case "Links:":
-				if i+1 < len(fields) {
-					if links, err := strconv.Atoi(fields[i+1]); err == nil {
-						entry.Links = links
-					}
+				if links, err := strconv.Atoi(fields[i+1]); err == nil {
+					entry.Links = links
 				}
+			case "type:":
+				// Older GNU stat prints the device number in hex ("801h/2049d")
+				// and so also the major and minor numbers
+				setStatDevice(entry, fields[i+1], strings.Contains(entry.Device, "h/"))
 			}
 		}
-	} else if strings.Contains(line, "Access: (") {
-		// Access: (0644/-rw-r--r--) Uid: (1000/user) Gid: (1000/group)
-		// Extract permissions
-		if start := strings.Index(line, "("); start != -1 {
-			if end := strings.Index(line[start:], ")"); end != -1 {
-				permsStr := line[start+1 : start+end]
-				if slash := strings.Index(permsStr, "/"); slash != -1 {
-					entry.Permissions = permsStr[slash+1:]
-				}
+	case "Access", "Mode":
+		if !strings.HasPrefix(value, "(") {
+			// Access: 2023-01-01 12:00:00.000000000 +0000
+			if t, err := parseStatTime(value); err == nil {
+				entry.AccessTime = t
 			}
+			return
 		}
-		
-		// Extract UID and GID
-		if uidStart := strings.Index(line, "Uid: ("); uidStart != -1 {
-			uidEnd := strings.Index(line[uidStart:], ")")
-			if uidEnd != -1 {
-				uidStr := line[uidStart+6 : uidStart+uidEnd]
-				if slash := strings.Index(uidStr, "/"); slash != -1 {
-					if uid, err := strconv.Atoi(uidStr[:slash]); err == nil {
-						entry.UID = uid
-					}
-				}
+
+		// Access: (0644/-rw-r--r--)  Uid: (1000/user)  Gid: (1000/group)
+		if perms, _, found := strings.Cut(strings.TrimPrefix(value, "("), ")"); found {
+			octal, symbolic, _ := strings.Cut(perms, "/")
+			entry.Permissions = strings.TrimSpace(symbolic)
+			entry.FileMode = decodeFileMode(entry.Permissions)
+			applyOctalMode(&entry.FileMode, strings.TrimSpace(octal))
+		}
+		for _, match := range idRe.FindAllStringSubmatch(line, -1) {
+			id, _ := strconv.Atoi(match[2])
+			name := match[3]
+			if name == "UNKNOWN" {
+				name = ""
+			}
+			if match[1] == "Uid" {
+				entry.UID, entry.User = &id, name
+			} else {
+				entry.GID, entry.Group = &id, name
 			}
 		}
-		
-		if gidStart := strings.Index(line, "Gid: ("); gidStart != -1 {
-			gidEnd := strings.Index(line[gidStart:], ")")
-			if gidEnd != -1 {
-				gidStr := line[gidStart+6 : gidStart+gidEnd]
-				if slash := strings.Index(gidStr, "/"); slash != -1 {
-					if gid, err := strconv.Atoi(gidStr[:slash]); err == nil {
-						entry.GID = gid
-					}
-				}
+	case "Modify":
+		if t, err := parseStatTime(value); err == nil {
+			entry.ModifyTime = t
+		}
+	case "Change":
+		if t, err := parseStatTime(value); err == nil {
+			entry.ChangeTime = t
+		}
+	case "Birth":
+		// Birth: - when the filesystem does not record it
+		if t, err := parseStatTime(value); err == nil {
+			entry.BirthTime = &t
+		}
+	case "Context":
+		entry.Context = value
+	}
+}
+
+// finishStatEntry splits the symlink target from the name and unquotes both
+func finishStatEntry(entry *StatEntry) {
+	name := entry.File
+	if entry.FileType == FileTypeSymlink && entry.LinkTarget == "" {
+		name, entry.LinkTarget = splitStatLink(name)
+	}
+	entry.File = unquoteStatName(name)
+}
+
+// splitStatLink splits "'name' -> 'target'" (or unquoted name -> target),
+// taking the quoting into account so a quoted name may contain " -> "
+func splitStatLink(value string) (string, string) {
+	separator := " -> "
+	for _, quote := range []string{"'", `"`, "’"} {
+		if len(value) > 0 && strings.HasSuffix(value, quote) && strings.Contains(value, quote+separator) {
+			separator = quote + " -> "
+			break
+		}
+	}
+
+	i := strings.Index(value, separator)
+	if i == -1 {
+		return value, ""
+	}
+	i += len(separator) - len(" -> ")
+	return value[:i], unquoteStatName(value[i+len(" -> "):])
+}
+
+// unquoteStatName removes the quoting stat adds to names: shell quoting like
+// ls in current GNU stat and ‘curly’ or `backtick' quotes in older versions
+func unquoteStatName(name string) string {
+	switch {
+	case strings.HasPrefix(name, "‘") && strings.HasSuffix(name, "’") && len(name) > len("‘’"):
+		return strings.TrimSuffix(strings.TrimPrefix(name, "‘"), "’")
+	case strings.HasPrefix(name, "`") && strings.HasSuffix(name, "'") && len(name) > 1:
+		return name[1 : len(name)-1]
+	}
+	return unquoteLsName(name)
+}
+
+// setStatFileType sets the file type from a stat file type description
+func setStatFileType(entry *StatEntry, description string) {
+	entry.TypeDescription = description
+	if fileType, ok := statFileTypes[strings.ToLower(description)]; ok {
+		entry.FileType = fileType
+	} else {
+		entry.FileType = FileTypeUnknown
+	}
+}
+
+// setStatDevice sets the major and minor numbers of a device node from a
+// "major,minor" pair
+func setStatDevice(entry *StatEntry, value string, hex bool) {
+	major, minor, found := strings.Cut(value, ",")
+	if !found {
+		return
+	}
+	base := 10
+	if hex {
+		base = 16
+	}
+	if n, err := strconv.ParseInt(strings.TrimSuffix(major, "h"), base, 64); err == nil {
+		m := int(n)
+		entry.DeviceMajor = &m
+	}
+	if n, err := strconv.ParseInt(strings.TrimSuffix(minor, "h"), base, 64); err == nil {
+		m := int(n)
+		entry.DeviceMinor = &m
+	}
+}
+
+// parseBsdStatLine builds an entry from a BSD stat line matched by Parse
+func parseBsdStatLine(matches []string) StatEntry {
+	entry := StatEntry{
+		Device:      matches[1],
+		Permissions: matches[3],
+		User:        matches[5],
+		Group:       matches[6],
+		Flags:       matches[15],
+		File:        matches[16],
+	}
+	entry.Inode, _ = strconv.ParseInt(matches[2], 10, 64)
+	entry.Links, _ = strconv.Atoi(matches[4])
+	entry.Size, _ = strconv.ParseInt(matches[8], 10, 64)
+	entry.IOBlock, _ = strconv.ParseInt(matches[13], 10, 64)
+	entry.Blocks, _ = strconv.ParseInt(matches[14], 10, 64)
+	entry.FileMode = decodeFileMode(entry.Permissions)
+
+	if matches[7] != "0" {
+		entry.Extra = map[string]string{"rdev": matches[7]}
+	}
+
+	if t, err := parseStatTime(matches[9]); err == nil {
+		entry.AccessTime = t
+	}
+	if t, err := parseStatTime(matches[10]); err == nil {
+		entry.ModifyTime = t
+	}
+	if t, err := parseStatTime(matches[11]); err == nil {
+		entry.ChangeTime = t
+	}
+	if t, err := parseStatTime(matches[12]); err == nil {
+		entry.BirthTime = &t
+	}
+
+	if entry.FileType == FileTypeSymlink {
+		if name, target, found := strings.Cut(entry.File, " -> "); found {
+			entry.File, entry.LinkTarget = name, target
+		}
+	}
+
+	return entry
+}
+
+// parseStatFilesystem parses stat -f output:
+//
+//	  File: "/"
+//	    ID: 2f3a4b5c6d7e8f90 Namelen: 255     Type: ext2/ext3
+//	Block size: 4096       Fundamental block size: 4096
+//	Blocks: Total: 12345678   Free: 2345678    Available: 1234567
+//	Inodes: Total: 3276800    Free: 2900000
+func parseStatFilesystem(lines []string) []StatFilesystemEntry {
+	pairRe := regexp.MustCompile(`([A-Za-z][A-Za-z ]*?):\s+(\S+)`)
+
+	var entries []StatFilesystemEntry
+	var entry *StatFilesystemEntry
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "File:") {
+			entries = append(entries, StatFilesystemEntry{
+				File: unquoteStatName(strings.TrimSpace(strings.TrimPrefix(line, "File:"))),
+			})
+			entry = &entries[len(entries)-1]
+			continue
+		}
+		if entry == nil {
+			continue
+		}
+
+		// "Blocks:" and "Inodes:" lines hold Total/Free/Available counts
+		section, rest, _ := strings.Cut(line, ":")
+		if section != "Blocks" && section != "Inodes" {
+			section, rest = "", line
+		}
+
+		for _, match := range pairRe.FindAllStringSubmatch(rest, -1) {
+			key, value := strings.TrimSpace(match[1]), match[2]
+			n, _ := strconv.ParseInt(value, 10, 64)
+			switch section + key {
+			case "ID":
+				entry.ID = value
+			case "Namelen":
+				entry.NameMax = int(n)
+			case "Type":
+				entry.Type = value
+			case "Block size":
+				entry.BlockSize = n
+			case "Fundamental block size":
+				entry.FundamentalBlockSize = n
+			case "BlocksTotal":
+				entry.Blocks = n
+			case "BlocksFree":
+				entry.BlocksFree = n
+			case "BlocksAvailable":
+				entry.BlocksAvailable = n
+			case "InodesTotal":
+				entry.Inodes = n
+			case "InodesFree":
+				entry.InodesFree = n
 			}
 		}
-	} else if strings.HasPrefix(line, "Access: ") && !strings.Contains(line, "(") {
-		// Access: 2023-01-01 12:00:00.000000000 +0000
-		timeStr := strings.TrimPrefix(line, "Access: ")
-		if t, err := parseStatTime(timeStr); err == nil {
-			entry.AccessTime = t
+	}
+
+	for i := range entries {
+		unit := entries[i].FundamentalBlockSize
+		if unit == 0 {
+			unit = entries[i].BlockSize
+		}
+		entries[i].SizeBytes = entries[i].Blocks * unit
+		entries[i].FreeBytes = entries[i].BlocksFree * unit
+		entries[i].AvailableBytes = entries[i].BlocksAvailable * unit
+	}
+
+	return entries
+}
+
+// parseStatPrintf parses stat --format or --printf output produced by format.
+// --format ends each record with a newline, so one is added when the format
+// does not end with a newline or NUL.
+func parseStatPrintf(input, format string) ([]StatEntry, error) {
+	directiveRe := regexp.MustCompile(`^%[-+# 0']*\d*(?:\.\d+)?([HL][dr]|[a-zA-Z%])`)
+	decoded := decodeLsEscapes(format)
+	if !strings.HasSuffix(decoded, "\n") && !strings.HasSuffix(decoded, "\x00") {
+		decoded += "\n"
+	}
+	directives := parseFormatDirectives(decoded, directiveRe)
+
+	names, records, err := matchFormatRecords(input, format, directives, statDirectivePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []StatEntry
+	for _, values := range records {
+		entry := StatEntry{}
+		for i, directive := range names {
+			applyStatDirective(&entry, directive, values[i])
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// statDirectivePattern returns the regexp capturing a directive's value.
+// Numeric directives may be space padded by a field width.
+func statDirectivePattern(directive string) string {
+	switch directive {
+	case "b", "B", "d", "g", "h", "i", "o", "s", "u", "Hd", "Ld", "Hr", "Lr":
+		return ` *(\d+) *`
+	case "a":
+		return ` *([0-7]+) *`
+	case "f", "t", "T", "D":
+		return ` *([0-9a-fA-F]+) *`
+	case "X", "Y", "Z", "W":
+		return ` *(-?\d+(?:\.\d+)?) *`
+	case "A":
+		return `([-dlcbpsD?][-rwxsStTlL?]{9}[.+@]?)`
+	}
+	return `(.*?)`
+}
+
+// applyStatDirective stores a captured --format value in its typed field;
+// directives without one are kept in Extra
+func applyStatDirective(entry *StatEntry, directive, value string) {
+	number, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+
+	switch directive {
+	case "n":
+		entry.File = value
+	case "N":
+		// %N quotes the name and adds the target of symlinks
+		name, target := splitStatLink(value)
+		entry.File = unquoteStatName(name)
+		if target != "" {
+			entry.LinkTarget = target
+		}
+	case "s":
+		entry.Size = number
+	case "b":
+		entry.Blocks = number
+	case "o":
+		entry.IOBlock = number
+	case "i":
+		entry.Inode = number
+	case "h":
+		entry.Links = int(number)
+	case "u":
+		uid := int(number)
+		entry.UID = &uid
+	case "U":
+		entry.User = value
+	case "g":
+		gid := int(number)
+		entry.GID = &gid
+	case "G":
+		entry.Group = value
+	case "C":
+		entry.Context = value
+	case "d", "D":
+		entry.Device = strings.TrimSpace(value)
+	case "a":
+		applyOctalMode(&entry.FileMode, strings.TrimSpace(value))
+	case "A":
+		entry.Permissions = value
+		mode := decodeFileMode(value)
+		if entry.Mode != "" {
+			mode.Mode = entry.Mode
 		}
-	} else if strings.HasPrefix(line, "Modify: ") {
-		// Modify: 2023-01-01 12:00:00.000000000 +0000
-		timeStr := strings.TrimPrefix(line, "Modify: ")
-		if t, err := parseStatTime(timeStr); err == nil {
+		if entry.FileType != "" && mode.FileType == FileTypeUnknown {
+			mode.FileType = entry.FileType
+		}
+		entry.FileMode = mode
+	case "f":
+		// Raw mode in hex: the file type bits and the permission bits
+		if raw, err := strconv.ParseUint(strings.TrimSpace(value), 16, 32); err == nil {
+			entry.FileType = statRawFileType(raw)
+			applyOctalMode(&entry.FileMode, strconv.FormatUint(raw&07777, 8))
+		}
+	case "F":
+		setStatFileType(entry, value)
+	case "t", "T":
+		if n, err := strconv.ParseInt(strings.TrimSpace(value), 16, 64); err == nil {
+			m := int(n)
+			if directive == "t" {
+				entry.DeviceMajor = &m
+			} else {
+				entry.DeviceMinor = &m
+			}
+		}
+	case "Hr":
+		m := int(number)
+		entry.DeviceMajor = &m
+	case "Lr":
+		m := int(number)
+		entry.DeviceMinor = &m
+	case "x", "y", "z", "w":
+		t, err := parseStatTime(value)
+		if err != nil {
+			// %w prints "-" when the birth time is unknown
+			return
+		}
+		switch directive {
+		case "x":
+			entry.AccessTime = t
+		case "y":
 			entry.ModifyTime = t
+		case "z":
+			entry.ChangeTime = t
+		case "w":
+			entry.BirthTime = &t
+		}
+	case "X", "Y", "Z", "W":
+		t, err := parseEpochTime(strings.TrimSpace(value), time.UTC)
+		if err != nil {
+			return
 		}
-	} else if strings.HasPrefix(line, "Change: ") {
-		// Change: 2023-01-01 12:00:00.000000000 +0000
-		timeStr := strings.TrimPrefix(line, "Change: ")
-		if t, err := parseStatTime(timeStr); err == nil {
+		switch directive {
+		case "X":
+			entry.AccessTime = t
+		case "Y":
+			entry.ModifyTime = t
+		case "Z":
 			entry.ChangeTime = t
+		case "W":
+			// %W prints 0 when the birth time is unknown
+			if t.Unix() != 0 {
+				entry.BirthTime = &t
+			}
+		}
+	default:
+		if entry.Extra == nil {
+			entry.Extra = map[string]string{}
 		}
+		entry.Extra["%"+directive] = value
+	}
+}
+
+// statRawFileType decodes the file type bits of a raw st_mode value
+func statRawFileType(raw uint64) string {
+	switch raw & 0170000 {
+	case 0100000:
+		return FileTypeRegular
+	case 0040000:
+		return FileTypeDirectory
+	case 0120000:
+		return FileTypeSymlink
+	case 0020000:
+		return FileTypeCharDevice
+	case 0060000:
+		return FileTypeBlockDevice
+	case 0010000:
+		return FileTypeFIFO
+	case 0140000:
+		return FileTypeSocket
 	}
+	return FileTypeUnknown
 }
 
 // parseStatTime parses timestamp from stat output
 func parseStatTime(timeStr string) (time.Time, error) {
-	// Common stat time formats
+	// Common stat time formats; BSD stat prints "Jan 15 14:30:25 2024" and
+	// stat -x adds the weekday
 	formats := []string{
-		"2006-01-02 15:04:05.000000000 -0700",
-		"2006-01-02 15:04:05.000000000 +0000",
+		"2006-01-02 15:04:05.999999999 -0700",
 		"2006-01-02 15:04:05 -0700",
-		"2006-01-02 15:04:05 +0000",
-		"2006-01-02 15:04:05.000000000",
+		"2006-01-02 15:04:05.999999999",
 		"2006-01-02 15:04:05",
+		"Mon Jan _2 15:04:05 2006",
+		"Jan _2 15:04:05 2006",
 	}
 
 	for _, format := range formats {
@@ -61,11 +61,11 @@ Change: 2023-01-15 14:25:10.000000000 +0000`
 	if entry.Permissions != "-rw-r--r--" {
 		t.Errorf("Expected permissions '-rw-r--r--', got '%s'", entry.Permissions)
 	}
-	if entry.UID != 1000 {
-		t.Errorf("Expected UID 1000, got %d", entry.UID)
+	if entry.UID == nil || *entry.UID != 1000 {
+		t.Errorf("Expected UID 1000, got %v", entry.UID)
 	}
-	if entry.GID != 1000 {
-		t.Errorf("Expected GID 1000, got %d", entry.GID)
+	if entry.GID == nil || *entry.GID != 1000 {
+		t.Errorf("Expected GID 1000, got %v", entry.GID)
 	}
 
 	// Test timestamps
@@ -175,3 +175,201 @@ func TestStatParserEmpty(t *testing.T) {
 		t.Errorf("Expected file 'test', got '%s'", entries[0].File)
 	}
 }
+
+func TestStatParserDetails(t *testing.T) {
+	parser := &StatParser{}
+
+	testInput := `  File: link -> 'my target.txt'
+  Size: 13        	Blocks: 0          IO Block: 4096   symbolic link
+Device: 8,1	Inode: 1000        Links: 1
+Access: (0777/lrwxrwxrwx)  Uid: ( 1000/   alice)   Gid: (  100/   users)
+Context: unconfined_u:object_r:user_home_t:s0
+Access: 2024-01-15 14:30:25.123456789 +0100
+Modify: 2024-01-15 14:25:10.000000000 +0100
+Change: 2024-01-15 14:25:10.000000000 +0100
+ Birth: 2024-01-10 09:00:00.000000000 +0100
+  File: /dev/null
+  Size: 0         	Blocks: 0          IO Block: 4096   character special file
+Device: 0,5	Inode: 4           Links: 1     Device type: 1,3
+Access: (4755/crwsr-xr-x)  Uid: (    0/    root)   Gid: (    0/    root)
+Access: 2024-01-15 14:30:25.000000000 +0000
+Modify: 2024-01-15 14:30:25.000000000 +0000
+Change: 2024-01-15 14:30:25.000000000 +0000
+ Birth: -`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]StatEntry)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	link := entries[0]
+	if link.File != "link" || link.LinkTarget != "my target.txt" {
+		t.Errorf("Unexpected name %q target %q", link.File, link.LinkTarget)
+	}
+	if link.FileType != FileTypeSymlink || link.TypeDescription != "symbolic link" {
+		t.Errorf("Unexpected type %q (%q)", link.FileType, link.TypeDescription)
+	}
+	if link.Mode != "0777" || link.User != "alice" || link.Group != "users" || link.GID == nil || *link.GID != 100 {
+		t.Errorf("Unexpected mode %q owner %q:%q gid %v", link.Mode, link.User, link.Group, link.GID)
+	}
+	if link.Context != "unconfined_u:object_r:user_home_t:s0" {
+		t.Errorf("Unexpected context %q", link.Context)
+	}
+	if link.BirthTime == nil || link.BirthTime.Day() != 10 {
+		t.Errorf("Expected birth time, got %v", link.BirthTime)
+	}
+	if link.AccessTime.Nanosecond() != 123456789 {
+		t.Errorf("Expected fractional access time, got %v", link.AccessTime)
+	}
+
+	null := entries[1]
+	if null.FileType != FileTypeCharDevice || null.DeviceMajor == nil || *null.DeviceMajor != 1 || *null.DeviceMinor != 3 {
+		t.Errorf("Unexpected device entry %+v", null)
+	}
+	if !null.Setuid || null.Mode != "4755" {
+		t.Errorf("Expected setuid mode 4755, got %q", null.Mode)
+	}
+	if null.BirthTime != nil {
+		t.Errorf("Expected no birth time, got %v", null.BirthTime)
+	}
+}
+
+func TestStatParserBSD(t *testing.T) {
+	parser := &StatParser{}
+
+	testInput := `16777230 8636986 -rw-r--r-- 1 alice staff 0 1024 "Jan 15 14:30:25 2024" "Jan 15 14:25:10 2024" "Jan 15 14:25:10 2024" "Jan  5 09:00:00 2024" 4096 8 0 my file.txt`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]StatEntry)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.File != "my file.txt" || entry.Size != 1024 || entry.Inode != 8636986 {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if entry.User != "alice" || entry.Group != "staff" || entry.Mode != "0644" || entry.FileType != FileTypeRegular {
+		t.Errorf("Unexpected ownership or mode %+v", entry)
+	}
+	if entry.BirthTime == nil || entry.BirthTime.Day() != 5 {
+		t.Errorf("Expected birth time Jan 5, got %v", entry.BirthTime)
+	}
+}
+
+func TestStatParserBSDVerbose(t *testing.T) {
+	parser := &StatParser{}
+
+	testInput := `  File: "test.txt"
+  Size: 1024         FileType: Regular File
+  Mode: (0644/-rw-r--r--)         Uid: (  501/    alice)  Gid: (   20/   staff)
+Device: 1,4   Inode: 1234567    Links: 1
+Access: Mon Jan 15 14:30:25 2024
+Modify: Mon Jan 15 14:25:10 2024
+Change: Mon Jan 15 14:25:10 2024
+ Birth: Mon Jan 15 14:20:00 2024`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entry := result.([]StatEntry)[0]
+	if entry.File != "test.txt" || entry.FileType != FileTypeRegular || entry.UID == nil || *entry.UID != 501 || entry.User != "alice" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if entry.ModifyTime.Minute() != 25 || entry.BirthTime == nil {
+		t.Errorf("Unexpected times modify=%v birth=%v", entry.ModifyTime, entry.BirthTime)
+	}
+}
+
+func TestStatParserFilesystem(t *testing.T) {
+	parser := &StatParser{}
+
+	testInput := `  File: "/"
+    ID: 2f3a4b5c6d7e8f90 Namelen: 255     Type: ext2/ext3
+Block size: 4096       Fundamental block size: 4096
+Blocks: Total: 1000       Free: 400        Available: 300
+Inodes: Total: 3276800    Free: 2900000`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]StatFilesystemEntry)
+	if !ok || len(entries) != 1 {
+		t.Fatalf("Expected one []StatFilesystemEntry, got %T", result)
+	}
+
+	fs := entries[0]
+	if fs.File != "/" || fs.Type != "ext2/ext3" || fs.NameMax != 255 || fs.ID != "2f3a4b5c6d7e8f90" {
+		t.Errorf("Unexpected filesystem %+v", fs)
+	}
+	if fs.Blocks != 1000 || fs.BlocksFree != 400 || fs.BlocksAvailable != 300 || fs.InodesFree != 2900000 {
+		t.Errorf("Unexpected counts %+v", fs)
+	}
+	if fs.SizeBytes != 4096000 || fs.AvailableBytes != 1228800 {
+		t.Errorf("Unexpected sizes %+v", fs)
+	}
+}
+
+func TestStatParserFormat(t *testing.T) {
+	parser := &StatParser{Format: `%n|%s|%a|%U|%G|%Y|%F|%i`}
+
+	testInput := "my file.txt|1024|644|alice|users|1705329025|regular file|42\n" +
+		"bin|4096|2755|root|staff|1705329000|directory|43\n"
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]StatEntry)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.File != "my file.txt" || entry.Size != 1024 || entry.Mode != "0644" || entry.Inode != 42 {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if entry.User != "alice" || entry.Group != "users" || entry.FileType != FileTypeRegular {
+		t.Errorf("Unexpected owner or type %+v", entry)
+	}
+	if entry.ModifyTime.Unix() != 1705329025 {
+		t.Errorf("Unexpected modify time %v", entry.ModifyTime)
+	}
+	if !entries[1].Setgid || entries[1].FileType != FileTypeDirectory {
+		t.Errorf("Unexpected directory entry %+v", entries[1])
+	}
+}
+
+func TestStatParserTerseFormat(t *testing.T) {
+	parser := &StatParser{Format: `%n %s %b %f %u %g %D %i %h %t %T %X %Y %Z %W %o`}
+
+	result, err := parser.Parse("/dev/sda 0 0 61b0 0 6 5 300 1 8 0 1705329025 1705329025 1705329025 0 4096\n")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entry := result.([]StatEntry)[0]
+	if entry.File != "/dev/sda" || entry.FileType != FileTypeBlockDevice || entry.Mode != "0660" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if *entry.DeviceMajor != 8 || *entry.DeviceMinor != 0 || entry.GID == nil || *entry.GID != 6 || entry.IOBlock != 4096 {
+		t.Errorf("Unexpected device numbers %+v", entry)
+	}
+	if entry.BirthTime != nil {
+		t.Errorf("Expected unknown birth time, got %v", entry.BirthTime)
+	}
+}
@@ -2,11 +2,18 @@ package parsers
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// SystemctlParser parses systemctl command output
-type SystemctlParser struct{}
+// SystemctlParser parses systemctl command output. Timestamps are read in
+// Location (UTC when nil); a zone abbreviation other than UTC, GMT or one
+// of Location's leaves the timestamp unset.
+type SystemctlParser struct {
+	Location *time.Location
+}
 
 // SystemctlEntry represents a systemctl service entry
 type SystemctlEntry struct {
@@ -16,12 +23,62 @@ type SystemctlEntry struct {
 	Sub         string `json:"sub"`
 	Description string `json:"description"`
 	// For systemctl status output
-	Status      string `json:"status,omitempty"`
-	Main        string `json:"main,omitempty"`
-	Tasks       string `json:"tasks,omitempty"`
-	Memory      string `json:"memory,omitempty"`
-	CPU         string `json:"cpu,omitempty"`
-	ProcessID   string `json:"process_id,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Main      string `json:"main,omitempty"`
+	Tasks     string `json:"tasks,omitempty"`
+	Memory    string `json:"memory,omitempty"`
+	CPU       string `json:"cpu,omitempty"`
+	ProcessID string `json:"process_id,omitempty"`
+	// Decoded systemctl status fields
+	LoadPath              string             `json:"load_path,omitempty"`
+	UnitFileState         string             `json:"unit_file_state,omitempty"`
+	VendorPreset          string             `json:"vendor_preset,omitempty"`
+	Result                string             `json:"result,omitempty"`
+	ActiveSince           *time.Time         `json:"active_since,omitempty"`
+	ActiveDuration        string             `json:"active_duration,omitempty"`
+	ActiveDurationSeconds int64              `json:"active_duration_seconds,omitempty"`
+	MainPID               int                `json:"main_pid,omitempty"`
+	MainProcess           string             `json:"main_process,omitempty"`
+	TasksCurrent          int                `json:"tasks_current,omitempty"`
+	TasksLimit            int                `json:"tasks_limit,omitempty"`
+	MemoryBytes           int64              `json:"memory_bytes,omitempty"`
+	CPUSeconds            float64            `json:"cpu_seconds,omitempty"`
+	Docs                  []string           `json:"docs,omitempty"`
+	DropIns               []string           `json:"drop_ins,omitempty"`
+	CGroup                string             `json:"cgroup,omitempty"`
+	Processes             []SystemctlProcess `json:"processes,omitempty"`
+	Journal               []string           `json:"journal,omitempty"`
+}
+
+// SystemctlProcess represents a process listed in the CGroup tree of systemctl status
+type SystemctlProcess struct {
+	PID     int    `json:"pid"`
+	Command string `json:"command"`
+}
+
+// SystemctlUnitFile represents a systemctl list-unit-files entry
+type SystemctlUnitFile struct {
+	UnitFile     string `json:"unit_file"`
+	State        string `json:"state"`
+	VendorPreset string `json:"vendor_preset,omitempty"`
+}
+
+// SystemctlTimer represents a systemctl list-timers entry
+type SystemctlTimer struct {
+	Next      *time.Time `json:"next,omitempty"`
+	Left      string     `json:"left,omitempty"`
+	Last      *time.Time `json:"last,omitempty"`
+	Passed    string     `json:"passed,omitempty"`
+	Unit      string     `json:"unit"`
+	Activates string     `json:"activates"`
+}
+
+// SystemctlSocket represents a systemctl list-sockets entry
+type SystemctlSocket struct {
+	Listen    string   `json:"listen"`
+	Type      string   `json:"type,omitempty"`
+	Unit      string   `json:"unit"`
+	Activates []string `json:"activates"`
 }
 
 func (p *SystemctlParser) Name() string {
@@ -39,9 +96,31 @@ func (p *SystemctlParser) Parse(input string) (interface{}, error) {
 		return []SystemctlEntry{}, nil
 	}
 
-	// Check if this is status output (contains ●)
-	if strings.Contains(input, "●") || strings.Contains(input, "Active:") {
-		return p.parseStatus(lines)
+	location := p.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	// systemctl show prints nothing but Key=Value lines
+	if isSystemctlShow(lines) {
+		return p.parseShow(input)
+	}
+
+	// Check if this is status output (has Loaded:/Active: property lines)
+	statusRe := regexp.MustCompile(`(?m)^\s*(Loaded|Active):\s`)
+	if statusRe.MatchString(input) {
+		return p.parseStatus(input, location)
+	}
+
+	// Pick the listing type from the table header
+	rawLines := strings.Split(input, "\n")
+	switch header := rawLines[0]; {
+	case strings.HasPrefix(header, "UNIT FILE"):
+		return p.parseListUnitFiles(lines)
+	case strings.HasPrefix(header, "NEXT") && strings.Contains(header, "ACTIVATES"):
+		return p.parseListTimers(rawLines, location)
+	case strings.HasPrefix(header, "LISTEN") && strings.Contains(header, "ACTIVATES"):
+		return p.parseListSockets(rawLines)
 	}
 
 	// Otherwise parse list-units output
@@ -50,7 +129,7 @@ func (p *SystemctlParser) Parse(input string) (interface{}, error) {
 
 func (p *SystemctlParser) parseListUnits(lines []string) (interface{}, error) {
 	var entries []SystemctlEntry
-	
+
 	// Skip header lines until we find one starting with UNIT
 	startIdx := -1
 	for i, line := range lines {
@@ -66,14 +145,17 @@ func (p *SystemctlParser) parseListUnits(lines []string) (interface{}, error) {
 
 	for i := startIdx; i < len(lines); i++ {
 		line := lines[i]
-		
+
 		// Skip summary lines
-		if strings.Contains(line, "loaded units listed") || 
-		   strings.Contains(line, "units listed") ||
-		   strings.HasPrefix(line, "LOAD") {
+		if strings.Contains(line, "loaded units listed") ||
+			strings.Contains(line, "units listed") ||
+			strings.HasPrefix(line, "LOAD") {
 			continue
 		}
 
+		// Failed units are prefixed with a bullet
+		line = strings.TrimSpace(strings.TrimLeft(line, "●*"))
+
 		fields := strings.Fields(line)
 		if len(fields) < 4 {
 			continue
@@ -97,49 +179,402 @@ func (p *SystemctlParser) parseListUnits(lines []string) (interface{}, error) {
 	return entries, nil
 }
 
-func (p *SystemctlParser) parseStatus(lines []string) (interface{}, error) {
-	entry := SystemctlEntry{}
-	
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		
-		// Parse service name from first line with ●
-		if strings.Contains(line, "●") {
-			parts := strings.Fields(line)
-			if len(parts) > 1 {
-				entry.Unit = parts[1]
-				if len(parts) > 3 {
-					entry.Description = strings.Join(parts[3:], " ")
+// parseStatus parses systemctl status output for one or more units
+func (p *SystemctlParser) parseStatus(input string, location *time.Location) (interface{}, error) {
+	// Units start with a state bullet: ● active, ○ inactive, × failed, ↻ reloading
+	unitRe := regexp.MustCompile(`^([●○×↻*])\s+(\S+)(?:\s+-\s+(.*))?$`)
+	propertyRe := regexp.MustCompile(`^\s*([A-Z][A-Za-z -]*?):\s*(.*)$`)
+
+	var entries []SystemctlEntry
+	var entry *SystemctlEntry
+	lastKey := ""
+	inJournal := false
+
+	for _, rawLine := range strings.Split(input, "\n") {
+		line := strings.TrimSpace(rawLine)
+
+		// Unit headers are never indented, unlike TriggeredBy continuation lines
+		if matches := unitRe.FindStringSubmatch(strings.TrimRight(rawLine, " \t\r")); matches != nil {
+			if entry != nil {
+				entries = append(entries, *entry)
+			}
+			entry = &SystemctlEntry{
+				Unit:        matches[2],
+				Description: matches[3],
+			}
+			lastKey = ""
+			inJournal = false
+			continue
+		}
+
+		// Status of a unit without a header line (e.g. trimmed output)
+		if entry == nil {
+			if line == "" {
+				continue
+			}
+			entry = &SystemctlEntry{}
+		}
+
+		// The journal tail is separated from the properties by a blank line
+		if line == "" {
+			if lastKey != "" {
+				inJournal = true
+			}
+			continue
+		}
+		if inJournal {
+			entry.Journal = append(entry.Journal, line)
+			continue
+		}
+
+		if matches := propertyRe.FindStringSubmatch(rawLine); matches != nil {
+			lastKey = matches[1]
+			applySystemctlProperty(entry, lastKey, strings.TrimSpace(matches[2]), location)
+			continue
+		}
+
+		// Continuation line of a multi-line property
+		applySystemctlContinuation(entry, lastKey, line)
+	}
+
+	if entry != nil {
+		entries = append(entries, *entry)
+	}
+
+	return entries, nil
+}
+
+// applySystemctlProperty decodes a "Key: value" line of systemctl status
+func applySystemctlProperty(entry *SystemctlEntry, key, value string, location *time.Location) {
+	switch key {
+	case "Loaded":
+		// loaded (/lib/systemd/system/apache2.service; enabled; vendor preset: enabled)
+		entry.Load = value
+		if open := strings.Index(value, " ("); open != -1 {
+			entry.Load = value[:open]
+			details := strings.Split(strings.TrimSuffix(value[open+2:], ")"), ";")
+			for i, detail := range details {
+				detail = strings.TrimSpace(detail)
+				switch {
+				case i == 0:
+					entry.LoadPath = detail
+				case strings.HasPrefix(detail, "vendor preset:"):
+					entry.VendorPreset = strings.TrimSpace(strings.TrimPrefix(detail, "vendor preset:"))
+				case strings.HasPrefix(detail, "preset:"):
+					entry.VendorPreset = strings.TrimSpace(strings.TrimPrefix(detail, "preset:"))
+				case i == 1:
+					entry.UnitFileState = detail
 				}
 			}
 		}
-		
-		// Parse key-value pairs
-		if strings.Contains(line, ":") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				key := strings.TrimSpace(parts[0])
-				value := strings.TrimSpace(parts[1])
-				
-				switch key {
-				case "Loaded":
-					entry.Load = value
-				case "Active":
-					entry.Active = value
-					entry.Status = value
-				case "Main PID":
-					entry.Main = value
-					entry.ProcessID = value
-				case "Tasks":
-					entry.Tasks = value
-				case "Memory":
-					entry.Memory = value
-				case "CPU":
-					entry.CPU = value
+	case "Active":
+		entry.Status = value
+		parseSystemctlActive(entry, value, location)
+	case "Main PID":
+		// 12345 (apache2) or 12345 (code=exited, status=0/SUCCESS)
+		entry.Main = value
+		entry.ProcessID = value
+		fields := strings.Fields(value)
+		if len(fields) > 0 {
+			if pid, err := strconv.Atoi(fields[0]); err == nil {
+				entry.MainPID = pid
+			}
+		}
+		if open := strings.Index(value, "("); open != -1 {
+			entry.MainProcess = strings.TrimSuffix(value[open+1:], ")")
+		}
+	case "Tasks":
+		// 55 (limit: 4915)
+		entry.Tasks = value
+		fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(value))
+		if len(fields) > 0 {
+			if tasks, err := strconv.Atoi(fields[0]); err == nil {
+				entry.TasksCurrent = tasks
+			}
+		}
+		for i, field := range fields {
+			if field == "limit:" && i+1 < len(fields) {
+				if limit, err := strconv.Atoi(fields[i+1]); err == nil {
+					entry.TasksLimit = limit
 				}
 			}
 		}
+	case "Memory":
+		// 123.4M or 123.4M (peak: 200.1M)
+		entry.Memory = value
+		if fields := strings.Fields(value); len(fields) > 0 {
+			if bytes, err := parseSizeToBytes(fields[0]); err == nil {
+				entry.MemoryBytes = bytes
+			}
+		}
+	case "CPU":
+		entry.CPU = value
+		if seconds, err := parseSystemdDuration(value); err == nil {
+			entry.CPUSeconds = seconds
+		}
+	case "Docs":
+		entry.Docs = append(entry.Docs, value)
+	case "Drop-In":
+		entry.DropIns = append(entry.DropIns, value)
+	case "CGroup":
+		entry.CGroup = value
+	}
+}
+
+// applySystemctlContinuation handles the indented lines that follow Docs,
+// Drop-In and CGroup in systemctl status output
+func applySystemctlContinuation(entry *SystemctlEntry, key, line string) {
+	switch key {
+	case "Docs":
+		entry.Docs = append(entry.Docs, line)
+	case "Drop-In":
+		// Further drop-in directories, then └─override.conf, limits.conf
+		// relative to the last directory
+		if strings.HasPrefix(line, "/") {
+			entry.DropIns = append(entry.DropIns, line)
+			return
+		}
+		if len(entry.DropIns) == 0 {
+			return
+		}
+		dir := entry.DropIns[len(entry.DropIns)-1]
+		if !strings.HasSuffix(dir, ".d") && !strings.HasSuffix(dir, ".d/") {
+			return
+		}
+		entry.DropIns = entry.DropIns[:len(entry.DropIns)-1]
+		for _, name := range strings.Split(trimSystemctlTree(line), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				entry.DropIns = append(entry.DropIns, strings.TrimSuffix(dir, "/")+"/"+name)
+			}
+		}
+	case "CGroup":
+		// ├─12345 /usr/sbin/apache2 -k start
+		item := trimSystemctlTree(line)
+		fields := strings.SplitN(item, " ", 2)
+		if pid, err := strconv.Atoi(fields[0]); err == nil {
+			process := SystemctlProcess{PID: pid}
+			if len(fields) > 1 {
+				process.Command = strings.TrimSpace(fields[1])
+			}
+			entry.Processes = append(entry.Processes, process)
+		}
+	}
+}
+
+// trimSystemctlTree strips the box drawing prefix used by systemctl trees
+func trimSystemctlTree(line string) string {
+	return strings.TrimSpace(strings.TrimLeft(line, "│├└─|`- "))
+}
+
+// parseSystemctlActive decodes "active (running) since Mon 2023-01-15 14:30:25 UTC; 2h 15min ago"
+func parseSystemctlActive(entry *SystemctlEntry, value string, location *time.Location) {
+	activeRe := regexp.MustCompile(`^(\S+)(?:\s+\(([^)]*)\))?(?:\s+since\s+([^;]+)(?:;\s*(.+?)\s+ago)?)?`)
+	matches := activeRe.FindStringSubmatch(value)
+	if matches == nil {
+		entry.Active = value
+		return
+	}
+
+	entry.Active = matches[1]
+	entry.Sub = matches[2]
+	if strings.HasPrefix(entry.Sub, "Result:") {
+		entry.Result = strings.TrimSpace(strings.TrimPrefix(entry.Sub, "Result:"))
+		entry.Sub = entry.Active
+	}
+
+	if matches[3] != "" {
+		if since, err := parseSystemdTimestamp(matches[3], location); err == nil {
+			entry.ActiveSince = &since
+		}
+	}
+	if matches[4] != "" {
+		entry.ActiveDuration = matches[4]
+		if seconds, err := parseSystemdDuration(matches[4]); err == nil {
+			entry.ActiveDurationSeconds = int64(seconds)
+		}
+	}
+}
+
+// parseSystemdTimestamp parses timestamps like "Mon 2023-01-15 14:30:25 UTC"
+// in location
+func parseSystemdTimestamp(value string, location *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	formats := []string{
+		"Mon 2006-01-02 15:04:05 MST",
+		"Mon 2006-01-02 15:04:05.000000 MST",
+		"Mon 2006-01-02 15:04:05 -0700",
+		"2006-01-02 15:04:05 MST",
+		"Mon 2006-01-02 15:04:05",
+	}
+
+	for _, format := range formats {
+		if t, err := parseZoneTime(format, value, location); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse systemd timestamp: %s", value)
+}
+
+// parseSystemdDuration converts systemd time spans such as "2h 15min",
+// "1 day 3h", "2.345s" or "1min 3.456s" into seconds
+func parseSystemdDuration(value string) (float64, error) {
+	units := map[string]float64{
+		"us": 1e-6, "usec": 1e-6, "µs": 1e-6,
+		"ms": 1e-3, "msec": 1e-3,
+		"s": 1, "sec": 1, "second": 1, "seconds": 1,
+		"m": 60, "min": 60, "minute": 60, "minutes": 60,
+		"h": 3600, "hr": 3600, "hour": 3600, "hours": 3600,
+		"d": 86400, "day": 86400, "days": 86400,
+		"w": 604800, "week": 604800, "weeks": 604800,
+		"M": 2629800, "month": 2629800, "months": 2629800,
+		"y": 31557600, "year": 31557600, "years": 31557600,
+	}
+
+	spanRe := regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([a-zA-Zµ]+)`)
+	matches := spanRe.FindAllStringSubmatch(value, -1)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("unable to parse duration: %s", value)
+	}
+
+	total := 0.0
+	for _, match := range matches {
+		multiplier, ok := units[match[2]]
+		if !ok {
+			return 0, fmt.Errorf("unknown duration unit in: %s", value)
+		}
+		number, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, err
+		}
+		total += number * multiplier
+	}
+
+	return total, nil
+}
+
+func (p *SystemctlParser) parseListUnitFiles(lines []string) (interface{}, error) {
+	var entries []SystemctlUnitFile
+
+	for _, line := range lines[1:] {
+		// Skip the "N unit files listed." footer
+		if strings.Contains(line, "unit files listed") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		entry := SystemctlUnitFile{
+			UnitFile: fields[0],
+			State:    fields[1],
+		}
+		if len(fields) > 2 {
+			entry.VendorPreset = fields[2]
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (p *SystemctlParser) parseListTimers(rawLines []string, location *time.Location) (interface{}, error) {
+	var entries []SystemctlTimer
+
+	// Columns contain spaces, so cut them at the header offsets
+	starts := columnStarts(rawLines[0], []string{"NEXT", "LEFT", "LAST", "PASSED", "UNIT", "ACTIVATES"})
+
+	for _, line := range rawLines[1:] {
+		if strings.TrimSpace(line) == "" ||
+			strings.Contains(line, "timers listed") ||
+			strings.HasPrefix(line, "Pass --all") {
+			continue
+		}
+
+		columns := sliceColumns(line, starts)
+		entry := SystemctlTimer{
+			Left:      columns[1],
+			Passed:    columns[3],
+			Unit:      columns[4],
+			Activates: columns[5],
+		}
+		if next, err := parseSystemdTimestamp(columns[0], location); err == nil {
+			entry.Next = &next
+		}
+		if last, err := parseSystemdTimestamp(columns[2], location); err == nil {
+			entry.Last = &last
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (p *SystemctlParser) parseListSockets(rawLines []string) (interface{}, error) {
+	var entries []SystemctlSocket
+
+	// TYPE is only present with --show-types
+	starts := columnStarts(rawLines[0], []string{"LISTEN", "TYPE", "UNIT", "ACTIVATES"})
+
+	for _, line := range rawLines[1:] {
+		if strings.TrimSpace(line) == "" ||
+			strings.Contains(line, "sockets listed") ||
+			strings.HasPrefix(line, "Pass --all") {
+			continue
+		}
+
+		columns := sliceColumns(line, starts)
+		entry := SystemctlSocket{
+			Listen:    columns[0],
+			Type:      columns[1],
+			Unit:      columns[2],
+			Activates: strings.Fields(columns[3]),
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// isSystemctlShow reports whether every line is a systemctl show property
+func isSystemctlShow(lines []string) bool {
+	keyRe := regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*=`)
+	for _, line := range lines {
+		if !keyRe.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseShow parses systemctl show Key=Value output into one map per unit.
+// Units are separated by a blank line.
+func (p *SystemctlParser) parseShow(input string) (interface{}, error) {
+	var units []map[string]string
+	current := map[string]string{}
+
+	for _, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if len(current) > 0 {
+				units = append(units, current)
+				current = map[string]string{}
+			}
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		current[parts[0]] = parts[1]
+	}
+
+	if len(current) > 0 {
+		units = append(units, current)
 	}
 
-	return entry, nil
+	return units, nil
 }
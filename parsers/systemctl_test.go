@@ -3,6 +3,7 @@ package parsers
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestSystemctlParser(t *testing.T) {
@@ -97,10 +98,14 @@ func TestSystemctlParserStatus(t *testing.T) {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	entry, ok := result.(SystemctlEntry)
+	entries, ok := result.([]SystemctlEntry)
 	if !ok {
-		t.Fatalf("Expected SystemctlEntry, got %T", result)
+		t.Fatalf("Expected []SystemctlEntry, got %T", result)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
 	}
+	entry := entries[0]
 
 	// Test service info
 	if entry.Unit != "apache2.service" {
@@ -162,3 +167,267 @@ func TestSystemctlParserEmpty(t *testing.T) {
 		t.Errorf("Expected 0 entries, got %d", len(entries))
 	}
 }
+
+func TestSystemctlParserStatusDecoded(t *testing.T) {
+	parser := &SystemctlParser{}
+
+	testInput := `● apache2.service - The Apache HTTP Server
+     Loaded: loaded (/lib/systemd/system/apache2.service; enabled; vendor preset: enabled)
+    Drop-In: /etc/systemd/system/apache2.service.d
+             └─override.conf, limits.conf
+     Active: active (running) since Mon 2023-01-15 14:30:25 UTC; 2h 15min ago
+       Docs: https://httpd.apache.org/docs/2.4/
+             man:apache2(8)
+   Main PID: 12345 (apache2)
+      Tasks: 55 (limit: 4915)
+     Memory: 123.4M
+        CPU: 1min 2.345s
+     CGroup: /system.slice/apache2.service
+             ├─12345 /usr/sbin/apache2 -k start
+             └─12346 /usr/sbin/apache2 -k start
+
+Jan 15 14:30:25 web systemd[1]: Starting The Apache HTTP Server...
+Jan 15 14:30:25 web systemd[1]: Started The Apache HTTP Server.
+
+× backup.service - Nightly backup
+     Loaded: loaded (/etc/systemd/system/backup.service; static)
+     Active: failed (Result: exit-code) since Mon 2023-01-15 03:00:01 UTC; 1 day 3h ago
+   Main PID: 999 (code=exited, status=1/FAILURE)
+        CPU: 345ms`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]SystemctlEntry)
+	if !ok {
+		t.Fatalf("Expected []SystemctlEntry, got %T", result)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	apache := entries[0]
+	if apache.Load != "loaded" || apache.LoadPath != "/lib/systemd/system/apache2.service" {
+		t.Errorf("Unexpected load fields: %q %q", apache.Load, apache.LoadPath)
+	}
+	if apache.UnitFileState != "enabled" || apache.VendorPreset != "enabled" {
+		t.Errorf("Unexpected unit file state %q / preset %q", apache.UnitFileState, apache.VendorPreset)
+	}
+	if apache.Active != "active" || apache.Sub != "running" {
+		t.Errorf("Expected active/running, got %s/%s", apache.Active, apache.Sub)
+	}
+	if apache.ActiveSince == nil || apache.ActiveSince.Format("2006-01-02 15:04:05") != "2023-01-15 14:30:25" {
+		t.Errorf("Unexpected active since: %v", apache.ActiveSince)
+	}
+	if apache.ActiveDuration != "2h 15min" || apache.ActiveDurationSeconds != 8100 {
+		t.Errorf("Unexpected duration %q (%d)", apache.ActiveDuration, apache.ActiveDurationSeconds)
+	}
+	if apache.MainPID != 12345 || apache.MainProcess != "apache2" {
+		t.Errorf("Unexpected main PID %d (%s)", apache.MainPID, apache.MainProcess)
+	}
+	if apache.TasksCurrent != 55 || apache.TasksLimit != 4915 {
+		t.Errorf("Unexpected tasks %d/%d", apache.TasksCurrent, apache.TasksLimit)
+	}
+	if apache.MemoryBytes != 129394278 {
+		t.Errorf("Expected memory bytes 129394278, got %d", apache.MemoryBytes)
+	}
+	if apache.CPUSeconds < 62.344 || apache.CPUSeconds > 62.346 {
+		t.Errorf("Expected CPU seconds 62.345, got %f", apache.CPUSeconds)
+	}
+	if len(apache.Docs) != 2 || apache.Docs[1] != "man:apache2(8)" {
+		t.Errorf("Unexpected docs: %v", apache.Docs)
+	}
+	if len(apache.DropIns) != 2 || apache.DropIns[0] != "/etc/systemd/system/apache2.service.d/override.conf" {
+		t.Errorf("Unexpected drop-ins: %v", apache.DropIns)
+	}
+	if apache.CGroup != "/system.slice/apache2.service" {
+		t.Errorf("Unexpected cgroup: %s", apache.CGroup)
+	}
+	if len(apache.Processes) != 2 || apache.Processes[1].PID != 12346 || apache.Processes[1].Command != "/usr/sbin/apache2 -k start" {
+		t.Errorf("Unexpected processes: %+v", apache.Processes)
+	}
+	if len(apache.Journal) != 2 {
+		t.Errorf("Expected 2 journal lines, got %d", len(apache.Journal))
+	}
+
+	backup := entries[1]
+	if backup.Unit != "backup.service" || backup.Description != "Nightly backup" {
+		t.Errorf("Unexpected unit %q - %q", backup.Unit, backup.Description)
+	}
+	if backup.Active != "failed" || backup.Sub != "failed" || backup.Result != "exit-code" {
+		t.Errorf("Unexpected failed state: %s/%s result %s", backup.Active, backup.Sub, backup.Result)
+	}
+	if backup.ActiveDurationSeconds != 97200 {
+		t.Errorf("Expected 97200 seconds, got %d", backup.ActiveDurationSeconds)
+	}
+	if backup.MainPID != 999 || backup.MainProcess != "code=exited, status=1/FAILURE" {
+		t.Errorf("Unexpected main process: %d %s", backup.MainPID, backup.MainProcess)
+	}
+	if backup.CPUSeconds < 0.3449 || backup.CPUSeconds > 0.3451 {
+		t.Errorf("Expected CPU seconds 0.345, got %f", backup.CPUSeconds)
+	}
+	if len(backup.Journal) != 0 {
+		t.Errorf("Expected no journal lines, got %v", backup.Journal)
+	}
+}
+
+func TestSystemctlParserListUnitFiles(t *testing.T) {
+	parser := &SystemctlParser{}
+
+	testInput := `UNIT FILE                          STATE           VENDOR PRESET
+apache2.service                    enabled         enabled
+getty@.service                     enabled         enabled
+systemd-fsck@.service              static          -
+
+3 unit files listed.`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]SystemctlUnitFile)
+	if !ok {
+		t.Fatalf("Expected []SystemctlUnitFile, got %T", result)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[2].UnitFile != "systemd-fsck@.service" || entries[2].State != "static" || entries[2].VendorPreset != "-" {
+		t.Errorf("Unexpected entry: %+v", entries[2])
+	}
+}
+
+func TestSystemctlParserListTimers(t *testing.T) {
+	parser := &SystemctlParser{}
+
+	testInput := `NEXT                        LEFT          LAST                        PASSED       UNIT                         ACTIVATES
+Tue 2023-01-17 00:00:00 UTC 9h left       Mon 2023-01-16 00:00:01 UTC 14h ago      logrotate.timer              logrotate.service
+n/a                         n/a           Mon 2023-01-16 06:12:44 UTC 8h ago       apt-daily.timer              apt-daily.service
+
+2 timers listed.
+Pass --all to see loaded but inactive timers, too.`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]SystemctlTimer)
+	if !ok {
+		t.Fatalf("Expected []SystemctlTimer, got %T", result)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Next == nil || entries[0].Next.Day() != 17 {
+		t.Errorf("Unexpected next: %v", entries[0].Next)
+	}
+	if entries[0].Left != "9h left" || entries[0].Passed != "14h ago" {
+		t.Errorf("Unexpected left/passed: %q %q", entries[0].Left, entries[0].Passed)
+	}
+	if entries[0].Unit != "logrotate.timer" || entries[0].Activates != "logrotate.service" {
+		t.Errorf("Unexpected unit/activates: %q %q", entries[0].Unit, entries[0].Activates)
+	}
+	if entries[1].Next != nil || entries[1].Last == nil {
+		t.Errorf("Expected n/a next and parsed last, got %v %v", entries[1].Next, entries[1].Last)
+	}
+}
+
+func TestSystemctlParserListSockets(t *testing.T) {
+	parser := &SystemctlParser{}
+
+	testInput := `LISTEN                          UNIT                         ACTIVATES
+/run/dbus/system_bus_socket     dbus.socket                  dbus.service
+[::]:22                         ssh.socket                   ssh.service
+kobject-uevent 1                systemd-udevd-kernel.socket  systemd-udevd.service
+
+3 sockets listed.`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries, ok := result.([]SystemctlSocket)
+	if !ok {
+		t.Fatalf("Expected []SystemctlSocket, got %T", result)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[2].Listen != "kobject-uevent 1" || entries[2].Unit != "systemd-udevd-kernel.socket" {
+		t.Errorf("Unexpected entry: %+v", entries[2])
+	}
+	if len(entries[1].Activates) != 1 || entries[1].Activates[0] != "ssh.service" {
+		t.Errorf("Unexpected activates: %v", entries[1].Activates)
+	}
+}
+
+func TestSystemctlParserShow(t *testing.T) {
+	parser := &SystemctlParser{}
+
+	result, err := parser.Parse(`Id=ssh.service
+ActiveState=active
+ExecMainPID=812`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	props, ok := result.([]map[string]string)
+	if !ok {
+		t.Fatalf("Expected []map[string]string, got %T", result)
+	}
+	if len(props) != 1 || props[0]["Id"] != "ssh.service" || props[0]["ExecMainPID"] != "812" {
+		t.Errorf("Unexpected properties: %v", props)
+	}
+
+	result, err = parser.Parse(`Id=ssh.service
+ActiveState=active
+
+Id=cron.service
+ActiveState=inactive`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	units, ok := result.([]map[string]string)
+	if !ok {
+		t.Fatalf("Expected []map[string]string, got %T", result)
+	}
+	if len(units) != 2 || units[1]["ActiveState"] != "inactive" {
+		t.Errorf("Unexpected units: %v", units)
+	}
+}
+
+func TestSystemctlParserStatusTimeZone(t *testing.T) {
+	testInput := `● cron.service - Regular background program processing daemon
+     Loaded: loaded (/lib/systemd/system/cron.service; enabled; preset: enabled)
+     Active: active (running) since Mon 2023-01-16 10:00:00 PST; 1h ago`
+
+	// An abbreviation that is not UTC or part of Location has no known offset
+	result, err := (&SystemctlParser{}).Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	entries := result.([]SystemctlEntry)
+	if entries[0].ActiveSince != nil {
+		t.Errorf("Expected no active since for an unknown zone, got %v", entries[0].ActiveSince)
+	}
+
+	parser := &SystemctlParser{Location: time.FixedZone("PST", -8*3600)}
+	result, err = parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	entries = result.([]SystemctlEntry)
+	if entries[0].ActiveSince == nil || !entries[0].ActiveSince.Equal(time.Date(2023, 1, 16, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected active since 18:00 UTC, got %v", entries[0].ActiveSince)
+	}
+}
@@ -2,23 +2,55 @@ package parsers
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// UnameParser parses uname command output
-type UnameParser struct{}
+// UnameParser parses uname command output. Flags holds the options uname
+// was run with (e.g. "-r" or "-snrm"); uname prints the selected values in
+// a fixed order, so they map onto fields without guessing. Without Flags,
+// output starting with a kernel name and a release is read as uname -a and
+// a single value is classified by its shape. The build date in the version
+// is read in Location (UTC when nil); it is left out when its zone is
+// neither UTC, GMT nor one of Location's.
+type UnameParser struct {
+	Flags    string
+	Location *time.Location
+}
 
-// UnameEntry represents uname output
+// UnameEntry represents uname output. Kernel is the release and version
+// strings broken into their parts.
 type UnameEntry struct {
-	KernelName    string `json:"kernel_name"`
-	NodeName      string `json:"node_name"`
-	KernelRelease string `json:"kernel_release"`
-	KernelVersion string `json:"kernel_version"`
-	Machine       string `json:"machine"`
-	Processor     string `json:"processor,omitempty"`
-	OS            string `json:"operating_system,omitempty"`
+	KernelName       string      `json:"kernel_name,omitempty"`
+	NodeName         string      `json:"node_name,omitempty"`
+	KernelRelease    string      `json:"kernel_release,omitempty"`
+	KernelVersion    string      `json:"kernel_version,omitempty"`
+	Machine          string      `json:"machine,omitempty"`
+	Processor        string      `json:"processor,omitempty"`
+	HardwarePlatform string      `json:"hardware_platform,omitempty"`
+	OS               string      `json:"operating_system,omitempty"`
+	Kernel           *KernelInfo `json:"kernel,omitempty"`
+}
+
+// KernelInfo holds the parts of a kernel release ("5.4.0-74-generic": 5, 4,
+// 0 and local version "-74-generic") and of the version string
+// ("#83-Ubuntu SMP Sat May 8 02:35:39 UTC 2021": build 83, options SMP and
+// the build date)
+type KernelInfo struct {
+	Major        int        `json:"major"`
+	Minor        int        `json:"minor"`
+	Patch        *int       `json:"patch,omitempty"`
+	LocalVersion string     `json:"local_version,omitempty"`
+	BuildNumber  *int       `json:"build_number,omitempty"`
+	BuildOptions []string   `json:"build_options,omitempty"`
+	BuildDate    *time.Time `json:"build_date,omitempty"`
 }
 
+// uname fields in output order, by option letter
+const unameOrder = "snrvmpio"
+
 func (p *UnameParser) Name() string {
 	return "uname"
 }
@@ -36,29 +68,258 @@ func (p *UnameParser) Parse(input string) (interface{}, error) {
 
 	entry := UnameEntry{}
 
-	// uname -a output: Linux hostname 5.4.0-74-generic #83-Ubuntu SMP Sat May 8 02:35:39 UTC 2021 x86_64 x86_64 x86_64 GNU/Linux
-	if len(fields) >= 1 {
-		entry.KernelName = fields[0]
+	selected := parseUnameFlags(p.Flags)
+	switch {
+	case selected != "" && selected != unameOrder:
+		assignUnameFields(&entry, fields, selected)
+	case selected == unameOrder || (len(fields) >= 4 && isUnameRelease(fields[2])):
+		parseUnameAll(&entry, fields)
+	case len(fields) == 1:
+		assignUnameFields(&entry, fields, guessUnameField(fields[0]))
+	default:
+		// Several values without flags: the version is the only one with
+		// spaces, so each value is classified on its own
+		for _, field := range fields {
+			assignUnameFields(&entry, []string{field}, guessUnameField(field))
+		}
 	}
-	if len(fields) >= 2 {
+
+	location := p.Location
+	if location == nil {
+		location = time.UTC
+	}
+	entry.Kernel = parseKernelInfo(entry.KernelRelease, entry.KernelVersion, location)
+	return entry, nil
+}
+
+// parseUnameAll maps uname -a output: kernel name, node name and release,
+// the version (which contains spaces), then machine, processor and
+// hardware platform and the operating system. GNU uname leaves out the
+// processor and hardware platform when they are unknown; BSD and macOS
+// print neither them nor the operating system.
+func parseUnameAll(entry *UnameEntry, fields []string) {
+	entry.KernelName = fields[0]
+	if len(fields) > 1 {
 		entry.NodeName = fields[1]
 	}
-	if len(fields) >= 3 {
+	if len(fields) > 2 {
 		entry.KernelRelease = fields[2]
 	}
-	if len(fields) >= 6 {
-		// Kernel version is typically multiple fields
-		entry.KernelVersion = strings.Join(fields[3:len(fields)-3], " ")
+	if len(fields) <= 3 {
+		return
 	}
-	if len(fields) >= 7 {
-		entry.Machine = fields[len(fields)-3]
+
+	rest := fields[3:]
+	if last := rest[len(rest)-1]; len(rest) > 1 && isUnameOS(last) {
+		entry.OS = last
+		rest = rest[:len(rest)-1]
 	}
-	if len(fields) >= 8 {
-		entry.Processor = fields[len(fields)-2]
+
+	var hardware []string
+	for len(rest) > 1 && len(hardware) < 3 && isUnameMachine(rest[len(rest)-1]) {
+		hardware = append([]string{rest[len(rest)-1]}, hardware...)
+		rest = rest[:len(rest)-1]
 	}
-	if len(fields) >= 9 {
-		entry.OS = fields[len(fields)-1]
+	assignUnameFields(entry, hardware, "mpi"[:len(hardware)])
+
+	entry.KernelVersion = strings.Join(rest, " ")
+}
+
+// assignUnameFields stores values printed for the given option letters, in
+// uname's output order. Only the version may take several values.
+func assignUnameFields(entry *UnameEntry, values []string, letters string) {
+	versionAt := strings.IndexByte(letters, 'v')
+	for i, letter := range letters {
+		var value string
+		switch {
+		case versionAt == -1 || i < versionAt:
+			if i >= len(values) {
+				return
+			}
+			value = values[i]
+		case i == versionAt:
+			// The version takes what the other fields leave over
+			end := len(values) - (len(letters) - versionAt - 1)
+			if end <= i {
+				return
+			}
+			value = strings.Join(values[i:end], " ")
+		default:
+			j := len(values) - (len(letters) - i)
+			if j < 0 || j >= len(values) {
+				continue
+			}
+			value = values[j]
+		}
+
+		switch letter {
+		case 's':
+			entry.KernelName = value
+		case 'n':
+			entry.NodeName = value
+		case 'r':
+			entry.KernelRelease = value
+		case 'v':
+			entry.KernelVersion = value
+		case 'm':
+			entry.Machine = value
+		case 'p':
+			entry.Processor = value
+		case 'i':
+			entry.HardwarePlatform = value
+		case 'o':
+			entry.OS = value
+		}
+	}
+}
+
+// parseUnameFlags returns the option letters uname was run with, in output
+// order. -a selects all of them.
+func parseUnameFlags(flags string) string {
+	longNames := map[string]byte{
+		"kernel-name": 's', "nodename": 'n', "kernel-release": 'r',
+		"kernel-version": 'v', "machine": 'm', "processor": 'p',
+		"hardware-platform": 'i', "operating-system": 'o',
 	}
 
-	return entry, nil
+	selected := map[byte]bool{}
+	for _, flag := range strings.Fields(flags) {
+		if name, ok := strings.CutPrefix(flag, "--"); ok {
+			if name == "all" {
+				return unameOrder
+			}
+			if letter, ok := longNames[name]; ok {
+				selected[letter] = true
+			}
+			continue
+		}
+		if !strings.HasPrefix(flag, "-") {
+			continue
+		}
+		for _, c := range []byte(flag[1:]) {
+			if c == 'a' {
+				return unameOrder
+			}
+			selected[c] = true
+		}
+	}
+
+	var letters []byte
+	for _, letter := range []byte(unameOrder) {
+		if selected[letter] {
+			letters = append(letters, letter)
+		}
+	}
+	return string(letters)
+}
+
+// guessUnameField tells which option printed a single value
+func guessUnameField(value string) string {
+	switch {
+	case strings.HasPrefix(value, "#"):
+		return "v"
+	case isUnameRelease(value):
+		return "r"
+	case isUnameMachine(value):
+		return "m"
+	case isUnameOS(value):
+		return "o"
+	case isUnameKernelName(value):
+		return "s"
+	default:
+		return "n"
+	}
+}
+
+// isUnameRelease reports whether value looks like a kernel release
+func isUnameRelease(value string) bool {
+	return regexp.MustCompile(`^\d+\.\d+`).MatchString(value)
+}
+
+// isUnameMachine reports whether value is a hardware name printed by
+// uname -m, -p or -i
+func isUnameMachine(value string) bool {
+	machineRe := regexp.MustCompile(`^(x86_64|amd64|i[3-6]86|aarch64|arm64|armv\w+|arm|ppc64le|ppc64|ppc|powerpc\w*|s390x?|riscv\d*|mips\w*|sparc\w*|loongarch64|alpha|ia64|unknown)$`)
+	return machineRe.MatchString(value)
+}
+
+// isUnameOS reports whether value is an operating system printed by uname -o
+func isUnameOS(value string) bool {
+	switch value {
+	case "GNU/Linux", "Android", "Cygwin", "Msys", "Toybox", "GNU/kFreeBSD", "GNU/Hurd":
+		return true
+	}
+	return false
+}
+
+// isUnameKernelName reports whether value is a kernel name printed by
+// uname -s. "Linux" is both a kernel name and, on some systems, the
+// operating system; it is taken as the kernel name.
+func isUnameKernelName(value string) bool {
+	switch value {
+	case "Linux", "Darwin", "FreeBSD", "OpenBSD", "NetBSD", "DragonFly", "SunOS", "AIX", "HP-UX", "GNU", "Haiku":
+		return true
+	}
+	return strings.HasPrefix(value, "CYGWIN") || strings.HasPrefix(value, "MINGW") || strings.HasPrefix(value, "MSYS")
+}
+
+// parseKernelInfo breaks a kernel release and version string into parts.
+// Build dates appear as "Sat May 8 02:35:39 UTC 2021", as a Debian package
+// date "(2024-02-01)" or as "@1700000000" seconds since the epoch, and are
+// read in location.
+func parseKernelInfo(release, version string, location *time.Location) *KernelInfo {
+	releaseRe := regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?(.*)$`)
+	matches := releaseRe.FindStringSubmatch(release)
+	if matches == nil {
+		return nil
+	}
+
+	info := &KernelInfo{
+		LocalVersion: matches[4],
+	}
+	info.Major, _ = strconv.Atoi(matches[1])
+	info.Minor, _ = strconv.Atoi(matches[2])
+	if matches[3] != "" {
+		patch, _ := strconv.Atoi(matches[3])
+		info.Patch = &patch
+	}
+
+	if version == "" {
+		return info
+	}
+
+	buildRe := regexp.MustCompile(`#(\d+)`)
+	dateRe := regexp.MustCompile(`[A-Z][a-z]{2} +[A-Z][a-z]{2} +\d{1,2} +\d{2}:\d{2}:\d{2}(?: +[A-Z]{2,5})? +\d{4}`)
+	debianDateRe := regexp.MustCompile(`\((\d{4}-\d{2}-\d{2})\)`)
+	epochRe := regexp.MustCompile(`(?:^|\s)@(\d+)(?:\s|$)`)
+
+	if m := buildRe.FindStringSubmatch(version); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		info.BuildNumber = &n
+	}
+	for _, field := range strings.Fields(version) {
+		if field == "SMP" || strings.HasPrefix(field, "PREEMPT") {
+			info.BuildOptions = append(info.BuildOptions, field)
+		}
+	}
+
+	if m := dateRe.FindString(version); m != "" {
+		date := strings.Join(strings.Fields(m), " ")
+		for _, layout := range []string{"Mon Jan 2 15:04:05 MST 2006", "Mon Jan 2 15:04:05 2006"} {
+			if t, err := parseZoneTime(layout, date, location); err == nil {
+				info.BuildDate = &t
+				break
+			}
+		}
+	} else if m := debianDateRe.FindStringSubmatch(version); m != nil {
+		if t, err := time.ParseInLocation("2006-01-02", m[1], location); err == nil {
+			info.BuildDate = &t
+		}
+	} else if m := epochRe.FindStringSubmatch(version); m != nil && m[1] != "0" {
+		if t, err := parseEpochTime(m[1], location); err == nil {
+			info.BuildDate = &t
+		}
+	}
+
+	return info
 }
@@ -2,6 +2,7 @@ package parsers
 
 import (
 	"testing"
+	"time"
 )
 
 func TestUnameParser(t *testing.T) {
@@ -49,3 +50,124 @@ func TestUnameParserEmpty(t *testing.T) {
 		t.Error("Expected error for whitespace-only input")
 	}
 }
+
+func TestUnameParserLayouts(t *testing.T) {
+	parser := &UnameParser{}
+
+	tests := []struct {
+		input                                string
+		release, version, machine, processor string
+		os                                   string
+	}{
+		// Processor and hardware platform left out when unknown
+		{"Linux web01 6.5.0-14-generic #14~22.04.1-Ubuntu SMP PREEMPT_DYNAMIC Mon Nov 20 18:15:30 UTC 2023 x86_64 GNU/Linux",
+			"6.5.0-14-generic", "#14~22.04.1-Ubuntu SMP PREEMPT_DYNAMIC Mon Nov 20 18:15:30 UTC 2023", "x86_64", "", "GNU/Linux"},
+		{"Linux db01 6.1.0-18-amd64 #1 SMP PREEMPT_DYNAMIC Debian 6.1.76-1 (2024-02-01) x86_64 GNU/Linux",
+			"6.1.0-18-amd64", "#1 SMP PREEMPT_DYNAMIC Debian 6.1.76-1 (2024-02-01)", "x86_64", "", "GNU/Linux"},
+		// macOS prints no operating system
+		{"Darwin mac.local 23.1.0 Darwin Kernel Version 23.1.0: Mon Oct  9 21:27:24 PDT 2023; root:xnu-10002.41.9~6/RELEASE_ARM64_T6000 arm64",
+			"23.1.0", "Darwin Kernel Version 23.1.0: Mon Oct 9 21:27:24 PDT 2023; root:xnu-10002.41.9~6/RELEASE_ARM64_T6000", "arm64", "", ""},
+		{"Linux pi 6.1.21-v8+ #1642 SMP PREEMPT Mon Apr  3 17:24:16 BST 2023 aarch64 unknown GNU/Linux",
+			"6.1.21-v8+", "#1642 SMP PREEMPT Mon Apr 3 17:24:16 BST 2023", "aarch64", "unknown", "GNU/Linux"},
+	}
+
+	for _, tt := range tests {
+		result, err := parser.Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+		}
+		entry := result.(UnameEntry)
+		if entry.KernelRelease != tt.release || entry.KernelVersion != tt.version || entry.Machine != tt.machine ||
+			entry.Processor != tt.processor || entry.OS != tt.os {
+			t.Errorf("Parse(%q): unexpected entry %+v", tt.input, entry)
+		}
+	}
+}
+
+func TestUnameParserKernelInfo(t *testing.T) {
+	parser := &UnameParser{}
+
+	result, err := parser.Parse("Linux hostname 5.4.0-74-generic #83-Ubuntu SMP Sat May 8 02:35:39 UTC 2021 x86_64 x86_64 x86_64 GNU/Linux")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	kernel := result.(UnameEntry).Kernel
+	if kernel == nil || kernel.Major != 5 || kernel.Minor != 4 || kernel.Patch == nil || *kernel.Patch != 0 ||
+		kernel.LocalVersion != "-74-generic" || kernel.BuildNumber == nil || *kernel.BuildNumber != 83 {
+		t.Fatalf("Unexpected kernel info %+v", kernel)
+	}
+	if len(kernel.BuildOptions) != 1 || kernel.BuildOptions[0] != "SMP" {
+		t.Errorf("Expected build options [SMP], got %v", kernel.BuildOptions)
+	}
+	if kernel.BuildDate == nil || kernel.BuildDate.Format("2006-01-02 15:04:05") != "2021-05-08 02:35:39" {
+		t.Errorf("Unexpected build date %v", kernel.BuildDate)
+	}
+
+	// Debian gives the package date
+	result, _ = parser.Parse("Linux db01 6.1.0-18-amd64 #1 SMP PREEMPT_DYNAMIC Debian 6.1.76-1 (2024-02-01) x86_64 GNU/Linux")
+	kernel = result.(UnameEntry).Kernel
+	if kernel.BuildDate == nil || kernel.BuildDate.Format("2006-01-02") != "2024-02-01" {
+		t.Errorf("Unexpected build date %v", kernel.BuildDate)
+	}
+	if len(kernel.BuildOptions) != 2 || kernel.BuildOptions[1] != "PREEMPT_DYNAMIC" {
+		t.Errorf("Unexpected build options %v", kernel.BuildOptions)
+	}
+}
+
+func TestUnameParserBuildDateZone(t *testing.T) {
+	input := "Linux build 6.5.0-1-custom #1 SMP Mon Nov 20 18:15:30 PST 2023 x86_64 GNU/Linux"
+
+	// PST has no known offset without a Location that uses it
+	result, err := (&UnameParser{}).Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if kernel := result.(UnameEntry).Kernel; kernel == nil || kernel.BuildDate != nil {
+		t.Errorf("Expected no build date for an unknown zone, got %+v", kernel)
+	}
+
+	parser := &UnameParser{Location: time.FixedZone("PST", -8*3600)}
+	result, err = parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	kernel := result.(UnameEntry).Kernel
+	if kernel == nil || kernel.BuildDate == nil || !kernel.BuildDate.Equal(time.Date(2023, 11, 21, 2, 15, 30, 0, time.UTC)) {
+		t.Errorf("Expected build date 2023-11-21 02:15:30 UTC, got %+v", kernel)
+	}
+}
+
+func TestUnameParserSingleFlags(t *testing.T) {
+	tests := []struct {
+		flags, input string
+		want         UnameEntry
+	}{
+		{"-r", "5.15.0-91-generic", UnameEntry{KernelRelease: "5.15.0-91-generic"}},
+		{"-m", "x86_64", UnameEntry{Machine: "x86_64"}},
+		{"-s", "Linux", UnameEntry{KernelName: "Linux"}},
+		{"-n", "web01", UnameEntry{NodeName: "web01"}},
+		{"-v", "#1 SMP PREEMPT_DYNAMIC Thu Jan  4 21:15:25 UTC 2024", UnameEntry{KernelVersion: "#1 SMP PREEMPT_DYNAMIC Thu Jan 4 21:15:25 UTC 2024"}},
+		{"-srm", "Linux 5.15.0-91-generic x86_64", UnameEntry{KernelName: "Linux", KernelRelease: "5.15.0-91-generic", Machine: "x86_64"}},
+		{"-rvm", "6.6.9 #1 SMP PREEMPT_DYNAMIC Thu Jan  4 21:15:25 UTC 2024 aarch64", UnameEntry{KernelRelease: "6.6.9", KernelVersion: "#1 SMP PREEMPT_DYNAMIC Thu Jan 4 21:15:25 UTC 2024", Machine: "aarch64"}},
+		// Guessed without flags
+		{"", "5.15.0-91-generic", UnameEntry{KernelRelease: "5.15.0-91-generic"}},
+		{"", "aarch64", UnameEntry{Machine: "aarch64"}},
+		{"", "GNU/Linux", UnameEntry{OS: "GNU/Linux"}},
+		{"", "Linux", UnameEntry{KernelName: "Linux"}},
+		{"", "web01", UnameEntry{NodeName: "web01"}},
+	}
+
+	for _, tt := range tests {
+		parser := &UnameParser{Flags: tt.flags}
+		result, err := parser.Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+		}
+		entry := result.(UnameEntry)
+		entry.Kernel = nil
+		if entry != tt.want {
+			t.Errorf("Parse(%q) with %q: expected %+v, got %+v", tt.input, tt.flags, tt.want, entry)
+		}
+	}
+}
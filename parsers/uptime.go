@@ -5,20 +5,38 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
-// UptimeParser parses uptime command output
-type UptimeParser struct{}
+// UptimeParser parses uptime command output from procps, busybox and
+// BSD/macOS, as well as uptime -p ("up 2 weeks, 3 days") and uptime -s
+// (the boot timestamp). The clock time in the output is placed on the date
+// of ReferenceTime (the current time when zero) in Location (UTC when nil),
+// and the boot time is computed from it.
+type UptimeParser struct {
+	ReferenceTime time.Time
+	Location      *time.Location
+}
 
 // UptimeEntry represents uptime output
 type UptimeEntry struct {
-	CurrentTime   string  `json:"current_time"`
-	Uptime        string  `json:"uptime"`
-	UptimeSeconds int     `json:"uptime_seconds"`
-	Users         int     `json:"users"`
-	LoadAvg1      float64 `json:"load_avg_1"`
-	LoadAvg5      float64 `json:"load_avg_5"`
-	LoadAvg15     float64 `json:"load_avg_15"`
+	CurrentTime   *time.Time      `json:"current_time,omitempty"`
+	BootTime      *time.Time      `json:"boot_time,omitempty"`
+	Uptime        string          `json:"uptime"`
+	UptimeSeconds int             `json:"uptime_seconds"`
+	Duration      *UptimeDuration `json:"duration,omitempty"`
+	Users         int             `json:"users"`
+	LoadAvg1      float64         `json:"load_avg_1"`
+	LoadAvg5      float64         `json:"load_avg_5"`
+	LoadAvg15     float64         `json:"load_avg_15"`
+}
+
+// UptimeDuration is the uptime split into days, hours, minutes and seconds
+type UptimeDuration struct {
+	Days    int `json:"days"`
+	Hours   int `json:"hours"`
+	Minutes int `json:"minutes"`
+	Seconds int `json:"seconds"`
 }
 
 func (p *UptimeParser) Name() string {
@@ -31,75 +49,115 @@ func (p *UptimeParser) Parse(input string) (interface{}, error) {
 		return nil, fmt.Errorf("empty input")
 	}
 
-	entry := UptimeEntry{}
+	clock := newLsClock(p.ReferenceTime, p.Location)
 
-	// Example: " 14:30:42 up 12 days,  3:45,  2 users,  load average: 0.15, 0.12, 0.10"
-	
-	// Extract current time
-	timeRe := regexp.MustCompile(`^\s*(\d{1,2}:\d{2}:\d{2})`)
-	if matches := timeRe.FindStringSubmatch(input); len(matches) > 1 {
-		entry.CurrentTime = matches[1]
+	// uptime -s: "2024-01-15 10:45:12"
+	if boot, err := time.ParseInLocation("2006-01-02 15:04:05", input, clock.location); err == nil {
+		return UptimeEntry{BootTime: &boot}, nil
 	}
 
-	// Extract uptime
-	uptimeRe := regexp.MustCompile(`up\s+(.+?),\s+\d+\s+users?`)
-	if matches := uptimeRe.FindStringSubmatch(input); len(matches) > 1 {
-		entry.Uptime = strings.TrimSpace(matches[1])
+	return parseUptimeLine(input, clock), nil
+}
+
+// parseUptimeLine parses the uptime line shared by uptime and w:
+//
+//	14:30:42 up 12 days,  3:45,  2 users,  load average: 0.15, 0.12, 0.10
+//	14:30:42 up 45 min,  load average: 0.15, 0.12, 0.10 (busybox)
+//	2:30PM  up 1 day, 3 hrs, 1 user, load averages: 1.52 1.63 1.71 (BSD/macOS)
+//	up 2 weeks, 3 days, 4 hours, 5 minutes (uptime -p)
+func parseUptimeLine(line string, clock lsClock) UptimeEntry {
+	entry := UptimeEntry{}
+
+	timeRe := regexp.MustCompile(`^(\d{1,2}:\d{2}(?::\d{2})?(?:\s?[AaPp][Mm])?)\s+up\s`)
+	uptimeRe := regexp.MustCompile(`\bup\s+(.+?)\s*(?:,\s*\d+\s+users?\b|,\s*load averages?:|$)`)
+	usersRe := regexp.MustCompile(`(\d+)\s+users?\b`)
+	loadRe := regexp.MustCompile(`load averages?:\s*([0-9.]+),?\s+([0-9.]+),?\s+([0-9.]+)`)
+
+	if matches := uptimeRe.FindStringSubmatch(line); matches != nil {
+		entry.Uptime = strings.TrimSuffix(matches[1], ",")
 		entry.UptimeSeconds = parseUptimeToSeconds(entry.Uptime)
+		entry.Duration = &UptimeDuration{
+			Days:    entry.UptimeSeconds / 86400,
+			Hours:   entry.UptimeSeconds % 86400 / 3600,
+			Minutes: entry.UptimeSeconds % 3600 / 60,
+			Seconds: entry.UptimeSeconds % 60,
+		}
 	}
 
-	// Extract users
-	usersRe := regexp.MustCompile(`(\d+)\s+users?`)
-	if matches := usersRe.FindStringSubmatch(input); len(matches) > 1 {
-		if users, err := strconv.Atoi(matches[1]); err == nil {
-			entry.Users = users
-		}
+	if matches := usersRe.FindStringSubmatch(line); matches != nil {
+		entry.Users, _ = strconv.Atoi(matches[1])
 	}
 
-	// Extract load averages
-	loadRe := regexp.MustCompile(`load average:\s*([0-9.]+),\s*([0-9.]+),\s*([0-9.]+)`)
-	if matches := loadRe.FindStringSubmatch(input); len(matches) > 3 {
-		if load1, err := strconv.ParseFloat(matches[1], 64); err == nil {
-			entry.LoadAvg1 = load1
-		}
-		if load5, err := strconv.ParseFloat(matches[2], 64); err == nil {
-			entry.LoadAvg5 = load5
-		}
-		if load15, err := strconv.ParseFloat(matches[3], 64); err == nil {
-			entry.LoadAvg15 = load15
+	if matches := loadRe.FindStringSubmatch(line); matches != nil {
+		entry.LoadAvg1, _ = strconv.ParseFloat(matches[1], 64)
+		entry.LoadAvg5, _ = strconv.ParseFloat(matches[2], 64)
+		entry.LoadAvg15, _ = strconv.ParseFloat(matches[3], 64)
+	}
+
+	// uptime -p has no clock time; the uptime runs up to the reference time
+	now := clock.now.Truncate(time.Second)
+	if matches := timeRe.FindStringSubmatch(line); matches != nil {
+		current, ok := parseUptimeClock(matches[1], clock)
+		if !ok {
+			return entry
 		}
+		entry.CurrentTime = &current
+		now = current
+	}
+	if entry.Duration != nil {
+		boot := now.Add(-time.Duration(entry.UptimeSeconds) * time.Second)
+		entry.BootTime = &boot
 	}
 
-	return entry, nil
+	return entry
 }
 
-func parseUptimeToSeconds(uptime string) int {
-	seconds := 0
-	
-	// Parse days
-	dayRe := regexp.MustCompile(`(\d+)\s+days?`)
-	if matches := dayRe.FindStringSubmatch(uptime); len(matches) > 1 {
-		if days, err := strconv.Atoi(matches[1]); err == nil {
-			seconds += days * 24 * 3600
+// parseUptimeClock places a clock time such as "14:30:42", "14:30" or
+// "2:30PM" on the reference date, or the day before when it would
+// otherwise be later than the reference time
+func parseUptimeClock(value string, clock lsClock) (time.Time, bool) {
+	value = strings.ToUpper(strings.ReplaceAll(value, " ", ""))
+	for _, layout := range []string{"15:04:05", "15:04", "3:04PM", "3:04:05PM"} {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			continue
 		}
+		now := clock.now
+		current := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, clock.location)
+		if current.After(now.Add(time.Minute)) {
+			current = current.AddDate(0, 0, -1)
+		}
+		return current, true
 	}
+	return time.Time{}, false
+}
 
-	// Parse hours and minutes
-	timeRe := regexp.MustCompile(`(\d+):(\d+)`)
-	if matches := timeRe.FindStringSubmatch(uptime); len(matches) > 2 {
-		if hours, err := strconv.Atoi(matches[1]); err == nil {
-			seconds += hours * 3600
-		}
-		if minutes, err := strconv.Atoi(matches[2]); err == nil {
-			seconds += minutes * 60
-		}
+// parseUptimeToSeconds converts the uptime part of the line into seconds.
+// It is a comma separated list of "H:MM" and counts of years, weeks, days,
+// hours, minutes or seconds, e.g. "12 days,  3:45", "2 days, 3 min",
+// "45 secs", "1 day, 3 hrs" or "2 weeks, 3 days, 4 hours, 5 minutes".
+func parseUptimeToSeconds(uptime string) int {
+	clockRe := regexp.MustCompile(`^(\d+):(\d{2})$`)
+	countRe := regexp.MustCompile(`^(\d+)\s*([a-z]+)$`)
+	units := map[string]int{
+		"year": 365 * 86400, "years": 365 * 86400,
+		"week": 7 * 86400, "weeks": 7 * 86400,
+		"day": 86400, "days": 86400,
+		"hour": 3600, "hours": 3600, "hr": 3600, "hrs": 3600, "h": 3600,
+		"minute": 60, "minutes": 60, "min": 60, "mins": 60, "m": 60,
+		"second": 1, "seconds": 1, "sec": 1, "secs": 1, "s": 1,
 	}
 
-	// Parse minutes only (e.g., "5 min")
-	minRe := regexp.MustCompile(`(\d+)\s+min`)
-	if matches := minRe.FindStringSubmatch(uptime); len(matches) > 1 {
-		if minutes, err := strconv.Atoi(matches[1]); err == nil {
-			seconds += minutes * 60
+	seconds := 0
+	for _, part := range strings.Split(uptime, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if matches := clockRe.FindStringSubmatch(part); matches != nil {
+			hours, _ := strconv.Atoi(matches[1])
+			minutes, _ := strconv.Atoi(matches[2])
+			seconds += hours*3600 + minutes*60
+		} else if matches := countRe.FindStringSubmatch(part); matches != nil {
+			count, _ := strconv.Atoi(matches[1])
+			seconds += count * units[matches[2]]
 		}
 	}
 
@@ -3,6 +3,7 @@ package parsers
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestUptimeParser(t *testing.T) {
@@ -21,8 +22,8 @@ func TestUptimeParser(t *testing.T) {
 	}
 
 	// Test current time
-	if entry.CurrentTime != "14:30:42" {
-		t.Errorf("Expected current time '14:30:42', got '%s'", entry.CurrentTime)
+	if entry.CurrentTime == nil || entry.CurrentTime.Format("15:04:05") != "14:30:42" {
+		t.Errorf("Expected current time '14:30:42', got %v", entry.CurrentTime)
 	}
 
 	// Test uptime
@@ -130,3 +131,109 @@ func TestUptimeParserEmpty(t *testing.T) {
 		t.Error("Expected error for whitespace-only input")
 	}
 }
+
+func TestUptimeParserDurations(t *testing.T) {
+	parser := &UptimeParser{}
+
+	tests := []struct {
+		input   string
+		uptime  string
+		seconds int
+		users   int
+	}{
+		{" 10:05:15 up 2 days, 3 min,  1 user,  load average: 0.00, 0.01, 0.05", "2 days, 3 min", 2*86400 + 3*60, 1},
+		{" 10:05:15 up 45 sec,  0 users,  load average: 0.00, 0.01, 0.05", "45 sec", 45, 0},
+		{" 10:05:15 up 1 day,  4 users,  load average: 0.00, 0.01, 0.05", "1 day", 86400, 4},
+		{" 10:05:15 up 1 day, 10 min,  1 user,  load average: 0.00, 0.01, 0.05", "1 day, 10 min", 86400 + 600, 1},
+		// busybox omits the user count
+		{" 10:05:15 up 3 min,  load average: 0.00, 0.01, 0.05", "3 min", 180, 0},
+		// macOS
+		{"10:05  up 5 mins, 2 users, load averages: 1.52 1.63 1.71", "5 mins", 300, 2},
+		{"10:05  up 1 day, 3 hrs, 2 users, load averages: 1.52 1.63 1.71", "1 day, 3 hrs", 86400 + 3*3600, 2},
+		{"10:05  up 45 secs, 1 user, load averages: 1.52 1.63 1.71", "45 secs", 45, 1},
+		// FreeBSD
+		{" 2:30PM  up 12 days,  3:45, 2 users, load averages: 0.15, 0.12, 0.10", "12 days,  3:45", 12*86400 + 3*3600 + 45*60, 2},
+	}
+
+	for _, tt := range tests {
+		result, err := parser.Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+		}
+		entry := result.(UptimeEntry)
+		if entry.Uptime != tt.uptime {
+			t.Errorf("Parse(%q): expected uptime %q, got %q", tt.input, tt.uptime, entry.Uptime)
+		}
+		if entry.UptimeSeconds != tt.seconds {
+			t.Errorf("Parse(%q): expected %d seconds, got %d", tt.input, tt.seconds, entry.UptimeSeconds)
+		}
+		if entry.Users != tt.users {
+			t.Errorf("Parse(%q): expected %d users, got %d", tt.input, tt.users, entry.Users)
+		}
+		if entry.CurrentTime == nil {
+			t.Errorf("Parse(%q): expected a current time", tt.input)
+		}
+	}
+}
+
+func TestUptimeParserBootTime(t *testing.T) {
+	parser := &UptimeParser{
+		ReferenceTime: time.Date(2024, 1, 15, 14, 35, 0, 0, time.UTC),
+	}
+
+	result, err := parser.Parse(" 14:30:42 up 1 day,  3:45,  2 users,  load average: 0.15, 0.12, 0.10")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entry := result.(UptimeEntry)
+	if want := time.Date(2024, 1, 15, 14, 30, 42, 0, time.UTC); entry.CurrentTime == nil || !entry.CurrentTime.Equal(want) {
+		t.Errorf("Expected current time %v, got %v", want, entry.CurrentTime)
+	}
+	if want := time.Date(2024, 1, 14, 10, 45, 42, 0, time.UTC); entry.BootTime == nil || !entry.BootTime.Equal(want) {
+		t.Errorf("Expected boot time %v, got %v", want, entry.BootTime)
+	}
+	if d := entry.Duration; d == nil || d.Days != 1 || d.Hours != 3 || d.Minutes != 45 || d.Seconds != 0 {
+		t.Errorf("Unexpected duration %+v", entry.Duration)
+	}
+
+	// A clock time later than the reference time is from the day before
+	result, _ = parser.Parse(" 23:50:00 up 10 min,  1 user,  load average: 0.15, 0.12, 0.10")
+	entry = result.(UptimeEntry)
+	if want := time.Date(2024, 1, 14, 23, 50, 0, 0, time.UTC); entry.CurrentTime == nil || !entry.CurrentTime.Equal(want) {
+		t.Errorf("Expected current time %v, got %v", want, entry.CurrentTime)
+	}
+}
+
+func TestUptimeParserPrettyAndSince(t *testing.T) {
+	parser := &UptimeParser{
+		ReferenceTime: time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC),
+	}
+
+	result, err := parser.Parse("up 2 weeks, 3 days, 4 hours, 5 minutes")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entry := result.(UptimeEntry)
+	expectedSeconds := 17*86400 + 4*3600 + 5*60
+	if entry.UptimeSeconds != expectedSeconds {
+		t.Errorf("Expected %d seconds, got %d", expectedSeconds, entry.UptimeSeconds)
+	}
+	if entry.CurrentTime != nil {
+		t.Errorf("Expected no current time, got %v", entry.CurrentTime)
+	}
+	if want := time.Date(2024, 1, 14, 7, 55, 0, 0, time.UTC); entry.BootTime == nil || !entry.BootTime.Equal(want) {
+		t.Errorf("Expected boot time %v, got %v", want, entry.BootTime)
+	}
+
+	result, err = parser.Parse("2024-01-15 10:45:12")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entry = result.(UptimeEntry)
+	if want := time.Date(2024, 1, 15, 10, 45, 12, 0, time.UTC); entry.BootTime == nil || !entry.BootTime.Equal(want) {
+		t.Errorf("Expected boot time %v, got %v", want, entry.BootTime)
+	}
+}
@@ -4,36 +4,43 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
-// WParser parses w command output
-type WParser struct{}
+// WParser parses w command output. The uptime line is parsed as by
+// UptimeParser, using ReferenceTime and Location the same way. The user
+// table may be headed by procps columns (LOGIN@ IDLE JCPU PCPU WHAT) or
+// BSD/macOS columns (LOGIN@ IDLE WHAT), and FROM is optional. With w -h
+// neither the uptime line nor the column headers are printed.
+type WParser struct {
+	ReferenceTime time.Time
+	Location      *time.Location
+}
 
-// WEntry represents a single w output entry
+// WEntry represents a single w output entry. The idle and CPU times are
+// also given in seconds when their format is recognised.
 type WEntry struct {
-	User    string  `json:"user"`
-	TTY     string  `json:"tty"`
-	From    string  `json:"from,omitempty"`
-	Login   string  `json:"login"`
-	Idle    string  `json:"idle"`
-	JCPU    string  `json:"jcpu,omitempty"`
-	PCPU    string  `json:"pcpu,omitempty"`
-	What    string  `json:"what"`
+	User        string   `json:"user"`
+	TTY         string   `json:"tty"`
+	From        string   `json:"from,omitempty"`
+	Login       string   `json:"login"`
+	Idle        string   `json:"idle"`
+	IdleSeconds *float64 `json:"idle_seconds,omitempty"`
+	JCPU        string   `json:"jcpu,omitempty"`
+	JCPUSeconds *float64 `json:"jcpu_seconds,omitempty"`
+	PCPU        string   `json:"pcpu,omitempty"`
+	PCPUSeconds *float64 `json:"pcpu_seconds,omitempty"`
+	What        string   `json:"what"`
 }
 
-// WHeader represents the header info from w command
+// WHeader represents the uptime line heading w output
 type WHeader struct {
-	CurrentTime   string  `json:"current_time"`
-	Uptime        string  `json:"uptime"`
-	Users         int     `json:"users"`
-	LoadAvg1      float64 `json:"load_avg_1"`
-	LoadAvg5      float64 `json:"load_avg_5"`
-	LoadAvg15     float64 `json:"load_avg_15"`
+	UptimeEntry
 }
 
 // WOutput represents the complete w command output
 type WOutput struct {
-	Header WHeader `json:"header"`
+	Header WHeader  `json:"header"`
 	Users  []WEntry `json:"users"`
 }
 
@@ -48,21 +55,34 @@ func (p *WParser) Parse(input string) (interface{}, error) {
 	}
 
 	lines := splitLines(input)
-	if len(lines) < 2 {
-		return nil, fmt.Errorf("insufficient lines in w output")
+	output := WOutput{
+		Users: []WEntry{},
 	}
 
-	output := WOutput{}
-
-	// Parse header (first line)
-	headerLine := lines[0]
-	output.Header = parseWHeader(headerLine)
+	// w -h leaves out the uptime line and the column headers, so user rows
+	// start after whichever of them are present
+	rows := lines
+	hasHeader := strings.Contains(rows[0], "load average") || strings.Contains(rows[0], " up ")
+	if hasHeader {
+		output.Header = parseWHeader(rows[0], newLsClock(p.ReferenceTime, p.Location))
+		rows = rows[1:]
+	}
 
-	// Skip the column headers line (second line)
-	dataLines := lines[2:]
-	var entries []WEntry
+	// The column headers line tells which columns are present
+	var columns []string
+	if len(rows) > 0 {
+		columns = splitFields(rows[0])
+	}
+	hasFrom := findInSlice(columns, "FROM") >= 0
+	hasCPU := findInSlice(columns, "JCPU") >= 0 || findInSlice(columns, "PCPU") >= 0
+	if findInSlice(columns, "USER") == -1 {
+		hasFrom, hasCPU = true, true
+		columns = nil
+	} else {
+		rows = rows[1:]
+	}
 
-	for _, line := range dataLines {
+	for _, line := range rows {
 		fields := splitFields(line)
 		if len(fields) < 4 {
 			continue
@@ -73,8 +93,11 @@ func (p *WParser) Parse(input string) (interface{}, error) {
 		entry.TTY = fields[1]
 
 		fieldIndex := 2
-		// Check if FROM field is present (not always there)
-		if len(fields) > 6 && !strings.Contains(fields[2], ":") {
+		// Without a header, FROM is told apart by the login time's colon
+		if columns == nil {
+			hasFrom = len(fields) > 6 && !strings.Contains(fields[2], ":")
+		}
+		if hasFrom {
 			entry.From = fields[2]
 			fieldIndex = 3
 		}
@@ -85,78 +108,87 @@ func (p *WParser) Parse(input string) (interface{}, error) {
 		if fieldIndex+1 < len(fields) {
 			entry.Idle = fields[fieldIndex+1]
 		}
-		if fieldIndex+2 < len(fields) {
-			entry.JCPU = fields[fieldIndex+2]
-		}
-		if fieldIndex+3 < len(fields) {
-			entry.PCPU = fields[fieldIndex+3]
+		whatIndex := fieldIndex + 2
+		if hasCPU {
+			if fieldIndex+2 < len(fields) {
+				entry.JCPU = fields[fieldIndex+2]
+			}
+			if fieldIndex+3 < len(fields) {
+				entry.PCPU = fields[fieldIndex+3]
+			}
+			whatIndex = fieldIndex + 4
 		}
-		if fieldIndex+4 < len(fields) {
-			entry.What = strings.Join(fields[fieldIndex+4:], " ")
+		if whatIndex < len(fields) {
+			entry.What = strings.Join(fields[whatIndex:], " ")
 		}
 
-		entries = append(entries, entry)
+		// procps prints M:SS below an hour; BSD prints H:MM
+		entry.IdleSeconds = parseWDuration(entry.Idle, !hasCPU)
+		entry.JCPUSeconds = parseWDuration(entry.JCPU, false)
+		entry.PCPUSeconds = parseWDuration(entry.PCPU, false)
+
+		output.Users = append(output.Users, entry)
 	}
 
-	output.Users = entries
+	if !hasHeader && columns == nil && len(output.Users) == 0 {
+		return nil, fmt.Errorf("no uptime line or user rows in w output")
+	}
 	return output, nil
 }
 
-func parseWHeader(headerLine string) WHeader {
-	header := WHeader{}
-
-	// Example: " 14:30:42 up 12 days,  3:45,  2 users,  load average: 0.15, 0.12, 0.10"
-	
-	// Extract current time
-	parts := strings.Fields(headerLine)
-	if len(parts) > 0 {
-		header.CurrentTime = parts[0]
+func parseWHeader(headerLine string, clock lsClock) WHeader {
+	return WHeader{
+		UptimeEntry: parseUptimeLine(headerLine, clock),
 	}
+}
 
-	// Parse users count
-	for i, part := range parts {
-		if strings.Contains(part, "user") && i > 0 {
-			if users, err := strconv.Atoi(parts[i-1]); err == nil {
-				header.Users = users
-			}
-			break
+// parseWDuration decodes w's idle and CPU time columns into seconds. procps
+// prints "5.00s" below a minute, "2:13" (minutes:seconds) below an hour,
+// "2:15m" (hours:minutes) below two days and "3days" beyond. BSD prints
+// idle minutes as "5", hours as "2:13" and days as "3days"; "-" means not
+// idle.
+func parseWDuration(value string, bsd bool) *float64 {
+	var seconds float64
+	switch {
+	case value == "":
+		return nil
+	case value == "-" && bsd:
+		seconds = 0
+	case strings.HasSuffix(value, "days"):
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "days"))
+		if err != nil {
+			return nil
 		}
-	}
-
-	// Parse uptime (simplified)
-	if upIdx := findInSlice(parts, "up"); upIdx >= 0 && upIdx+1 < len(parts) {
-		// Find the uptime portion
-		uptimeParts := []string{}
-		for i := upIdx + 1; i < len(parts); i++ {
-			if strings.Contains(parts[i], "user") {
-				break
-			}
-			uptimeParts = append(uptimeParts, parts[i])
+		seconds = float64(days) * 86400
+	case strings.HasSuffix(value, "s"):
+		s, err := strconv.ParseFloat(strings.TrimSuffix(value, "s"), 64)
+		if err != nil {
+			return nil
 		}
-		header.Uptime = strings.Join(uptimeParts, " ")
-		header.Uptime = strings.TrimSuffix(header.Uptime, ",")
-	}
-
-	// Parse load averages
-	if avgIdx := findInSlice(parts, "average:"); avgIdx >= 0 {
-		if avgIdx+1 < len(parts) {
-			if load1, err := strconv.ParseFloat(strings.TrimSuffix(parts[avgIdx+1], ","), 64); err == nil {
-				header.LoadAvg1 = load1
-			}
+		seconds = s
+	case strings.Contains(value, ":"):
+		hoursMinutes := bsd || strings.HasSuffix(value, "m")
+		high, low, _ := strings.Cut(strings.TrimSuffix(value, "m"), ":")
+		h, err1 := strconv.Atoi(high)
+		l, err2 := strconv.Atoi(low)
+		if err1 != nil || err2 != nil {
+			return nil
 		}
-		if avgIdx+2 < len(parts) {
-			if load5, err := strconv.ParseFloat(strings.TrimSuffix(parts[avgIdx+2], ","), 64); err == nil {
-				header.LoadAvg5 = load5
-			}
+		if hoursMinutes {
+			seconds = float64(h*3600 + l*60)
+		} else {
+			seconds = float64(h*60 + l)
 		}
-		if avgIdx+3 < len(parts) {
-			if load15, err := strconv.ParseFloat(parts[avgIdx+3], 64); err == nil {
-				header.LoadAvg15 = load15
-			}
+	case bsd:
+		minutes, err := strconv.Atoi(value)
+		if err != nil {
+			return nil
 		}
+		seconds = float64(minutes) * 60
+	default:
+		return nil
 	}
-
-	return header
+	return &seconds
 }
 
 func findInSlice(slice []string, target string) int {
@@ -25,8 +25,8 @@ user     pts/1    :0               13:45    0.00s  0.35s  0.03s vim test.txt`
 	}
 
 	// Test header
-	if output.Header.CurrentTime != "14:30:42" {
-		t.Errorf("Expected current time '14:30:42', got '%s'", output.Header.CurrentTime)
+	if output.Header.CurrentTime == nil || output.Header.CurrentTime.Format("15:04:05") != "14:30:42" {
+		t.Errorf("Expected current time '14:30:42', got %v", output.Header.CurrentTime)
 	}
 	if output.Header.Users != 2 {
 		t.Errorf("Expected 2 users, got %d", output.Header.Users)
@@ -129,6 +129,55 @@ testuser pts/0    10:00    0.00s  bash`
 	}
 }
 
+func TestWParserNoColumnHeaders(t *testing.T) {
+	parser := &WParser{}
+
+	rows := `alice    pts/0    192.168.1.10     09:15    1:02m  0.10s  0.02s bash
+bob      pts/1    192.168.1.11     10:02    0.00s  0.05s  0.00s vim notes`
+
+	// With the uptime line but no column headers, and with neither (w -h)
+	for _, testInput := range []string{
+		" 10:15:30 up 1 day,  5:25,  2 users,  load average: 0.05, 0.03, 0.01\n" + rows,
+		rows,
+	} {
+		result, err := parser.Parse(testInput)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+
+		output := result.(WOutput)
+		if len(output.Users) != 2 {
+			t.Fatalf("Expected 2 user entries, got %d", len(output.Users))
+		}
+		if output.Users[0].User != "alice" || output.Users[0].From != "192.168.1.10" {
+			t.Errorf("Unexpected first entry: %+v", output.Users[0])
+		}
+		if output.Users[1].User != "bob" || output.Users[1].What != "vim notes" {
+			t.Errorf("Unexpected second entry: %+v", output.Users[1])
+		}
+	}
+
+	// w -h with one logged-in user
+	result, err := parser.Parse("alice    pts/0    192.168.1.10     09:15    1:02m  0.10s  0.02s bash")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	output := result.(WOutput)
+	if len(output.Users) != 1 || output.Users[0].User != "alice" || output.Users[0].What != "bash" {
+		t.Errorf("Unexpected users: %+v", output.Users)
+	}
+
+	// The uptime line alone: nobody logged in
+	result, err = parser.Parse(" 10:15:30 up 1 day,  5:25,  0 users,  load average: 0.05, 0.03, 0.01")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	output = result.(WOutput)
+	if output.Users == nil || len(output.Users) != 0 || output.Header.UptimeSeconds != 105900 {
+		t.Errorf("Expected empty users and the uptime, got %+v", output)
+	}
+}
+
 func TestWParserEmpty(t *testing.T) {
 	parser := &WParser{}
 	
@@ -148,3 +197,82 @@ func TestWParserEmpty(t *testing.T) {
 		t.Error("Expected error for insufficient lines")
 	}
 }
+
+func TestWParserBSD(t *testing.T) {
+	parser := &WParser{}
+
+	testInput := `10:15  up 2 days, 3 hrs, 2 users, load averages: 1.52 1.63 1.71
+USER     TTY      FROM              LOGIN@  IDLE WHAT
+alice    console  -                Mon09   2days -
+alice    s000     192.168.1.20     10:02       - vim notes.txt`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	output := result.(WOutput)
+	if output.Header.UptimeSeconds != 2*86400+3*3600 || output.Header.Users != 2 || output.Header.LoadAvg15 != 1.71 {
+		t.Errorf("Unexpected header %+v", output.Header)
+	}
+	if len(output.Users) != 2 {
+		t.Fatalf("Expected 2 user entries, got %d", len(output.Users))
+	}
+
+	user := output.Users[1]
+	if user.From != "192.168.1.20" || user.Login != "10:02" || user.Idle != "-" || user.JCPU != "" || user.What != "vim notes.txt" {
+		t.Errorf("Unexpected entry %+v", user)
+	}
+}
+
+func TestWParserDurations(t *testing.T) {
+	parser := &WParser{}
+
+	testInput := ` 14:30:42 up 12 days,  3:45,  2 users,  load average: 0.15, 0.12, 0.10
+USER     TTY      FROM             LOGIN@   IDLE   JCPU   PCPU WHAT
+user     pts/0    192.168.1.100    14:20    5.00s  2:13   0.01s ssh server1
+root     tty1     -                09:00    2:15m  1:02m  0.02s -bash
+admin    pts/1    10.0.0.5         Mon09    3days  0.35s  0.03s vim test.txt`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	output := result.(WOutput)
+	tests := []struct {
+		idle, jcpu, pcpu float64
+	}{
+		{5, 133, 0.01},
+		{2*3600 + 15*60, 3600 + 2*60, 0.02},
+		{3 * 86400, 0.35, 0.03},
+	}
+	for i, want := range tests {
+		got := output.Users[i]
+		if got.IdleSeconds == nil || *got.IdleSeconds != want.idle {
+			t.Errorf("Entry %d: expected idle %v, got %v", i, want.idle, got.IdleSeconds)
+		}
+		if got.JCPUSeconds == nil || *got.JCPUSeconds != want.jcpu {
+			t.Errorf("Entry %d: expected JCPU %v, got %v", i, want.jcpu, got.JCPUSeconds)
+		}
+		if got.PCPUSeconds == nil || *got.PCPUSeconds != want.pcpu {
+			t.Errorf("Entry %d: expected PCPU %v, got %v", i, want.pcpu, got.PCPUSeconds)
+		}
+	}
+
+	// BSD prints idle time as minutes or hours:minutes
+	result, err = parser.Parse(`10:15  up 2 days, 3 hrs, 2 users, load averages: 1.52 1.63 1.71
+USER     TTY      FROM              LOGIN@  IDLE WHAT
+alice    s000     192.168.1.20     10:02       5 vim
+alice    s001     192.168.1.20     09:02    1:10 top`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	output = result.(WOutput)
+	if idle := output.Users[0].IdleSeconds; idle == nil || *idle != 300 {
+		t.Errorf("Expected idle 300, got %v", idle)
+	}
+	if idle := output.Users[1].IdleSeconds; idle == nil || *idle != 4200 {
+		t.Errorf("Expected idle 4200, got %v", idle)
+	}
+}
@@ -6,19 +6,44 @@ import (
 	"strings"
 )
 
-// WcParser parses wc command output
-type WcParser struct{}
+// WcParser parses wc command output. Flags holds the options wc was run
+// with (e.g. "-lw" or "--lines --chars") and decides which counts the
+// columns hold; wc always prints them in the order lines, words, characters,
+// bytes, maximum line length. Without Flags, three columns are taken as the
+// default lines, words and bytes.
+type WcParser struct {
+	Flags string
+}
 
-// WcEntry represents wc command output
+// WcEntry represents the counts for one file. Counts wc did not print are
+// omitted; Counts holds the raw columns when they cannot be attributed.
 type WcEntry struct {
-	Lines      int    `json:"lines"`
-	Words      int    `json:"words"`
-	Characters int    `json:"characters"`
-	Bytes      int    `json:"bytes"`
-	Filename   string `json:"filename,omitempty"`
-	Original   string `json:"original"`
+	Lines         *int   `json:"lines,omitempty"`
+	Words         *int   `json:"words,omitempty"`
+	Characters    *int   `json:"characters,omitempty"`
+	Bytes         *int   `json:"bytes,omitempty"`
+	MaxLineLength *int   `json:"max_line_length,omitempty"`
+	Counts        []int  `json:"counts,omitempty"`
+	Filename      string `json:"filename,omitempty"`
+	Original      string `json:"original"`
+}
+
+// WcOutput is the parsed wc output: one entry per file and, for several
+// files, the total row wc prints last
+type WcOutput struct {
+	Files []WcEntry `json:"files"`
+	Total *WcEntry  `json:"total,omitempty"`
 }
 
+// wc columns in output order
+const (
+	wcLines = iota
+	wcWords
+	wcChars
+	wcBytes
+	wcMaxLineLength
+)
+
 func (p *WcParser) Name() string {
 	return "wc"
 }
@@ -30,74 +55,140 @@ func (p *WcParser) Parse(input string) (interface{}, error) {
 	}
 
 	lines := splitLines(input)
-	var entries []WcEntry
+	columns := parseWcFlags(p.Flags)
+
+	// Without flags, the column count is the number of leading numbers all
+	// lines share, so a filename starting with a number is not taken as one
+	count := len(columns)
+	if columns == nil {
+		count = -1
+		for _, line := range lines {
+			n := 0
+			for _, field := range strings.Fields(line) {
+				if _, err := strconv.Atoi(field); err != nil {
+					break
+				}
+				n++
+			}
+			if n > 0 && (count == -1 || n < count) {
+				count = n
+			}
+		}
+		switch count {
+		case 3:
+			columns = []int{wcLines, wcWords, wcBytes}
+		case 4:
+			columns = []int{wcLines, wcWords, wcChars, wcBytes}
+		case 5:
+			columns = []int{wcLines, wcWords, wcChars, wcBytes, wcMaxLineLength}
+		}
+	}
+
+	output := WcOutput{
+		Files: []WcEntry{},
+	}
 
-	for _, line := range lines {
+	for i, line := range lines {
 		entry := WcEntry{
 			Original: line,
 		}
 
-		fields := strings.Fields(line)
-		if len(fields) == 0 {
+		rest := line
+		var counts []int
+		for len(counts) < count {
+			field, remainder, _ := strings.Cut(strings.TrimLeft(rest, " \t"), " ")
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				break
+			}
+			counts = append(counts, n)
+			rest = remainder
+		}
+		if len(counts) == 0 {
 			continue
 		}
+		entry.Filename = unquoteLsName(strings.TrimLeft(rest, " \t"))
 
-		// Standard wc output formats:
-		// wc -l: "123 filename" or just "123"
-		// wc -w: "456 filename" or just "456"
-		// wc -c: "789 filename" or just "789"
-		// wc (default): "123 456 789 filename" or "123 456 789"
-
-		switch len(fields) {
-		case 1:
-			// Single number, could be lines, words, or characters
-			if val, err := strconv.Atoi(fields[0]); err == nil {
-				entry.Lines = val
-				entry.Words = val
-				entry.Characters = val
-			}
-		case 2:
-			// Number and filename
-			if val, err := strconv.Atoi(fields[0]); err == nil {
-				entry.Lines = val
-				entry.Words = val
-				entry.Characters = val
-			}
-			entry.Filename = fields[1]
-		case 3:
-			// lines words chars (no filename)
-			if lines, err := strconv.Atoi(fields[0]); err == nil {
-				entry.Lines = lines
+		if len(counts) == len(columns) {
+			for j, column := range columns {
+				n := counts[j]
+				switch column {
+				case wcLines:
+					entry.Lines = &n
+				case wcWords:
+					entry.Words = &n
+				case wcChars:
+					entry.Characters = &n
+				case wcBytes:
+					entry.Bytes = &n
+				case wcMaxLineLength:
+					entry.MaxLineLength = &n
+				}
 			}
-			if words, err := strconv.Atoi(fields[1]); err == nil {
-				entry.Words = words
-			}
-			if chars, err := strconv.Atoi(fields[2]); err == nil {
-				entry.Characters = chars
-				entry.Bytes = chars
-			}
-		case 4:
-			// lines words chars filename
-			if lines, err := strconv.Atoi(fields[0]); err == nil {
-				entry.Lines = lines
-			}
-			if words, err := strconv.Atoi(fields[1]); err == nil {
-				entry.Words = words
+		} else {
+			entry.Counts = counts
+		}
+
+		// wc prints a total row after two or more files
+		if i == len(lines)-1 && i > 0 && entry.Filename == "total" {
+			output.Total = &entry
+			continue
+		}
+
+		output.Files = append(output.Files, entry)
+	}
+
+	return output, nil
+}
+
+// parseWcFlags returns the columns selected by wc options, in output order,
+// or nil when no counting option is given
+func parseWcFlags(flags string) []int {
+	selected := map[int]bool{}
+	for _, flag := range strings.Fields(flags) {
+		if strings.HasPrefix(flag, "--") {
+			switch strings.TrimPrefix(flag, "--") {
+			case "lines":
+				selected[wcLines] = true
+			case "words":
+				selected[wcWords] = true
+			case "chars":
+				selected[wcChars] = true
+			case "bytes":
+				selected[wcBytes] = true
+			case "max-line-length":
+				selected[wcMaxLineLength] = true
 			}
-			if chars, err := strconv.Atoi(fields[2]); err == nil {
-				entry.Characters = chars
-				entry.Bytes = chars
+			continue
+		}
+		if !strings.HasPrefix(flag, "-") {
+			continue
+		}
+		for _, c := range flag[1:] {
+			switch c {
+			case 'l':
+				selected[wcLines] = true
+			case 'w':
+				selected[wcWords] = true
+			case 'm':
+				selected[wcChars] = true
+			case 'c':
+				selected[wcBytes] = true
+			case 'L':
+				selected[wcMaxLineLength] = true
 			}
-			entry.Filename = fields[3]
 		}
-
-		entries = append(entries, entry)
 	}
 
-	// If only one entry and it looks like a total line, return just that entry
-	if len(entries) == 1 {
-		return entries[0], nil
+	if len(selected) == 0 {
+		return nil
 	}
 
-	return entries, nil
+	var columns []int
+	for column := wcLines; column <= wcMaxLineLength; column++ {
+		if selected[column] {
+			columns = append(columns, column)
+		}
+	}
+	return columns
 }
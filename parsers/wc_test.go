@@ -5,6 +5,15 @@ import (
 	"testing"
 )
 
+func wcCount(t *testing.T, name string, got *int, want int) {
+	t.Helper()
+	if got == nil {
+		t.Errorf("Expected %s %d, got none", name, want)
+	} else if *got != want {
+		t.Errorf("Expected %s %d, got %d", name, want, *got)
+	}
+}
+
 func TestWcParser(t *testing.T) {
 	parser := &WcParser{}
 
@@ -15,23 +24,21 @@ func TestWcParser(t *testing.T) {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	entry, ok := result.(WcEntry)
+	output, ok := result.(WcOutput)
 	if !ok {
-		t.Fatalf("Expected WcEntry, got %T", result)
-	}
-
-	// Test all fields for standard wc output
-	if entry.Lines != 123 {
-		t.Errorf("Expected lines 123, got %d", entry.Lines)
-	}
-	if entry.Words != 456 {
-		t.Errorf("Expected words 456, got %d", entry.Words)
+		t.Fatalf("Expected WcOutput, got %T", result)
 	}
-	if entry.Characters != 789 {
-		t.Errorf("Expected characters 789, got %d", entry.Characters)
+	if len(output.Files) != 1 || output.Total != nil {
+		t.Fatalf("Expected 1 file and no total, got %+v", output)
 	}
-	if entry.Bytes != 789 {
-		t.Errorf("Expected bytes 789, got %d", entry.Bytes)
+
+	// Default wc output is lines, words and bytes
+	entry := output.Files[0]
+	wcCount(t, "lines", entry.Lines, 123)
+	wcCount(t, "words", entry.Words, 456)
+	wcCount(t, "bytes", entry.Bytes, 789)
+	if entry.Characters != nil {
+		t.Errorf("Expected no character count, got %d", *entry.Characters)
 	}
 	if entry.Filename != "test.txt" {
 		t.Errorf("Expected filename 'test.txt', got '%s'", entry.Filename)
@@ -41,7 +48,7 @@ func TestWcParser(t *testing.T) {
 	}
 
 	// Test JSON marshaling
-	jsonData, err := json.Marshal(entry)
+	jsonData, err := json.Marshal(output)
 	if err != nil {
 		t.Fatalf("JSON marshal failed: %v", err)
 	}
@@ -63,68 +70,86 @@ func TestWcParserMultipleFiles(t *testing.T) {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	entries, ok := result.([]WcEntry)
-	if !ok {
-		t.Fatalf("Expected []WcEntry, got %T", result)
-	}
-
-	if len(entries) != 3 {
-		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	output := result.(WcOutput)
+	if len(output.Files) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(output.Files))
 	}
 
 	// Test first file
-	if entries[0].Lines != 10 {
-		t.Errorf("Expected lines 10, got %d", entries[0].Lines)
-	}
-	if entries[0].Filename != "file1.txt" {
-		t.Errorf("Expected filename 'file1.txt', got '%s'", entries[0].Filename)
+	wcCount(t, "lines", output.Files[0].Lines, 10)
+	if output.Files[0].Filename != "file1.txt" {
+		t.Errorf("Expected filename 'file1.txt', got '%s'", output.Files[0].Filename)
 	}
 
 	// Test second file
-	if entries[1].Words != 25 {
-		t.Errorf("Expected words 25, got %d", entries[1].Words)
-	}
-	if entries[1].Filename != "file2.txt" {
-		t.Errorf("Expected filename 'file2.txt', got '%s'", entries[1].Filename)
+	wcCount(t, "words", output.Files[1].Words, 25)
+	if output.Files[1].Filename != "file2.txt" {
+		t.Errorf("Expected filename 'file2.txt', got '%s'", output.Files[1].Filename)
 	}
 
 	// Test total line
-	if entries[2].Lines != 25 {
-		t.Errorf("Expected total lines 25, got %d", entries[2].Lines)
-	}
-	if entries[2].Filename != "total" {
-		t.Errorf("Expected filename 'total', got '%s'", entries[2].Filename)
+	if output.Total == nil {
+		t.Fatal("Expected a total")
 	}
+	wcCount(t, "total lines", output.Total.Lines, 25)
+	wcCount(t, "total bytes", output.Total.Bytes, 65)
 }
 
 func TestWcParserLinesOnly(t *testing.T) {
-	parser := &WcParser{}
+	parser := &WcParser{Flags: "-l"}
+
+	result, err := parser.Parse(`42 my file.txt`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
 
-	testInput := `42 myfile.txt`
+	entry := result.(WcOutput).Files[0]
+	wcCount(t, "lines", entry.Lines, 42)
+	if entry.Words != nil || entry.Bytes != nil {
+		t.Errorf("Expected only a line count, got %+v", entry)
+	}
+	if entry.Filename != "my file.txt" {
+		t.Errorf("Expected filename 'my file.txt', got '%s'", entry.Filename)
+	}
+}
+
+func TestWcParserFlags(t *testing.T) {
+	parser := &WcParser{Flags: "-wl -mL"}
+
+	testInput := ` 3  12  80  30 2024 report.txt
+ 1   4  20  20 notes
+ 4  16 100  30 total`
 
 	result, err := parser.Parse(testInput)
 	if err != nil {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	entry, ok := result.(WcEntry)
-	if !ok {
-		t.Fatalf("Expected WcEntry, got %T", result)
+	output := result.(WcOutput)
+	if len(output.Files) != 2 || output.Total == nil {
+		t.Fatalf("Expected 2 files and a total, got %+v", output)
 	}
 
-	// Test single number format (could be lines, words, or characters)
-	if entry.Lines != 42 {
-		t.Errorf("Expected lines 42, got %d", entry.Lines)
+	entry := output.Files[0]
+	wcCount(t, "lines", entry.Lines, 3)
+	wcCount(t, "words", entry.Words, 12)
+	wcCount(t, "characters", entry.Characters, 80)
+	wcCount(t, "max line length", entry.MaxLineLength, 30)
+	if entry.Bytes != nil {
+		t.Errorf("Expected no byte count, got %d", *entry.Bytes)
 	}
-	if entry.Words != 42 {
-		t.Errorf("Expected words 42, got %d", entry.Words)
+	if entry.Filename != "2024 report.txt" {
+		t.Errorf("Expected filename '2024 report.txt', got '%s'", entry.Filename)
 	}
-	if entry.Characters != 42 {
-		t.Errorf("Expected characters 42, got %d", entry.Characters)
-	}
-	if entry.Filename != "myfile.txt" {
-		t.Errorf("Expected filename 'myfile.txt', got '%s'", entry.Filename)
+
+	parser.Flags = "--lines --bytes"
+	result, err = parser.Parse(" 3 80 a.txt")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
 	}
+	entry = result.(WcOutput).Files[0]
+	wcCount(t, "lines", entry.Lines, 3)
+	wcCount(t, "bytes", entry.Bytes, 80)
 }
 
 func TestWcParserNoFilename(t *testing.T) {
@@ -137,21 +162,12 @@ func TestWcParserNoFilename(t *testing.T) {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	entry, ok := result.(WcEntry)
-	if !ok {
-		t.Fatalf("Expected WcEntry, got %T", result)
-	}
+	entry := result.(WcOutput).Files[0]
 
 	// Test without filename
-	if entry.Lines != 5 {
-		t.Errorf("Expected lines 5, got %d", entry.Lines)
-	}
-	if entry.Words != 10 {
-		t.Errorf("Expected words 10, got %d", entry.Words)
-	}
-	if entry.Characters != 25 {
-		t.Errorf("Expected characters 25, got %d", entry.Characters)
-	}
+	wcCount(t, "lines", entry.Lines, 5)
+	wcCount(t, "words", entry.Words, 10)
+	wcCount(t, "bytes", entry.Bytes, 25)
 	if entry.Filename != "" {
 		t.Errorf("Expected empty filename, got '%s'", entry.Filename)
 	}
@@ -160,38 +176,29 @@ func TestWcParserNoFilename(t *testing.T) {
 func TestWcParserSingleNumber(t *testing.T) {
 	parser := &WcParser{}
 
-	testInput := `100`
-
-	result, err := parser.Parse(testInput)
+	result, err := parser.Parse(`100`)
 	if err != nil {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	entry, ok := result.(WcEntry)
-	if !ok {
-		t.Fatalf("Expected WcEntry, got %T", result)
-	}
-
-	// Test single number (ambiguous - could be any count)
-	if entry.Lines != 100 {
-		t.Errorf("Expected lines 100, got %d", entry.Lines)
-	}
-	if entry.Words != 100 {
-		t.Errorf("Expected words 100, got %d", entry.Words)
+	// Without flags a single count cannot be attributed
+	entry := result.(WcOutput).Files[0]
+	if entry.Lines != nil || entry.Words != nil || entry.Bytes != nil {
+		t.Errorf("Expected no attributed counts, got %+v", entry)
 	}
-	if entry.Characters != 100 {
-		t.Errorf("Expected characters 100, got %d", entry.Characters)
+	if len(entry.Counts) != 1 || entry.Counts[0] != 100 {
+		t.Errorf("Expected raw count 100, got %v", entry.Counts)
 	}
 }
 
 func TestWcParserEmpty(t *testing.T) {
 	parser := &WcParser{}
-	
+
 	_, err := parser.Parse("")
 	if err == nil {
 		t.Error("Expected error for empty input")
 	}
-	
+
 	_, err = parser.Parse("   ")
 	if err == nil {
 		t.Error("Expected error for whitespace-only input")
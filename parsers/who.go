@@ -2,77 +2,197 @@ package parsers
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
-// WhoParser parses who command output
-type WhoParser struct{}
+// WhoParser parses who command output, including who -a (boot time,
+// run-level, LOGIN and dead process entries, message status, idle time and
+// PIDs), who -b, who -r, who -u and the -H column headers. Times are read
+// in Location (UTC when nil), and times printed without a year are placed
+// relative to ReferenceTime (the current time when zero), as for w.
+type WhoParser struct {
+	ReferenceTime time.Time
+	Location      *time.Location
+}
 
-// WhoEntry represents a single who output entry
+// WhoEntry represents a single who output entry. LoginTime is the time of
+// the event for boot and run-level entries. MessageStatus is "+" when the
+// terminal accepts write messages, "-" when it does not and "?" when
+// unknown.
 type WhoEntry struct {
-	User     string    `json:"user"`
-	TTY      string    `json:"tty"`
-	LoginTime time.Time `json:"login_time"`
-	Host     string    `json:"host,omitempty"`
-	Comment  string    `json:"comment,omitempty"`
+	User             string    `json:"user"`
+	TTY              string    `json:"tty"`
+	Type             string    `json:"type,omitempty"`
+	MessageStatus    string    `json:"message_status,omitempty"`
+	LoginTime        time.Time `json:"login_time"`
+	Idle             string    `json:"idle,omitempty"`
+	IdleSeconds      *float64  `json:"idle_seconds,omitempty"`
+	PID              int       `json:"pid,omitempty"`
+	Host             string    `json:"host,omitempty"`
+	ID               string    `json:"id,omitempty"`
+	RunLevel         string    `json:"run_level,omitempty"`
+	PreviousRunLevel string    `json:"previous_run_level,omitempty"`
+	TermStatus       *int      `json:"term_status,omitempty"`
+	ExitStatus       *int      `json:"exit_status,omitempty"`
+	Comment          string    `json:"comment,omitempty"`
 }
 
+// Entry types reported by who -a
+const (
+	WhoTypeUser     = "user"
+	WhoTypeLogin    = "login"
+	WhoTypeBoot     = "boot"
+	WhoTypeRunLevel = "run_level"
+	WhoTypeDead     = "dead"
+)
+
 func (p *WhoParser) Name() string {
 	return "who"
 }
 
 func (p *WhoParser) Parse(input string) (interface{}, error) {
+	raw := input
 	input = strings.TrimSpace(input)
 	if input == "" {
 		return nil, fmt.Errorf("empty input")
 	}
 
-	lines := splitLines(input)
+	// Login time: "2023-01-15 14:30", "2023-01-15 14:30:05" or "Jan 15 14:30"
+	timeRe := regexp.MustCompile(`\b(\d{4}-\d{2}-\d{2} \d{2}:\d{2}(?::\d{2})?|[A-Z][a-z]{2} +\d{1,2} \d{2}:\d{2})\b`)
+	runLevelRe := regexp.MustCompile(`^run-level (\S+)$`)
+
+	clock := newLsClock(p.ReferenceTime, p.Location)
+
 	var entries []WhoEntry
 
-	for _, line := range lines {
+	// Lines are kept untrimmed; boot, run-level and dead process entries
+	// have no user and start with blanks
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimRight(line, " \t\r")
 		fields := splitFields(line)
-		if len(fields) < 3 {
+		if len(fields) < 3 || isWhoHeader(fields) {
 			continue
 		}
 
-		entry := WhoEntry{}
-		entry.User = fields[0]
-		entry.TTY = fields[1]
-
-		// Parse date and time (format varies)
-		// Example: "2023-01-15 14:30" or "Jan 15 14:30"
-		dateTimeStr := ""
-		if len(fields) >= 4 {
-			dateTimeStr = fields[2] + " " + fields[3]
+		loc := timeRe.FindStringIndex(line)
+		if loc == nil {
+			continue
 		}
 
-		if parsedTime, err := parseWhoDateTime(dateTimeStr); err == nil {
+		entry := WhoEntry{}
+		if parsedTime, err := parseWhoDateTime(line[loc[0]:loc[1]], clock); err == nil {
 			entry.LoginTime = parsedTime
 		}
 
-		// Extract host if present (usually in parentheses)
-		restFields := fields[4:]
-		for _, field := range restFields {
-			if strings.HasPrefix(field, "(") && strings.HasSuffix(field, ")") {
-				entry.Host = strings.Trim(field, "()")
-			} else {
-				if entry.Comment == "" {
-					entry.Comment = field
-				} else {
-					entry.Comment += " " + field
-				}
+		// Before the time: [user] [message status] line
+		prefix := strings.TrimSpace(line[:loc[0]])
+		hasUser := line != "" && line[0] != ' ' && line[0] != '\t'
+		if m := runLevelRe.FindStringSubmatch(prefix); m != nil || prefix == "system boot" {
+			entry.TTY = prefix
+			entry.Type = WhoTypeBoot
+			if m != nil {
+				entry.Type = WhoTypeRunLevel
+				entry.RunLevel = m[1]
+			}
+		} else {
+			prefixFields := splitFields(prefix)
+			if hasUser && len(prefixFields) > 0 {
+				entry.User = prefixFields[0]
+				prefixFields = prefixFields[1:]
+			}
+			if len(prefixFields) > 1 && (prefixFields[0] == "+" || prefixFields[0] == "-" || prefixFields[0] == "?") {
+				entry.MessageStatus = prefixFields[0]
+				prefixFields = prefixFields[1:]
+			}
+			entry.TTY = strings.Join(prefixFields, " ")
+
+			switch {
+			case entry.User == "LOGIN":
+				entry.Type = WhoTypeLogin
+			case entry.User == "":
+				entry.Type = WhoTypeDead
+			default:
+				entry.Type = WhoTypeUser
 			}
 		}
 
+		parseWhoRest(&entry, splitFields(line[loc[1]:]))
 		entries = append(entries, entry)
 	}
 
 	return entries, nil
 }
 
-func parseWhoDateTime(dateStr string) (time.Time, error) {
+// parseWhoRest parses the columns following the time: the idle time and
+// PID (who -u, who -a), then the host in parentheses, id=, term= and exit=
+// values and last= for run-level entries. The idle time is only recognised
+// in front of a PID, so a lone word after the time stays a comment.
+func parseWhoRest(entry *WhoEntry, fields []string) {
+	if len(fields) > 1 && isNumeric(fields[1]) {
+		if seconds, ok := parseWhoIdle(fields[0]); ok {
+			entry.Idle = fields[0]
+			entry.IdleSeconds = seconds
+			fields = fields[1:]
+		}
+	}
+	if len(fields) > 0 && isNumeric(fields[0]) {
+		entry.PID, _ = strconv.Atoi(fields[0])
+		fields = fields[1:]
+	}
+
+	var comment []string
+	for _, field := range fields {
+		key, value, _ := strings.Cut(field, "=")
+		switch {
+		case strings.HasPrefix(field, "(") && strings.HasSuffix(field, ")"):
+			entry.Host = strings.Trim(field, "()")
+		case key == "id" && value != "":
+			entry.ID = value
+		case key == "last" && entry.Type == WhoTypeRunLevel:
+			entry.PreviousRunLevel = value
+		case key == "term" && isNumeric(value):
+			n, _ := strconv.Atoi(value)
+			entry.TermStatus = &n
+		case key == "exit" && isNumeric(value):
+			n, _ := strconv.Atoi(value)
+			entry.ExitStatus = &n
+		default:
+			comment = append(comment, field)
+		}
+	}
+	entry.Comment = strings.Join(comment, " ")
+}
+
+// parseWhoIdle decodes who's idle column: "." for activity within the last
+// minute, "HH:MM" and "old" for more than a day, which has no exact value
+func parseWhoIdle(value string) (*float64, bool) {
+	switch value {
+	case ".":
+		seconds := 0.0
+		return &seconds, true
+	case "old":
+		return nil, true
+	}
+	hours, minutes, found := strings.Cut(value, ":")
+	if !found || !isNumeric(hours) || !isNumeric(minutes) {
+		return nil, false
+	}
+	h, _ := strconv.Atoi(hours)
+	m, _ := strconv.Atoi(minutes)
+	seconds := float64(h*3600 + m*60)
+	return &seconds, true
+}
+
+// isWhoHeader reports whether fields are the who -H column headers
+// ("NAME LINE TIME ...") or the BSD "USER LINE WHEN" equivalent
+func isWhoHeader(fields []string) bool {
+	return (fields[0] == "NAME" || fields[0] == "USER") && fields[1] == "LINE"
+}
+
+func parseWhoDateTime(dateStr string, clock lsClock) (time.Time, error) {
 	formats := []string{
 		"2006-01-02 15:04",
 		"Jan 2 15:04",
@@ -81,13 +201,8 @@ func parseWhoDateTime(dateStr string) (time.Time, error) {
 	}
 
 	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			// If year is not specified, assume current year
-			if t.Year() == 0 {
-				now := time.Now()
-				t = t.AddDate(now.Year(), 0, 0)
-			}
-			return t, nil
+		if t, err := time.ParseInLocation(format, dateStr, clock.location); err == nil {
+			return clock.inferYear(t), nil
 		}
 	}
 
@@ -3,6 +3,7 @@ package parsers
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestWhoParser(t *testing.T) {
@@ -199,3 +200,127 @@ func TestWhoParserEmpty(t *testing.T) {
 		t.Errorf("Expected 0 entries for insufficient fields, got %d", len(entries))
 	}
 }
+
+func TestWhoParserAll(t *testing.T) {
+	parser := &WhoParser{}
+
+	testInput := `NAME       LINE         TIME             IDLE          PID COMMENT  EXIT
+           system boot  2024-01-15 10:45
+           run-level 5  2024-01-15 10:46                   last=S
+LOGIN      tty1         2024-01-15 10:46              1234 id=tty1
+root     - tty2         2024-01-15 11:00 00:05        1456
+user     + pts/0        2024-01-15 14:30   .          2345 (192.168.1.100)
+user     + pts/1        2024-01-13 08:00  old         2400 (10.0.0.8)
+           pts/2        2024-01-15 12:00              3456 id=ts/2  term=0 exit=0`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]WhoEntry)
+	if len(entries) != 7 {
+		t.Fatalf("Expected 7 entries, got %d", len(entries))
+	}
+
+	boot := entries[0]
+	if boot.Type != WhoTypeBoot || boot.User != "" || boot.LoginTime.Format("2006-01-02 15:04") != "2024-01-15 10:45" {
+		t.Errorf("Unexpected boot entry %+v", boot)
+	}
+
+	runLevel := entries[1]
+	if runLevel.Type != WhoTypeRunLevel || runLevel.RunLevel != "5" || runLevel.PreviousRunLevel != "S" {
+		t.Errorf("Unexpected run-level entry %+v", runLevel)
+	}
+
+	login := entries[2]
+	if login.Type != WhoTypeLogin || login.TTY != "tty1" || login.PID != 1234 || login.ID != "tty1" {
+		t.Errorf("Unexpected LOGIN entry %+v", login)
+	}
+
+	root := entries[3]
+	if root.User != "root" || root.MessageStatus != "-" || root.TTY != "tty2" || root.Idle != "00:05" ||
+		root.IdleSeconds == nil || *root.IdleSeconds != 300 || root.PID != 1456 {
+		t.Errorf("Unexpected user entry %+v", root)
+	}
+
+	user := entries[4]
+	if user.Type != WhoTypeUser || user.MessageStatus != "+" || user.IdleSeconds == nil || *user.IdleSeconds != 0 ||
+		user.PID != 2345 || user.Host != "192.168.1.100" {
+		t.Errorf("Unexpected user entry %+v", user)
+	}
+
+	if old := entries[5]; old.Idle != "old" || old.IdleSeconds != nil || old.PID != 2400 {
+		t.Errorf("Unexpected idle entry %+v", old)
+	}
+
+	dead := entries[6]
+	if dead.Type != WhoTypeDead || dead.User != "" || dead.TTY != "pts/2" || dead.PID != 3456 || dead.ID != "ts/2" ||
+		dead.TermStatus == nil || *dead.TermStatus != 0 || dead.ExitStatus == nil || *dead.ExitStatus != 0 {
+		t.Errorf("Unexpected dead process entry %+v", dead)
+	}
+}
+
+func TestWhoParserBootAndRunLevel(t *testing.T) {
+	parser := &WhoParser{}
+
+	result, err := parser.Parse("         system boot  2024-01-15 10:45\n")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	entries := result.([]WhoEntry)
+	if len(entries) != 1 || entries[0].Type != WhoTypeBoot || entries[0].TTY != "system boot" {
+		t.Errorf("Unexpected who -b output %+v", entries)
+	}
+
+	result, err = parser.Parse("         run-level 3  2024-01-15 10:46")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	entries = result.([]WhoEntry)
+	if len(entries) != 1 || entries[0].Type != WhoTypeRunLevel || entries[0].RunLevel != "3" {
+		t.Errorf("Unexpected who -r output %+v", entries)
+	}
+}
+
+func TestWhoParserHeader(t *testing.T) {
+	parser := &WhoParser{}
+
+	testInput := `NAME     LINE         TIME             COMMENT
+user     pts/0        2024-01-15 14:30 (192.168.1.100)`
+
+	result, err := parser.Parse(testInput)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]WhoEntry)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].User != "user" || entries[0].Host != "192.168.1.100" || entries[0].Type != WhoTypeUser {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+}
+
+func TestWhoParserYearInference(t *testing.T) {
+	// BSD who prints no year; a December login seen in January is last year's
+	parser := &WhoParser{ReferenceTime: time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC)}
+
+	result, err := parser.Parse(`alice    console  Dec 28 08:12
+bob      ttys000  Jan  2 17:40`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	entries := result.([]WhoEntry)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if got := entries[0].LoginTime.Format("2006-01-02 15:04"); got != "2023-12-28 08:12" {
+		t.Errorf("Expected 2023-12-28 08:12, got %s", got)
+	}
+	if got := entries[1].LoginTime.Format("2006-01-02 15:04"); got != "2024-01-02 17:40" {
+		t.Errorf("Expected 2024-01-02 17:40, got %s", got)
+	}
+}
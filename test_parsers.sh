@@ -114,10 +114,10 @@ echo "To run Go unit tests:"
 echo "  go test ./parsers -v"
 echo
 echo "Available parsers:"
-echo "  System: uname, uptime, who, w, id, env"
+echo "  System: uname, osrelease, lsbrelease, hostnamectl, uptime, who, w, last, lastb, lastlog, id, env, dmesg"
 echo "  Process: ps, free, vmstat"
 echo "  Network: ping, netstat, arp, dig"
-echo "  Files: ls, df, du, mount, lsblk, find, stat"
+echo "  Files: ls, df, du, mount, lsblk, blkid, fdisk, parted, find, stat"
 echo "  Services: systemctl"
 echo "  Utilities: date, wc"
-echo "  Config: hosts, passwd"
+echo "  Config: hosts, resolvconf, nsswitch, passwd, group, shadow, gshadow, fstab, crypttab"